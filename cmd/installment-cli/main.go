@@ -1,46 +1,407 @@
 package main
 
 import (
-	"flag"
+	"context"
+	"errors"
 	"fmt"
+	"log"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/icoder-new/installment-cli/internal/delivery/cli"
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/i18n"
+	"github.com/icoder-new/installment-cli/internal/infra/document"
+	"github.com/icoder-new/installment-cli/internal/infra/email"
+	"github.com/icoder-new/installment-cli/internal/infra/messenger"
 	"github.com/icoder-new/installment-cli/internal/infra/sms"
+	"github.com/icoder-new/installment-cli/internal/infra/storage"
+	"github.com/icoder-new/installment-cli/internal/infra/webhook"
+	"github.com/icoder-new/installment-cli/internal/phone"
 	"github.com/icoder-new/installment-cli/internal/usecase"
 )
 
+const (
+	defaultContractsFile = "contracts.json"
+	defaultRemindersFile = "reminders.json"
+	defaultCustomersFile = "customers.json"
+	defaultOutboxFile    = "sms_outbox.json"
+	defaultSMSLogFile    = "sms_log.json"
+	defaultDeadWebhooks  = "webhooks_dead.json"
+	defaultWebhookQueue  = "webhooks_pending.json"
+	defaultConsentsFile  = "consents.json"
+	defaultSMTPFrom      = "installment@localhost"
+	defaultShellHistory  = "~/.installment_history"
+
+	defaultRateLimit  = "3/1h"
+	defaultQuietHours = "21:00-08:00"
+
+	defaultSMSTimeout = 30 * time.Second
+	// shutdownGrace is how long an interrupted command may take to wind down,
+	// e.g. while blocked on a prompt, before the process exits.
+	shutdownGrace = 3 * time.Second
+)
+
 func main() {
-	smsSender := sms.NewConsoleSender()
-	calculator := usecase.NewInstallmentCalculator(smsSender)
-	handler := cli.NewHandler(calculator)
+	lang, args, langErr := cli.ExtractLanguage(os.Args[1:])
+	plain, args := cli.ExtractPlain(args)
+	catalog := i18n.New(i18n.Detect(lang, os.Getenv))
+	exitOnError(catalog, langErr)
+
+	templatesDir := os.Getenv("INSTALLMENT_TEMPLATES_DIR")
+	renderer, err := sms.NewTemplateRenderer(templatesDir)
+	exitOnError(catalog, err)
+
+	agreementRenderer, err := document.NewRenderer(templatesDir)
+	exitOnError(catalog, err)
+
+	policy, err := compactionPolicyFromEnv(catalog)
+	exitOnError(catalog, err)
+
+	smsTimeout, err := durationFromEnv(catalog, "INSTALLMENT_SMS_TIMEOUT", defaultSMSTimeout)
+	exitOnError(catalog, err)
+
+	ctx := interruptibleContext(catalog)
+
+	smsLogger := log.New(os.Stderr, "", 0)
+	gateway, failover, err := smsGatewayFromEnv(smsTimeout, smsLogger)
+	exitOnError(catalog, err)
+
+	sendPolicy, err := sendPolicyFromEnv(catalog)
+	exitOnError(catalog, err)
+
+	webhookConfig, err := webhookConfigFromEnv()
+	exitOnError(catalog, err)
+
+	consentTTL, err := durationFromEnv(catalog, "INSTALLMENT_CONSENT_TTL", usecase.DefaultConsentTTL)
+	exitOnError(catalog, err)
+
+	consentAttempts, err := positiveIntFromEnv(catalog, "INSTALLMENT_CONSENT_MAX_ATTEMPTS", usecase.DefaultConsentMaxAttempts)
+	exitOnError(catalog, err)
+
+	phones, err := phone.NewParser(strings.Split(os.Getenv("INSTALLMENT_PHONE_COUNTRIES"), ",")...)
+	exitOnError(catalog, err)
+
+	contractRepository := storage.NewFileContractRepository(
+		envOrDefault("INSTALLMENT_CONTRACTS_FILE", defaultContractsFile))
+	reminderLog := storage.NewFileReminderLog(
+		envOrDefault("INSTALLMENT_REMINDERS_FILE", defaultRemindersFile))
+	customerRepository := storage.NewFileCustomerRepository(
+		envOrDefault("INSTALLMENT_CUSTOMERS_FILE", defaultCustomersFile))
+	outbox := storage.NewFileSMSOutbox(
+		envOrDefault("INSTALLMENT_SMS_OUTBOX_FILE", defaultOutboxFile))
+	sendLog := storage.NewFileSMSSendLog(
+		envOrDefault("INSTALLMENT_SMS_LOG_FILE", defaultSMSLogFile), sendPolicy.RateWindow)
+	consentRepository := storage.NewFileConsentRepository(
+		envOrDefault("INSTALLMENT_CONSENTS_FILE", defaultConsentsFile))
+	pendingWebhooks := storage.NewFilePendingWebhookRepository(
+		envOrDefault("INSTALLMENT_WEBHOOKS_PENDING_FILE", defaultWebhookQueue))
+	deadWebhooks := storage.NewFileDeadWebhookRepository(
+		envOrDefault("INSTALLMENT_WEBHOOKS_DEAD_FILE", defaultDeadWebhooks))
+
+	webhooks := webhook.NewDispatcher(webhookConfig, pendingWebhooks, deadWebhooks, log.New(os.Stderr, "", 0))
+	events := usecase.NewEventBus(log.New(os.Stderr, "", 0))
+
+	compactingSender := sms.NewCompactingSender(gateway, policy, smsLogger)
+	smsSender := sms.NewPolicySender(
+		compactingSender,
+		sendPolicy,
+		customerRepository,
+		outbox,
+		sendLog,
+		smsLogger,
+	)
+
+	notifier := usecase.NewNotifier(renderer, customerRepository, notificationChannels(smsSender, smsTimeout)...)
+	calculator := usecase.NewInstallmentCalculator(events)
+	contractService := usecase.NewContractService(contractRepository, events)
+	reminderService := usecase.NewReminderService(contractRepository, reminderLog, notifier)
+	customerService := usecase.NewCustomerService(customerRepository)
+	consentService := usecase.NewConsentService(consentRepository, smsSender, renderer,
+		customerRepository, contractService, consentTTL, consentAttempts)
+	deliveryService := usecase.NewDeliveryService(contractRepository)
+	outboxService := usecase.NewOutboxService(outbox, smsSender, contractRepository)
+	agreementService := usecase.NewAgreementService(contractRepository, agreementRenderer, storeFromEnv())
+	events.Subscribe(usecase.NewNotificationSubscriber(contractRepository, notifier, events).Handle,
+		domain.EventNameInstallmentConfirmed,
+		domain.EventNameInstallmentCancelled,
+		domain.EventNamePaymentReceived,
+	)
+	events.SubscribeAsync(webhooks.Handle, webhook.Events...)
+
+	printer := cli.NewResultPrinter(os.Stdout, cli.DetectStyle(os.Stdout, plain, os.Getenv), catalog)
+	handler := cli.NewHandler(calculator, contractService, customerService, consentService, phones, printer, os.Stdin, os.Stdout, catalog)
+
+	contracts := cli.NewContractsHandler(contractService, deliveryService, agreementService, printer, os.Stdout, catalog)
+	app := cli.NewApp(os.Stdout, os.Stderr, catalog, "calc",
+		cli.Command{Name: "calc", Run: handler.Run},
+		cli.Command{Name: "quote", Run: handler.Quote},
+		cli.Command{Name: "batch", Run: cli.NewBatchHandler(calculator, phones, os.Stdin, os.Stdout, catalog).Run},
+		cli.Command{Name: "contracts", Run: contracts.Run},
+		cli.Command{Name: "contract", Run: contracts.Run, Hidden: true},
+		cli.Command{Name: "pay", Run: cli.NewPayHandler(contractService, printer, catalog).Run},
+		cli.Command{Name: "cancel", Run: cli.NewCancelHandler(contractService, printer, catalog).Run},
+		cli.Command{Name: "remind", Run: cli.NewRemindHandler(reminderService, os.Stdout, catalog).Run},
+		cli.Command{Name: "customers", Run: cli.NewCustomersHandler(customerService, phones, os.Stdout, catalog).Run},
+		cli.Command{Name: "sms", Run: cli.NewSMSHandler(templatesDir, policy, customerService, outboxService, phones, os.Stdout, catalog).Run},
+		cli.Command{Name: "webhooks", Run: cli.NewWebhooksHandler(webhooks, os.Stdout, catalog).Run},
+		cli.Command{Name: "serve", Run: cli.NewServeHandler(deliveryService, calculator, consentService, webhooks, phones,
+			os.Getenv("INSTALLMENT_DLR_TOKEN"), os.Getenv("INSTALLMENT_API_TOKEN"), catalog).Run},
+		cli.Command{Name: "config", Run: cli.NewConfigHandler(settings(), os.Stdout, catalog).Run},
+	)
+	shell := cli.NewShellHandler(app, handler, os.Stdin, os.Stdout, shellHistoryFile(), catalog)
+	completion := cli.NewCompletionHandler(app, handler, os.Stdout, catalog)
+	app.Register(
+		cli.Command{Name: "shell", Run: shell.Run},
+		cli.Command{Name: "completion", Run: completion.Run},
+		cli.Command{Name: cli.CompleteCommand, Run: completion.Complete, Hidden: true},
+	)
+
+	code := app.Run(ctx, args)
+	events.Wait()
+	if failover != nil {
+		logProviderMetrics(failover, smsLogger)
+	}
+	os.Exit(code)
+}
+
+// settings lists the environment variables read at startup for the config
+// command.
+func settings() []cli.Setting {
+	return []cli.Setting{
+		{Name: "INSTALLMENT_CONTRACTS_FILE", Default: defaultContractsFile},
+		{Name: "INSTALLMENT_REMINDERS_FILE", Default: defaultRemindersFile},
+		{Name: "INSTALLMENT_CUSTOMERS_FILE", Default: defaultCustomersFile},
+		{Name: "INSTALLMENT_CONSENTS_FILE", Default: defaultConsentsFile},
+		{Name: "INSTALLMENT_SMS_OUTBOX_FILE", Default: defaultOutboxFile},
+		{Name: "INSTALLMENT_SMS_LOG_FILE", Default: defaultSMSLogFile},
+		{Name: "INSTALLMENT_WEBHOOKS_FILE"},
+		{Name: "INSTALLMENT_WEBHOOKS_PENDING_FILE", Default: defaultWebhookQueue},
+		{Name: "INSTALLMENT_WEBHOOKS_DEAD_FILE", Default: defaultDeadWebhooks},
+		{Name: "INSTALLMENT_TEMPLATES_DIR"},
+		{Name: "INSTALLMENT_SMS_PROVIDERS_FILE"},
+		{Name: "INSTALLMENT_SMS_TIMEOUT", Default: defaultSMSTimeout.String()},
+		{Name: "INSTALLMENT_SMS_RATE_LIMIT", Default: defaultRateLimit},
+		{Name: "INSTALLMENT_SMS_QUIET_HOURS", Default: defaultQuietHours},
+		{Name: "INSTALLMENT_SMS_MAX_SEGMENTS"},
+		{Name: "INSTALLMENT_SMS_TRANSLITERATE"},
+		{Name: "INSTALLMENT_CONSENT_TTL", Default: usecase.DefaultConsentTTL.String()},
+		{Name: "INSTALLMENT_CONSENT_MAX_ATTEMPTS", Default: strconv.Itoa(usecase.DefaultConsentMaxAttempts)},
+		{Name: "INSTALLMENT_PHONE_COUNTRIES", Default: phone.HomeCountry},
+		{Name: "INSTALLMENT_SHELL_HISTORY", Default: defaultShellHistory},
+		{Name: "INSTALLMENT_PLAIN"},
+		{Name: "INSTALLMENT_STORE_NAME"},
+		{Name: "INSTALLMENT_STORE_ADDRESS"},
+		{Name: "INSTALLMENT_STORE_PHONE"},
+		{Name: "INSTALLMENT_STORE_TAX_ID"},
+		{Name: "INSTALLMENT_SMTP_ADDR"},
+		{Name: "INSTALLMENT_SMTP_FROM", Default: defaultSMTPFrom},
+		{Name: "INSTALLMENT_SMTP_USERNAME"},
+		{Name: "INSTALLMENT_SMTP_PASSWORD", Secret: true},
+		{Name: "INSTALLMENT_MESSENGER_WEBHOOK_URL"},
+		{Name: "INSTALLMENT_MESSENGER_TOKEN", Secret: true},
+		{Name: "INSTALLMENT_DLR_TOKEN", Secret: true},
+		{Name: "INSTALLMENT_API_TOKEN", Secret: true},
+	}
+}
+
+// shellHistoryFile returns where the shell keeps its command history; "~/" is
+// the home directory.
+func shellHistoryFile() string {
+	path := envOrDefault("INSTALLMENT_SHELL_HISTORY", defaultShellHistory)
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		path = filepath.Join(home, rest)
+	}
+	return path
+}
+
+// smsGatewayFromEnv returns the console sender unless INSTALLMENT_SMS_PROVIDERS_FILE
+// names a provider list, in which case the providers are chained for failover.
+func smsGatewayFromEnv(timeout time.Duration, logger *log.Logger) (domain.SMSSender, *sms.FailoverSender, error) {
+	path := os.Getenv("INSTALLMENT_SMS_PROVIDERS_FILE")
+	if path == "" {
+		return sms.NewTimeoutSender(sms.NewConsoleSender(), timeout), nil, nil
+	}
+
+	config, err := sms.LoadProvidersConfig(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	failover, err := sms.NewProviderChain(config, timeout, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	return failover, failover, nil
+}
+
+// webhookConfigFromEnv reads the subscriptions from INSTALLMENT_WEBHOOKS_FILE;
+// without it no webhooks are sent.
+func webhookConfigFromEnv() (webhook.Config, error) {
+	path := os.Getenv("INSTALLMENT_WEBHOOKS_FILE")
+	if path == "" {
+		return webhook.Config{}, nil
+	}
+	return webhook.LoadConfig(path)
+}
+
+// storeFromEnv returns the seller details printed in agreements.
+func storeFromEnv() domain.Store {
+	return domain.Store{
+		Name:    os.Getenv("INSTALLMENT_STORE_NAME"),
+		Address: os.Getenv("INSTALLMENT_STORE_ADDRESS"),
+		Phone:   os.Getenv("INSTALLMENT_STORE_PHONE"),
+		TaxID:   os.Getenv("INSTALLMENT_STORE_TAX_ID"),
+	}
+}
+
+// notificationChannels returns SMS plus the email and messenger channels
+// configured in the environment. Email and messenger sends are bounded by the
+// same timeout as SMS.
+func notificationChannels(smsSender domain.SMSSender, timeout time.Duration) []domain.Notifier {
+	channels := []domain.Notifier{sms.NewChannel(smsSender)}
 
-	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr, `Использование: %s [ПАРАМЕТРЫ]
+	if addr := os.Getenv("INSTALLMENT_SMTP_ADDR"); addr != "" {
+		channels = append(channels, email.NewSMTPNotifier(email.SMTPConfig{
+			Addr:     addr,
+			From:     envOrDefault("INSTALLMENT_SMTP_FROM", defaultSMTPFrom),
+			Username: os.Getenv("INSTALLMENT_SMTP_USERNAME"),
+			Password: os.Getenv("INSTALLMENT_SMTP_PASSWORD"),
+			Timeout:  timeout,
+		}))
+	}
 
-Параметры:
-  -h, --help             Показать эту справку
-  -i, --interactive      Включить интерактивный режим
-  -p, --product ТОВАР    Тип товара (Смартфон, Компьютер, Телевизор)
-  -c, --cost ЦЕНА       Цена товара в сомони
-  -n, --number НОМЕР    Номер телефона клиента
-  -m, --months МЕСЯЦЫ   Срок рассрочки в месяцах
+	if url := os.Getenv("INSTALLMENT_MESSENGER_WEBHOOK_URL"); url != "" {
+		channels = append(channels, messenger.NewWebhookNotifier(url, os.Getenv("INSTALLMENT_MESSENGER_TOKEN"), timeout))
+	}
 
-Примеры:
-  %[1]s -p Смартфон -c 1000 -n +992001234567 -m 6
-  %[1]s --product=Компьютер --cost=2000 --number=+992001234567 --months=12
-  %[1]s -i
-  %[1]s --interactive
+	return channels
+}
+
+func logProviderMetrics(failover *sms.FailoverSender, logger *log.Logger) {
+	for _, metrics := range failover.Metrics() {
+		if metrics.Attempts == 0 && metrics.Skipped == 0 {
+			continue
+		}
+
+		var average time.Duration
+		if metrics.Attempts > 0 {
+			average = metrics.Latency / time.Duration(metrics.Attempts)
+		}
+
+		logger.Printf("смс-провайдер %s: цепь %s, попыток %d, успешно %d, ошибок %d, отклонено %d, пропущено %d, среднее время %s",
+			metrics.Name, metrics.State, metrics.Attempts, metrics.Successes, metrics.Failures,
+			metrics.Rejected, metrics.Skipped, average.Round(time.Millisecond))
+	}
+}
+
+// interruptibleContext is cancelled on Ctrl+C or SIGTERM so that in-flight
+// SMS sends are abandoned. A second signal, or a command that does not stop
+// within shutdownGrace, terminates the process.
+func interruptibleContext(catalog *i18n.Catalog) context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		<-signals
+		cancel()
+
+		select {
+		case <-signals:
+		case <-time.After(shutdownGrace):
+		}
+
+		fmt.Fprintf(os.Stderr, "\n%s%s\n", catalog.T("error.prefix"), catalog.Error(context.Canceled))
+		os.Exit(130)
+	}()
+
+	return ctx
+}
+
+func exitOnError(catalog *i18n.Catalog, err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s%s\n", catalog.T("error.prefix"), catalog.Error(err))
+		os.Exit(cli.ExitCode(err))
+	}
+}
 
-Для интерактивного режима можно указать часть параметров, 
-а остальные ввести в диалоговом режиме:
-  %[1]s -p Телевизор -i
+func compactionPolicyFromEnv(catalog *i18n.Catalog) (sms.CompactionPolicy, error) {
+	var policy sms.CompactionPolicy
 
-`, os.Args[0])
+	if value := os.Getenv("INSTALLMENT_SMS_MAX_SEGMENTS"); value != "" {
+		maxSegments, err := strconv.Atoi(value)
+		if err != nil || maxSegments < 0 {
+			return policy, envValueError(catalog, "INSTALLMENT_SMS_MAX_SEGMENTS", value)
+		}
+		policy.MaxSegments = maxSegments
 	}
 
-	if err := handler.Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
-		os.Exit(1)
+	policy.Transliterate = os.Getenv("INSTALLMENT_SMS_TRANSLITERATE") == "1"
+	return policy, nil
+}
+
+func sendPolicyFromEnv(catalog *i18n.Catalog) (sms.SendPolicy, error) {
+	policy := sms.SendPolicy{Location: sms.DushanbeLocation()}
+
+	var err error
+	rateLimit := envOrDefault("INSTALLMENT_SMS_RATE_LIMIT", defaultRateLimit)
+	policy.RateLimit, policy.RateWindow, err = sms.ParseRateLimit(rateLimit)
+	if err != nil {
+		return policy, envValueError(catalog, "INSTALLMENT_SMS_RATE_LIMIT", rateLimit)
+	}
+
+	quietHours := envOrDefault("INSTALLMENT_SMS_QUIET_HOURS", defaultQuietHours)
+	policy.QuietStart, policy.QuietEnd, err = sms.ParseQuietHours(quietHours)
+	if err != nil {
+		return policy, envValueError(catalog, "INSTALLMENT_SMS_QUIET_HOURS", quietHours)
+	}
+	return policy, nil
+}
+
+func durationFromEnv(catalog *i18n.Catalog, key string, fallback time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return 0, envValueError(catalog, key, value)
+	}
+	return duration, nil
+}
+
+func positiveIntFromEnv(catalog *i18n.Catalog, key string, fallback int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return 0, envValueError(catalog, key, value)
+	}
+	return n, nil
+}
+
+func envValueError(catalog *i18n.Catalog, key, value string) error {
+	return errors.New(catalog.T("error.env_value", key, value))
+}
+
+func envOrDefault(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
 	}
+	return fallback
 }
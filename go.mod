@@ -2,11 +2,17 @@ module github.com/icoder-new/installment-cli
 
 go 1.24.4
 
-require github.com/stretchr/testify v1.10.0
+require (
+	github.com/jung-kurt/gofpdf v1.16.2
+	github.com/stretchr/testify v1.10.0
+	golang.org/x/term v0.37.0
+	golang.org/x/text v0.31.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/stretchr/objx v0.5.2 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
+	golang.org/x/sys v0.38.0 // indirect
 )
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/icoder-new/installment-cli/internal/i18n"
+)
+
+// Keys of the answers file.
+const (
+	AnswerProduct     = "product"
+	AnswerPrice       = "price"
+	AnswerPhone       = "phone"
+	AnswerMonths      = "months"
+	AnswerConsentCode = "consent_code"
+)
+
+// Answers holds the replies to the interactive questions by key, so that a
+// scripted run gives the same result every time:
+//
+//	product: Смартфон
+//	price: 1000
+//	phone: "+992 93 123 45 67"
+//	months: 6
+type Answers map[string]string
+
+// LoadAnswers reads an answers file. Unknown keys are rejected so that a typo
+// does not silently fall back to the input.
+func LoadAnswers(path string, catalog *i18n.Catalog) (Answers, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", catalog.T("error.answers_open"), err)
+	}
+
+	var file struct {
+		Product     string `yaml:"product"`
+		Price       string `yaml:"price"`
+		Phone       string `yaml:"phone"`
+		Months      string `yaml:"months"`
+		ConsentCode string `yaml:"consent_code"`
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil && len(bytes.TrimSpace(data)) > 0 {
+		return nil, fmt.Errorf("%s: %w", catalog.T("error.answers_parse", path), err)
+	}
+
+	answers := Answers{}
+	for key, value := range map[string]string{
+		AnswerProduct:     file.Product,
+		AnswerPrice:       file.Price,
+		AnswerPhone:       file.Phone,
+		AnswerMonths:      file.Months,
+		AnswerConsentCode: file.ConsentCode,
+	} {
+		if value != "" {
+			answers[key] = value
+		}
+	}
+	return answers, nil
+}
@@ -0,0 +1,256 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/icoder-new/installment-cli/internal/i18n"
+)
+
+// Exit codes of the program.
+const (
+	ExitOK          = 0
+	ExitError       = 1
+	ExitUsage       = 2
+	ExitInterrupted = 130
+)
+
+const helpCommand = "help"
+
+// UsageError reports a wrong invocation: an unknown command, a bad flag or a
+// missing argument. It exits with ExitUsage instead of ExitError. Usage is
+// the flag help of the command, when the flags were at fault.
+type UsageError struct {
+	Message string
+	Usage   string
+}
+
+func (e *UsageError) Error() string {
+	return e.Message
+}
+
+func usageError(message string) error {
+	return &UsageError{Message: message}
+}
+
+// helpError carries the help a command produced for -h, so that the caller
+// decides where to print it. It matches flag.ErrHelp.
+type helpError struct {
+	text string
+}
+
+func (e *helpError) Error() string { return flag.ErrHelp.Error() }
+func (e *helpError) Unwrap() error { return flag.ErrHelp }
+
+// printHelp writes the help carried by err, if any.
+func printHelp(w io.Writer, err error) {
+	var help *helpError
+	if errors.As(err, &help) {
+		fmt.Fprint(w, help.text)
+	}
+}
+
+// Command is a subcommand. Its one-line description is the catalog message
+// "command.<Name>"; the detailed help is printed by Run itself for -h.
+// Hidden commands are left out of the help and of completion.
+type Command struct {
+	Name   string
+	Run    func(ctx context.Context, args []string) error
+	Hidden bool
+}
+
+// App dispatches the first argument to a subcommand. Arguments that do not
+// start with a command name go to the fallback command, so that the original
+// "installment-cli -p ... -c ..." invocation keeps working as calc.
+type App struct {
+	commands []Command
+	fallback string
+	out      io.Writer
+	errOut   io.Writer
+	catalog  *i18n.Catalog
+}
+
+func NewApp(out, errOut io.Writer, catalog *i18n.Catalog, fallback string, commands ...Command) *App {
+	return &App{
+		commands: commands,
+		fallback: fallback,
+		out:      out,
+		errOut:   errOut,
+		catalog:  catalog,
+	}
+}
+
+// Register adds commands after the app is created, for commands such as the
+// shell that dispatch back to it.
+func (a *App) Register(commands ...Command) {
+	a.commands = append(a.commands, commands...)
+}
+
+// Commands returns the names of the visible commands in the order they were
+// registered.
+func (a *App) Commands() []string {
+	names := make([]string, 0, len(a.commands)+1)
+	for _, command := range a.commands {
+		if !command.Hidden {
+			names = append(names, command.Name)
+		}
+	}
+	return append(names, helpCommand)
+}
+
+// Run executes args, prints the help a command asked for, reports an error
+// and returns the exit code.
+func (a *App) Run(ctx context.Context, args []string) int {
+	err := a.Dispatch(ctx, args)
+	code := ExitCode(err)
+
+	var usage *UsageError
+	switch code {
+	case ExitOK:
+		printHelp(a.out, err)
+	case ExitUsage:
+		if errors.As(err, &usage) {
+			fmt.Fprint(a.errOut, usage.Usage)
+		}
+		fmt.Fprintf(a.errOut, "%s%s\n", a.catalog.T("error.prefix"), a.catalog.Error(err))
+		fmt.Fprintln(a.errOut, a.catalog.T("app.help_hint", programName()))
+	default:
+		fmt.Fprintf(a.errOut, "%s%s\n", a.catalog.T("error.prefix"), a.catalog.Error(err))
+	}
+	return code
+}
+
+// Dispatch runs the command named by the first argument.
+func (a *App) Dispatch(ctx context.Context, args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		if len(args) > 0 && isHelpFlag(args[0]) {
+			a.printUsage()
+			return nil
+		}
+		return a.run(ctx, a.fallback, args)
+	}
+
+	if args[0] == helpCommand {
+		return a.help(ctx, args[1:])
+	}
+	return a.run(ctx, args[0], args[1:])
+}
+
+func (a *App) run(ctx context.Context, name string, args []string) error {
+	command, ok := a.lookup(name)
+	if !ok {
+		return usageError(a.catalog.T("error.unknown_command", name))
+	}
+	return command.Run(ctx, args)
+}
+
+// help prints the overview or, for "help COMMAND", the command's own help.
+func (a *App) help(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		a.printUsage()
+		return nil
+	}
+
+	command, ok := a.lookup(args[0])
+	if !ok {
+		return usageError(a.catalog.T("error.unknown_command", args[0]))
+	}
+
+	// Every command parses its flags first, so -h prints the help and stops.
+	// Commands with actions answer it with their usage line.
+	err := command.Run(ctx, []string{"-h"})
+	var usage *UsageError
+	if errors.As(err, &usage) {
+		fmt.Fprintln(a.out, usage.Message)
+		return nil
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		printHelp(a.out, err)
+		return nil
+	}
+	return err
+}
+
+func (a *App) lookup(name string) (Command, bool) {
+	for _, command := range a.commands {
+		if command.Name == name {
+			return command, true
+		}
+	}
+	return Command{}, false
+}
+
+func (a *App) printUsage() {
+	fmt.Fprintf(a.out, a.catalog.T("usage"), programName())
+	for _, name := range a.Commands() {
+		fmt.Fprintf(a.out, "  %-10s %s\n", name, a.catalog.T("command."+name))
+	}
+	fmt.Fprintf(a.out, a.catalog.T("usage.footer"), programName(), a.fallback)
+}
+
+// ExitCode maps the error returned by a command to the exit code.
+func ExitCode(err error) int {
+	var usage *UsageError
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return ExitOK
+	case errors.Is(err, context.Canceled):
+		return ExitInterrupted
+	case errors.As(err, &usage):
+		return ExitUsage
+	default:
+		return ExitError
+	}
+}
+
+func isHelpFlag(arg string) bool {
+	switch arg {
+	case "-h", "-help", "--help":
+		return true
+	}
+	return false
+}
+
+func programName() string {
+	return filepath.Base(os.Args[0])
+}
+
+// newFlagSet returns the flag set of a subcommand. Its help lists the flags
+// under a localized title. The help is collected rather than printed:
+// parseFlags hands it back in the error.
+func newFlagSet(catalog *i18n.Catalog, name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), catalog.T("app.flags", programName(), name))
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses args and turns flag errors into usage errors. Unexpected
+// positional arguments are rejected too.
+func parseFlags(catalog *i18n.Catalog, fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		var output string
+		if buffer, ok := fs.Output().(*bytes.Buffer); ok {
+			output = buffer.String()
+		}
+		if errors.Is(err, flag.ErrHelp) {
+			return &helpError{text: output}
+		}
+		return &UsageError{Message: err.Error(), Usage: output}
+	}
+
+	if fs.NArg() > 0 {
+		return usageError(catalog.T("error.unexpected_argument", fs.Arg(0)))
+	}
+	return nil
+}
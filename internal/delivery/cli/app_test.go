@@ -0,0 +1,139 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/icoder-new/installment-cli/internal/delivery/cli"
+	"github.com/icoder-new/installment-cli/internal/i18n"
+)
+
+type recordedCall struct {
+	command string
+	args    []string
+}
+
+func newTestApp(calls *[]recordedCall, results map[string]error) *cli.App {
+	command := func(name string) cli.Command {
+		return cli.Command{Name: name, Run: func(ctx context.Context, args []string) error {
+			*calls = append(*calls, recordedCall{command: name, args: args})
+			return results[name]
+		}}
+	}
+	return cli.NewApp(io.Discard, io.Discard, i18n.New(i18n.Russian), "calc", command("calc"), command("pay"))
+}
+
+func TestAppDispatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    recordedCall
+		wantErr bool
+	}{
+		{
+			name: "command with flags",
+			args: []string{"pay", "--contract", "1", "--amount", "10"},
+			want: recordedCall{command: "pay", args: []string{"--contract", "1", "--amount", "10"}},
+		},
+		{
+			name: "flags without a command go to the fallback",
+			args: []string{"-p", "Смартфон", "-c", "1000"},
+			want: recordedCall{command: "calc", args: []string{"-p", "Смартфон", "-c", "1000"}},
+		},
+		{
+			name: "no arguments go to the fallback",
+			args: nil,
+			want: recordedCall{command: "calc", args: nil},
+		},
+		{
+			name: "help for a command asks the command",
+			args: []string{"help", "pay"},
+			want: recordedCall{command: "pay", args: []string{"-h"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []recordedCall
+			app := newTestApp(&calls, map[string]error{"pay": nil})
+
+			err := app.Dispatch(context.Background(), tt.args)
+
+			require.NoError(t, err)
+			require.Len(t, calls, 1)
+			assert.Equal(t, tt.want, calls[0])
+		})
+	}
+}
+
+func TestAppUnknownCommand(t *testing.T) {
+	var calls []recordedCall
+	app := newTestApp(&calls, nil)
+
+	err := app.Dispatch(context.Background(), []string{"refund"})
+
+	var usage *cli.UsageError
+	require.ErrorAs(t, err, &usage)
+	assert.Contains(t, usage.Message, "refund")
+	assert.Empty(t, calls)
+}
+
+func TestAppCommands(t *testing.T) {
+	var calls []recordedCall
+	app := newTestApp(&calls, nil)
+
+	assert.Equal(t, []string{"calc", "pay", "help"}, app.Commands())
+}
+
+func TestAppWritesToItsOutputs(t *testing.T) {
+	t.Setenv("INSTALLMENT_TEST_FILE", "")
+	catalog := i18n.New(i18n.Russian)
+
+	var out, errOut bytes.Buffer
+	config := cli.NewConfigHandler([]cli.Setting{{Name: "INSTALLMENT_TEST_FILE", Default: "test.json"}}, &out, catalog)
+	app := cli.NewApp(&out, &errOut, catalog, "config", cli.Command{Name: "config", Run: config.Run})
+
+	assert.Equal(t, cli.ExitOK, app.Run(context.Background(), []string{"config"}))
+	assert.Contains(t, out.String(), "INSTALLMENT_TEST_FILE  test.json")
+
+	out.Reset()
+	assert.Equal(t, cli.ExitOK, app.Run(context.Background(), []string{"help"}))
+	assert.Contains(t, out.String(), "config")
+
+	out.Reset()
+	assert.Equal(t, cli.ExitOK, app.Run(context.Background(), []string{"config", "-h"}))
+	assert.Contains(t, out.String(), "config [ПАРАМЕТРЫ]")
+
+	out.Reset()
+	assert.Equal(t, cli.ExitUsage, app.Run(context.Background(), []string{"config", "--verbose"}))
+	assert.Empty(t, out.String())
+	assert.Contains(t, errOut.String(), "config [ПАРАМЕТРЫ]", "the flag help comes with the error")
+	assert.Contains(t, errOut.String(), "-verbose")
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "success", err: nil, want: cli.ExitOK},
+		{name: "help", err: flag.ErrHelp, want: cli.ExitOK},
+		{name: "usage", err: &cli.UsageError{Message: "лишний аргумент"}, want: cli.ExitUsage},
+		{name: "interrupted", err: context.Canceled, want: cli.ExitInterrupted},
+		{name: "failure", err: errors.New("договор не найден"), want: cli.ExitError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, cli.ExitCode(tt.err))
+		})
+	}
+}
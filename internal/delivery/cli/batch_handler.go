@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/i18n"
+	"github.com/icoder-new/installment-cli/internal/phone"
+	"github.com/icoder-new/installment-cli/internal/usecase"
+)
+
+var batchHeader = []string{"product", "price", "phone", "months", "total_payment", "overpayment", "error"}
+
+// BatchHandler quotes a list of installments read from CSV with the columns
+// product, price, phone and months, e.g. to print a price list. No contracts
+// are opened.
+type BatchHandler struct {
+	calculator *usecase.InstallmentCalculator
+	phones     *phone.Parser
+	in         io.Reader
+	out        io.Writer
+	catalog    *i18n.Catalog
+}
+
+func NewBatchHandler(calculator *usecase.InstallmentCalculator, phones *phone.Parser, in io.Reader, out io.Writer, catalog *i18n.Catalog) *BatchHandler {
+	return &BatchHandler{
+		calculator: calculator,
+		phones:     phones,
+		in:         in,
+		out:        out,
+		catalog:    catalog,
+	}
+}
+
+func (h *BatchHandler) Run(ctx context.Context, args []string) error {
+	var path string
+
+	fs := newFlagSet(h.catalog, "batch")
+	fs.StringVar(&path, "file", "-", h.catalog.T("flag.batch_file"))
+
+	if err := parseFlags(h.catalog, fs, args); err != nil {
+		return err
+	}
+
+	input := h.in
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", h.catalog.T("error.batch_open"), err)
+		}
+		defer file.Close()
+		input = file
+	}
+
+	reader := csv.NewReader(input)
+	reader.FieldsPerRecord = 4
+	reader.TrimLeadingSpace = true
+
+	writer := csv.NewWriter(h.out)
+	defer writer.Flush()
+	if err := writer.Write(batchHeader); err != nil {
+		return err
+	}
+
+	var rows, failed int
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", h.catalog.T("error.batch_read"), err)
+		}
+		if rows == 0 && isBatchHeader(record) {
+			continue
+		}
+
+		rows++
+		row, err := h.quote(ctx, record)
+		if err != nil {
+			failed++
+			row = append(record, "", "", h.catalog.Error(err))
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+
+	if failed > 0 {
+		return errors.New(h.catalog.T("batch.failed", failed, rows))
+	}
+	return nil
+}
+
+func (h *BatchHandler) quote(ctx context.Context, record []string) ([]string, error) {
+	productType, ok := resolveProductType(record[0])
+	if !ok {
+		return nil, productError(h.catalog, h.catalog.Error(domain.ErrInvalidProductType), record[0])
+	}
+
+	price, err := parseAmount(h.catalog, record[1])
+	if err != nil {
+		return nil, err
+	}
+
+	phoneNumber, err := parsePhoneNumber(h.phones, h.catalog, record[2])
+	if err != nil {
+		return nil, err
+	}
+
+	months, err := strconv.Atoi(strings.TrimSpace(record[3]))
+	if err != nil {
+		return nil, errors.New(h.catalog.T("error.not_a_number"))
+	}
+
+	product := domain.Product{
+		Type:         productType,
+		Price:        price,
+		PhoneNumber:  phoneNumber,
+		PeriodMonths: months,
+	}
+
+	totalPayment, err := h.calculator.CalculateInstallment(ctx, product)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{
+		h.catalog.Product(productType),
+		formatPrice(price),
+		phoneNumber,
+		strconv.Itoa(months),
+		formatPrice(totalPayment),
+		formatPrice(totalPayment - price),
+		"",
+	}, nil
+}
+
+func isBatchHeader(record []string) bool {
+	return strings.EqualFold(strings.TrimSpace(record[0]), batchHeader[0])
+}
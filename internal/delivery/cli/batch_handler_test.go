@@ -0,0 +1,35 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/icoder-new/installment-cli/internal/delivery/cli"
+	"github.com/icoder-new/installment-cli/internal/i18n"
+	"github.com/icoder-new/installment-cli/internal/phone"
+	"github.com/icoder-new/installment-cli/internal/usecase"
+)
+
+func TestBatchHandlerReadsAndWritesItsStreams(t *testing.T) {
+	parser, err := phone.NewParser()
+	require.NoError(t, err)
+	calculator := usecase.NewInstallmentCalculator(usecase.NewEventBus(log.New(io.Discard, "", 0)))
+
+	in := strings.NewReader("product,price,phone,months\nСмартфон,1000,931234567,6\n")
+	var out bytes.Buffer
+	handler := cli.NewBatchHandler(calculator, parser, in, &out, i18n.New(i18n.Russian))
+
+	require.NoError(t, handler.Run(context.Background(), nil))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, "product,price,phone,months,total_payment,overpayment,error", lines[0])
+	assert.Equal(t, "Смартфон,1000.00,+992931234567,6,1030.00,30.00,", lines[1])
+}
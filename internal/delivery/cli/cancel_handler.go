@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/icoder-new/installment-cli/internal/i18n"
+	"github.com/icoder-new/installment-cli/internal/usecase"
+)
+
+type CancelHandler struct {
+	contracts *usecase.ContractService
+	printer   *ResultPrinter
+	catalog   *i18n.Catalog
+}
+
+func NewCancelHandler(contracts *usecase.ContractService, printer *ResultPrinter, catalog *i18n.Catalog) *CancelHandler {
+	return &CancelHandler{
+		contracts: contracts,
+		printer:   printer,
+		catalog:   catalog,
+	}
+}
+
+func (h *CancelHandler) Run(ctx context.Context, args []string) error {
+	var contractID, reason string
+
+	fs := newFlagSet(h.catalog, "cancel")
+	fs.StringVar(&contractID, "contract", "", h.catalog.T("flag.contract"))
+	fs.StringVar(&reason, "reason", "", h.catalog.T("flag.reason"))
+
+	if err := parseFlags(h.catalog, fs, args); err != nil {
+		return err
+	}
+
+	if contractID == "" || reason == "" {
+		return usageError(h.catalog.T("cancel.required"))
+	}
+
+	contract, err := h.contracts.Cancel(ctx, contractID, reason)
+	if err != nil && contract.ID == "" {
+		return fmt.Errorf("%s: %w", h.catalog.T("error.cancel"), err)
+	}
+
+	h.printer.PrintCancellation(contract)
+	return err
+}
@@ -0,0 +1,379 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/i18n"
+)
+
+// CompleteCommand is the hidden command the completion scripts call with the
+// words typed so far, the last one being the word under the cursor.
+const CompleteCommand = "__complete"
+
+// flagCommands are the commands whose flags are defined by
+// FlagParser.defineFlags, with whether they take the contract options.
+var flagCommands = map[string]bool{"calc": true, "quote": false}
+
+// globalFlags are removed from the arguments before a command runs.
+var globalFlags = []completion{
+	{value: "--lang", description: "Язык интерфейса (ru, tg, en)"},
+	{value: "--plain", description: "Вывод без рамок и цвета"},
+}
+
+type flagKind int
+
+const (
+	kindOther flagKind = iota
+	kindBool
+	kindProduct
+	kindPeriod
+	kindLanguage
+	kindFile
+)
+
+// flagSpec is a flag of calc or quote as defineFlags defines it.
+type flagSpec struct {
+	name  string
+	usage string
+	kind  flagKind
+}
+
+func (s flagSpec) option() string {
+	if len(s.name) == 1 {
+		return "-" + s.name
+	}
+	return "--" + s.name
+}
+
+type completion struct {
+	value       string
+	description string
+}
+
+// CompletionHandler prints completion scripts for bash, zsh and fish and
+// answers their requests for the words that fit at the cursor.
+type CompletionHandler struct {
+	app     *App
+	handler *Handler
+	out     io.Writer
+	catalog *i18n.Catalog
+}
+
+func NewCompletionHandler(app *App, handler *Handler, out io.Writer, catalog *i18n.Catalog) *CompletionHandler {
+	return &CompletionHandler{
+		app:     app,
+		handler: handler,
+		out:     out,
+		catalog: catalog,
+	}
+}
+
+// Run prints the completion script for the shell named in args.
+func (h *CompletionHandler) Run(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return usageError(h.catalog.T("completion.usage"))
+	}
+
+	script, ok := completionScripts[args[0]]
+	if !ok {
+		return usageError(h.catalog.T("completion.usage"))
+	}
+
+	var files []string
+	for _, spec := range h.flagSpecs(true) {
+		if spec.kind == kindFile {
+			files = append(files, spec.name)
+		}
+	}
+
+	program := programName()
+	return script.Execute(h.out, map[string]any{
+		"Program":  program,
+		"Function": strings.Map(identifierRune, program),
+		"Command":  CompleteCommand,
+		"Files":    files,
+	})
+}
+
+// Complete prints one candidate per line, with a description after a tab
+// when there is one. Nothing is printed when the shell should complete file
+// names itself.
+func (h *CompletionHandler) Complete(ctx context.Context, args []string) error {
+	current := ""
+	if len(args) > 0 {
+		current, args = args[len(args)-1], args[:len(args)-1]
+	}
+
+	for _, candidate := range h.candidates(args, current) {
+		if candidate.description == "" {
+			fmt.Fprintln(h.out, candidate.value)
+			continue
+		}
+		fmt.Fprintf(h.out, "%s\t%s\n", candidate.value, candidate.description)
+	}
+	return nil
+}
+
+func (h *CompletionHandler) candidates(before []string, current string) []completion {
+	if len(before) == 0 && !strings.HasPrefix(current, "-") {
+		return h.commands(current)
+	}
+
+	command := h.app.fallback
+	if len(before) > 0 && !strings.HasPrefix(before[0], "-") {
+		command, before = before[0], before[1:]
+	}
+
+	switch command {
+	case helpCommand:
+		if len(before) == 0 {
+			return h.commands(current)
+		}
+		return nil
+	case "completion":
+		if len(before) == 0 {
+			return matching(current, "", completions(slices.Sorted(maps.Keys(completionScripts))))
+		}
+		return nil
+	}
+
+	contract, ok := flagCommands[command]
+	if !ok {
+		return nil
+	}
+	return h.flagCandidates(before, current, contract)
+}
+
+// flagCandidates completes the flags of calc and quote and their values:
+// product names, the periods allowed for the product given so far and
+// languages.
+func (h *CompletionHandler) flagCandidates(before []string, current string, contract bool) []completion {
+	specs := h.flagSpecs(contract)
+
+	// bash splits "--product=Смарт" into "--product", "=" and "Смарт".
+	if len(before) >= 2 && before[len(before)-1] == "=" {
+		if spec, ok := lookupFlag(specs, before[len(before)-2]); ok {
+			return matching(current, "", h.values(spec, before, contract))
+		}
+	}
+
+	if name, value, ok := strings.Cut(current, "="); ok && strings.HasPrefix(current, "-") {
+		if spec, ok := lookupFlag(specs, name); ok {
+			return matching(value, name+"=", h.values(spec, before, contract))
+		}
+		return nil
+	}
+
+	if len(before) > 0 {
+		if spec, ok := lookupFlag(specs, before[len(before)-1]); ok && spec.kind != kindBool {
+			return matching(current, "", h.values(spec, before, contract))
+		}
+	}
+
+	if !strings.HasPrefix(current, "-") {
+		return nil
+	}
+
+	options := make([]completion, 0, len(specs)+len(globalFlags))
+	for _, spec := range specs {
+		options = append(options, completion{value: spec.option(), description: spec.usage})
+	}
+	return matching(current, "", append(options, globalFlags...))
+}
+
+func (h *CompletionHandler) values(spec flagSpec, before []string, contract bool) []completion {
+	switch spec.kind {
+	case kindProduct:
+		return completions([]string{
+			h.catalog.Product(domain.Smartphone),
+			h.catalog.Product(domain.Computer),
+			h.catalog.Product(domain.TV),
+		})
+	case kindPeriod:
+		product := h.typedProduct(before, contract)
+		periods := product.AllowedPeriods()
+		values := make([]string, 0, len(periods))
+		for _, period := range periods {
+			values = append(values, strconv.Itoa(period))
+		}
+		return completions(values)
+	case kindLanguage:
+		values := make([]string, 0, len(i18n.Languages))
+		for _, lang := range i18n.Languages {
+			values = append(values, string(lang))
+		}
+		return completions(values)
+	}
+	return nil
+}
+
+// typedProduct returns the product given by the flags typed so far. Without
+// one every period is allowed.
+func (h *CompletionHandler) typedProduct(before []string, contract bool) domain.Product {
+	flags := &Flags{}
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	h.handler.flagParser.defineFlags(fs, flags, contract)
+	// The flags before a bad or unfinished one are still set.
+	_ = fs.Parse(before)
+
+	productType, _ := resolveProductType(flags.ProductType)
+	return domain.Product{Type: productType}
+}
+
+func (h *CompletionHandler) commands(current string) []completion {
+	names := h.app.Commands()
+	commands := make([]completion, 0, len(names))
+	for _, name := range names {
+		commands = append(commands, completion{value: name, description: h.catalog.T("command." + name)})
+	}
+	return matching(current, "", commands)
+}
+
+// flagSpecs lists the flags defineFlags defines. What a flag holds is found
+// by setting a probe value and seeing which field takes it, so that the
+// completions follow the flag definitions.
+func (h *CompletionHandler) flagSpecs(contract bool) []flagSpec {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	h.handler.flagParser.defineFlags(fs, &Flags{}, contract)
+
+	var specs []flagSpec
+	fs.VisitAll(func(f *flag.Flag) {
+		specs = append(specs, flagSpec{name: f.Name, usage: f.Usage, kind: h.flagKind(f.Name, contract)})
+	})
+	return specs
+}
+
+func (h *CompletionHandler) flagKind(name string, contract bool) flagKind {
+	probe := &Flags{}
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	h.handler.flagParser.defineFlags(fs, probe, contract)
+
+	if value, ok := fs.Lookup(name).Value.(interface{ IsBoolFlag() bool }); ok && value.IsBoolFlag() {
+		return kindBool
+	}
+	if err := fs.Set(name, "1"); err != nil {
+		return kindOther
+	}
+
+	switch {
+	case probe.ProductType != "":
+		return kindProduct
+	case probe.Months != 0:
+		return kindPeriod
+	case probe.SMSLanguage != "":
+		return kindLanguage
+	case probe.Answers != "":
+		return kindFile
+	}
+	return kindOther
+}
+
+// lookupFlag finds the flag named by arg, given with one or two dashes.
+func lookupFlag(specs []flagSpec, arg string) (flagSpec, bool) {
+	if !strings.HasPrefix(arg, "-") {
+		return flagSpec{}, false
+	}
+
+	name := strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-")
+	for _, spec := range specs {
+		if spec.name == name {
+			return spec, true
+		}
+	}
+	return flagSpec{}, false
+}
+
+// matching keeps the candidates that start with word, ignoring case, and
+// puts prefix before each of them.
+func matching(word, prefix string, candidates []completion) []completion {
+	var matches []completion
+	for _, candidate := range candidates {
+		if strings.HasPrefix(strings.ToLower(candidate.value), strings.ToLower(word)) {
+			candidate.value = prefix + candidate.value
+			matches = append(matches, candidate)
+		}
+	}
+	return matches
+}
+
+func completions(values []string) []completion {
+	candidates := make([]completion, 0, len(values))
+	for _, value := range values {
+		candidates = append(candidates, completion{value: value})
+	}
+	return candidates
+}
+
+func identifierRune(r rune) rune {
+	if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+		return r
+	}
+	return '_'
+}
+
+// The scripts ask the program itself for the candidates, so that they do not
+// go out of date when flags change.
+var completionScripts = map[string]*template.Template{
+	"bash": template.Must(template.New("bash").Parse(`# bash completion for {{.Program}}
+# source <({{.Program}} completion bash)
+
+_{{.Function}}() {
+    local line
+    COMPREPLY=()
+    while IFS= read -r line; do
+        COMPREPLY+=("${line%%$'\t'*}")
+    done < <({{.Program}} {{.Command}} "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null)
+}
+
+complete -o default -F _{{.Function}} {{.Program}}
+`)),
+	"zsh": template.Must(template.New("zsh").Parse(`# zsh completion for {{.Program}}
+# source <({{.Program}} completion zsh)
+
+_{{.Function}}() {
+    local -a values display
+    local line
+    for line in "${(@f)$({{.Program}} {{.Command}} "${(@)words[2,CURRENT]}" 2>/dev/null)}"; do
+        [[ -z $line ]] && continue
+        values+=("${line%%$'\t'*}")
+        if [[ $line == *$'\t'* ]]; then
+            display+=("${line%%$'\t'*}  -- ${line#*$'\t'}")
+        else
+            display+=("$line")
+        fi
+    done
+
+    if (( ${#values} )); then
+        compadd -l -d display -a values
+    else
+        _files
+    fi
+}
+
+compdef _{{.Function}} {{.Program}}
+`)),
+	"fish": template.Must(template.New("fish").Parse(`# fish completion for {{.Program}}
+# {{.Program}} completion fish > ~/.config/fish/completions/{{.Program}}.fish
+
+function __{{.Function}}_complete
+    set -l tokens (commandline -opc)
+    set -l current (commandline -ct)
+    {{.Program}} {{.Command}} $tokens[2..-1] "$current" 2>/dev/null
+end
+
+complete -c {{.Program}} -f -a '(__{{.Function}}_complete)'
+{{- range .Files}}
+complete -c {{$.Program}} -l {{.}} -r -F
+{{- end}}
+`)),
+}
@@ -0,0 +1,165 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/icoder-new/installment-cli/internal/delivery/cli"
+	"github.com/icoder-new/installment-cli/internal/i18n"
+	"github.com/icoder-new/installment-cli/internal/phone"
+)
+
+func newCompletionHandler(t *testing.T, out *bytes.Buffer) *cli.CompletionHandler {
+	t.Helper()
+	return newLocalizedCompletionHandler(t, out, i18n.Russian)
+}
+
+func newLocalizedCompletionHandler(t *testing.T, out *bytes.Buffer, lang i18n.Language) *cli.CompletionHandler {
+	t.Helper()
+
+	catalog := i18n.New(lang)
+	parser, err := phone.NewParser()
+	require.NoError(t, err)
+
+	handler := cli.NewHandler(nil, nil, nil, nil, parser, cli.NewResultPrinter(out, cli.Style{}, catalog),
+		strings.NewReader(""), out, catalog)
+	app := cli.NewApp(io.Discard, io.Discard, catalog, "calc",
+		cli.Command{Name: "calc", Run: handler.Run},
+		cli.Command{Name: "quote", Run: handler.Quote},
+		cli.Command{Name: "pay"},
+	)
+	completion := cli.NewCompletionHandler(app, handler, out, catalog)
+	app.Register(
+		cli.Command{Name: "completion", Run: completion.Run},
+		cli.Command{Name: cli.CompleteCommand, Run: completion.Complete, Hidden: true},
+	)
+	return completion
+}
+
+func TestCompletionHandlerComplete(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{
+			name: "commands",
+			args: []string{"c"},
+			want: []string{"calc", "completion"},
+		},
+		{
+			name: "flags of calc without a command",
+			args: []string{"--s"},
+			want: []string{"--sms-lang"},
+		},
+		{
+			name: "quote has no contract flags",
+			args: []string{"quote", "--s"},
+		},
+		{
+			name: "product names ignoring case",
+			args: []string{"calc", "-p", "ком"},
+			want: []string{"Компьютер"},
+		},
+		{
+			name: "periods of the product given",
+			args: []string{"quote", "-p", "Смартфон", "-c", "1000", "-m", ""},
+			want: []string{"3", "6", "9"},
+		},
+		{
+			name: "every period without a product",
+			args: []string{"quote", "--months", "1"},
+			want: []string{"12", "18"},
+		},
+		{
+			name: "value after an equals sign",
+			args: []string{"--product=3", "--months="},
+			want: []string{"--months=3", "--months=6", "--months=9", "--months=12", "--months=18"},
+		},
+		{
+			name: "value split by bash at the equals sign",
+			args: []string{"--sms-lang", "=", "t"},
+			want: []string{"tg"},
+		},
+		{
+			name: "files are left to the shell",
+			args: []string{"--answers", ""},
+		},
+		{
+			name: "shells",
+			args: []string{"completion", ""},
+			want: []string{"bash", "fish", "zsh"},
+		},
+		{
+			name: "other commands",
+			args: []string{"pay", "-"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			completion := newCompletionHandler(t, &out)
+			require.NoError(t, completion.Complete(context.Background(), tt.args))
+
+			var got []string
+			for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+				if value, _, _ := strings.Cut(line, "\t"); value != "" {
+					got = append(got, value)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCompletionHandlerHidesItself(t *testing.T) {
+	var out bytes.Buffer
+	completion := newCompletionHandler(t, &out)
+	require.NoError(t, completion.Complete(context.Background(), []string{""}))
+
+	assert.Contains(t, out.String(), "completion\tскрипт автодополнения для bash, zsh или fish\n")
+	assert.NotContains(t, out.String(), cli.CompleteCommand)
+}
+
+func TestCompletionHandlerDescribesFlagsInCatalogLanguage(t *testing.T) {
+	var out bytes.Buffer
+	completion := newLocalizedCompletionHandler(t, &out, i18n.English)
+	require.NoError(t, completion.Complete(context.Background(), []string{"--"}))
+
+	assert.Contains(t, out.String(), "--sms-lang\tCustomer SMS language (ru, tg, en)\n")
+	assert.Contains(t, out.String(), "--product\tProduct type (Smartphone, Computer, TV) (long form)\n")
+}
+
+func TestCompletionHandlerScripts(t *testing.T) {
+	tests := []struct {
+		shell string
+		want  []string
+	}{
+		{shell: "bash", want: []string{"complete -o default -F _", cli.CompleteCommand}},
+		{shell: "zsh", want: []string{"compdef _", cli.CompleteCommand}},
+		{shell: "fish", want: []string{"-l answers -r -F", cli.CompleteCommand}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			var out bytes.Buffer
+			completion := newCompletionHandler(t, &out)
+			require.NoError(t, completion.Run(context.Background(), []string{tt.shell}))
+			for _, want := range tt.want {
+				assert.Contains(t, out.String(), want)
+			}
+		})
+	}
+
+	var out bytes.Buffer
+	err := newCompletionHandler(t, &out).Run(context.Background(), []string{"powershell"})
+	var usage *cli.UsageError
+	assert.ErrorAs(t, err, &usage)
+}
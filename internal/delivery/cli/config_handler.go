@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"unicode/utf8"
+
+	"github.com/icoder-new/installment-cli/internal/i18n"
+)
+
+// Setting is an environment variable the program reads, with the value used
+// when it is not set.
+type Setting struct {
+	Name    string
+	Default string
+	Secret  bool
+}
+
+// ConfigHandler prints the effective configuration.
+type ConfigHandler struct {
+	settings []Setting
+	out      io.Writer
+	catalog  *i18n.Catalog
+}
+
+func NewConfigHandler(settings []Setting, out io.Writer, catalog *i18n.Catalog) *ConfigHandler {
+	return &ConfigHandler{
+		settings: settings,
+		out:      out,
+		catalog:  catalog,
+	}
+}
+
+func (h *ConfigHandler) Run(ctx context.Context, args []string) error {
+	fs := newFlagSet(h.catalog, "config")
+	if err := parseFlags(h.catalog, fs, args); err != nil {
+		return err
+	}
+
+	rows := make([][3]string, 0, len(h.settings))
+	var nameWidth, valueWidth int
+	for _, setting := range h.settings {
+		value, ok := os.LookupEnv(setting.Name)
+		source := h.catalog.T("config.env")
+		switch {
+		case !ok || value == "":
+			value, source = setting.Default, h.catalog.T("config.default")
+		case setting.Secret:
+			value = "***"
+		}
+
+		row := [3]string{setting.Name, orDash(value), source}
+		nameWidth = max(nameWidth, len(row[0]))
+		valueWidth = max(valueWidth, utf8.RuneCountInString(row[1]))
+		rows = append(rows, row)
+	}
+
+	for _, row := range rows {
+		fmt.Fprintf(h.out, "%-*s  %-*s  %s\n", nameWidth, row[0], valueWidth, row[1], row[2])
+	}
+	return nil
+}
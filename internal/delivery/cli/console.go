@@ -0,0 +1,266 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+const historyLimit = 500
+
+// console reads the shell commands and the answers to the prompts line by
+// line. On a terminal the lines can be edited, the commands are kept in a
+// history file and Tab completes words; otherwise lines are read as they
+// come. It is both the input and the output of the prompter: a prompt
+// written without a newline becomes the prompt of the next line read.
+type console struct {
+	input    *bufio.Reader
+	out      io.Writer
+	terminal *term.Terminal
+	fd       int
+	commands *fileHistory
+	answers  *fileHistory
+	complete func(line string, command bool) []string
+	command  bool
+	prompt   []byte
+	line     []byte
+}
+
+func newConsole(in io.Reader, out io.Writer, historyPath string, complete func(string, bool) []string) (*console, error) {
+	c := &console{
+		input:    bufio.NewReader(in),
+		out:      out,
+		complete: complete,
+	}
+
+	file, ok := in.(*os.File)
+	if !ok || !term.IsTerminal(int(file.Fd())) {
+		return c, nil
+	}
+	fd := int(file.Fd())
+
+	commands, err := loadHistory(historyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	c.fd = fd
+	c.commands = commands
+	c.answers = &fileHistory{}
+	c.terminal = term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{in, out}, "")
+	c.terminal.AutoCompleteCallback = c.autoComplete
+	if width, height, err := term.GetSize(fd); err == nil && width > 0 {
+		_ = c.terminal.SetSize(width, height)
+	}
+	return c, nil
+}
+
+func (c *console) isTerminal() bool {
+	return c.terminal != nil
+}
+
+// ReadCommand reads a shell command after prompt.
+func (c *console) ReadCommand(prompt string) (string, error) {
+	c.command = true
+	defer func() { c.command = false }()
+
+	c.prompt = append(c.prompt[:0], prompt...)
+	line, err := c.readLine()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// Read serves the prompter one line at a time, so that its buffer never
+// holds input meant for the shell.
+func (c *console) Read(p []byte) (int, error) {
+	if len(c.line) == 0 {
+		line, err := c.readLine()
+		if err != nil {
+			return 0, err
+		}
+		c.line = append(c.line[:0], line...)
+		if !strings.HasSuffix(line, "\n") {
+			c.line = append(c.line, '\n')
+		}
+	}
+
+	n := copy(p, c.line)
+	c.line = c.line[n:]
+	return n, nil
+}
+
+// Write prints whole lines and keeps the unfinished last line as the prompt
+// of the next read.
+func (c *console) Write(p []byte) (int, error) {
+	c.prompt = append(c.prompt, p...)
+	if i := bytes.LastIndexByte(c.prompt, '\n'); i >= 0 {
+		if _, err := c.out.Write(c.prompt[:i+1]); err != nil {
+			return 0, err
+		}
+		c.prompt = append(c.prompt[:0], c.prompt[i+1:]...)
+	}
+	return len(p), nil
+}
+
+func (c *console) readLine() (string, error) {
+	prompt := string(c.prompt)
+	c.prompt = c.prompt[:0]
+
+	if c.terminal == nil {
+		if _, err := io.WriteString(c.out, prompt); err != nil {
+			return "", err
+		}
+		line, err := c.input.ReadString('\n')
+		if errors.Is(err, io.EOF) && line != "" {
+			err = nil
+		}
+		if err == nil && c.command {
+			// Echo the command so that a piped session reads like a typed one.
+			_, err = io.WriteString(c.out, strings.TrimSpace(line)+"\n")
+		}
+		return line, err
+	}
+
+	// The terminal is raw only while a line is edited, so that the output of
+	// the commands is printed as usual.
+	state, err := term.MakeRaw(c.fd)
+	if err != nil {
+		return "", err
+	}
+	defer term.Restore(c.fd, state)
+
+	c.terminal.History = c.answers
+	if c.command {
+		c.terminal.History = c.commands
+	}
+	c.terminal.SetPrompt(prompt)
+	return c.terminal.ReadLine()
+}
+
+// autoComplete completes the word before the cursor on Tab: to the only
+// candidate or to the prefix all candidates share.
+func (c *console) autoComplete(line string, pos int, key rune) (string, int, bool) {
+	if key != '\t' || c.complete == nil {
+		return "", 0, false
+	}
+
+	start := strings.LastIndexByte(line[:pos], ' ') + 1
+	word := strings.ToLower(line[start:pos])
+
+	var matches []string
+	for _, candidate := range c.complete(line[:start], c.command) {
+		if strings.HasPrefix(strings.ToLower(candidate), word) {
+			matches = append(matches, candidate)
+		}
+	}
+	if len(matches) == 0 {
+		return "", 0, false
+	}
+
+	completion := commonPrefix(matches)
+	if len(matches) == 1 && c.command {
+		completion += " "
+	}
+	if utf8.RuneCountInString(completion) <= utf8.RuneCountInString(word) {
+		return "", 0, false
+	}
+
+	newLine := line[:start] + completion + line[pos:]
+	return newLine, start + len(completion), true
+}
+
+func commonPrefix(words []string) string {
+	prefix := []rune(words[0])
+	for _, word := range words[1:] {
+		runes := []rune(word)
+		n := 0
+		for n < len(prefix) && n < len(runes) && strings.EqualFold(string(prefix[n]), string(runes[n])) {
+			n++
+		}
+		prefix = prefix[:n]
+	}
+	return string(prefix)
+}
+
+// fileHistory is the history of the line editor, appended to a file when it
+// has a path.
+type fileHistory struct {
+	path    string
+	entries []string
+}
+
+func loadHistory(path string) (*fileHistory, error) {
+	history := &fileHistory{path: path}
+	if path == "" {
+		return history, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if line != "" {
+			history.entries = append(history.entries, line)
+		}
+	}
+	if len(history.entries) > historyLimit {
+		history.entries = history.entries[len(history.entries)-historyLimit:]
+		// Rewrite the file so that it does not grow without bound.
+		data := strings.Join(history.entries, "\n") + "\n"
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			return nil, err
+		}
+	}
+	return history, nil
+}
+
+func (h *fileHistory) Add(entry string) {
+	entry = strings.TrimSpace(entry)
+	if entry == "" || (len(h.entries) > 0 && h.entries[len(h.entries)-1] == entry) {
+		return
+	}
+
+	h.entries = append(h.entries, entry)
+	if len(h.entries) > historyLimit {
+		h.entries = h.entries[1:]
+	}
+
+	if h.path == "" {
+		return
+	}
+	// History is a convenience: a file that cannot be written is ignored.
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o700); err != nil {
+		return
+	}
+	file, err := os.OpenFile(h.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	_, _ = file.WriteString(entry + "\n")
+}
+
+func (h *fileHistory) Len() int {
+	return len(h.entries)
+}
+
+func (h *fileHistory) At(idx int) string {
+	return h.entries[len(h.entries)-1-idx]
+}
@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/i18n"
+	"github.com/icoder-new/installment-cli/internal/usecase"
+)
+
+const defaultMaxPending = 24 * time.Hour
+
+type ContractsHandler struct {
+	contracts  *usecase.ContractService
+	deliveries *usecase.DeliveryService
+	agreements *usecase.AgreementService
+	printer    *ResultPrinter
+	out        io.Writer
+	catalog    *i18n.Catalog
+}
+
+func NewContractsHandler(contracts *usecase.ContractService, deliveries *usecase.DeliveryService, agreements *usecase.AgreementService, printer *ResultPrinter, out io.Writer, catalog *i18n.Catalog) *ContractsHandler {
+	return &ContractsHandler{
+		contracts:  contracts,
+		deliveries: deliveries,
+		agreements: agreements,
+		printer:    printer,
+		out:        out,
+		catalog:    catalog,
+	}
+}
+
+func (h *ContractsHandler) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(h.catalog.T("contracts.usage"))
+	}
+
+	switch args[0] {
+	case "show":
+		return h.show(args[1:])
+	case "render":
+		return h.render(args[1:])
+	case "undelivered":
+		return h.undelivered(args[1:])
+	default:
+		return usageError(h.catalog.T("contracts.usage"))
+	}
+}
+
+func (h *ContractsHandler) show(args []string) error {
+	var contractID string
+
+	fs := newFlagSet(h.catalog, "contracts show")
+	fs.StringVar(&contractID, "id", "", h.catalog.T("flag.contract"))
+
+	if err := parseFlags(h.catalog, fs, args); err != nil {
+		return err
+	}
+
+	if contractID == "" {
+		return usageError(h.catalog.T("contracts.usage"))
+	}
+
+	contract, err := h.contracts.Find(contractID)
+	if err != nil {
+		return err
+	}
+
+	h.printer.PrintContract(contract)
+	return nil
+}
+
+// render writes the printable agreement to a file or to stdout. A PDF is
+// not written to a terminal.
+func (h *ContractsHandler) render(args []string) error {
+	var contractID, format, output string
+
+	fs := newFlagSet(h.catalog, "contracts render")
+	fs.StringVar(&contractID, "id", "", h.catalog.T("flag.contract"))
+	fs.StringVar(&format, "format", string(domain.FormatHTML), h.catalog.T("flag.format"))
+	fs.StringVar(&output, "o", "", h.catalog.T("flag.output"))
+	fs.StringVar(&output, "output", "", h.catalog.T("flag.output"))
+
+	if err := parseFlags(h.catalog, fs, args); err != nil {
+		return err
+	}
+
+	if contractID == "" {
+		return usageError(h.catalog.T("contracts.usage"))
+	}
+
+	documentFormat, err := domain.ParseDocumentFormat(format)
+	if err != nil {
+		return err
+	}
+
+	if output == "" && documentFormat == domain.FormatPDF && writesToTerminal(h.out) {
+		return usageError(h.catalog.T("contracts.pdf_terminal", contractID))
+	}
+
+	document, err := h.agreements.Render(contractID, string(h.catalog.Language()), documentFormat)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		_, err := h.out.Write(document)
+		return err
+	}
+
+	if err := os.WriteFile(output, document, 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintln(h.out, h.catalog.T("contracts.saved", output))
+	return nil
+}
+
+func (h *ContractsHandler) undelivered(args []string) error {
+	var maxPending time.Duration
+
+	fs := newFlagSet(h.catalog, "contracts undelivered")
+	fs.DurationVar(&maxPending, "older-than", defaultMaxPending, h.catalog.T("flag.older_than"))
+
+	if err := parseFlags(h.catalog, fs, args); err != nil {
+		return err
+	}
+
+	undelivered, err := h.deliveries.Undelivered(maxPending)
+	if err != nil {
+		return err
+	}
+
+	h.printer.PrintUndelivered(undelivered)
+	return nil
+}
+
+// writesToTerminal reports whether out is a terminal, where binary output
+// would garble the screen.
+func writesToTerminal(out io.Writer) bool {
+	file, ok := out.(*os.File)
+	return ok && term.IsTerminal(int(file.Fd()))
+}
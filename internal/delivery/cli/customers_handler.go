@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/i18n"
+	"github.com/icoder-new/installment-cli/internal/phone"
+	"github.com/icoder-new/installment-cli/internal/usecase"
+)
+
+type CustomersHandler struct {
+	customers *usecase.CustomerService
+	phones    *phone.Parser
+	out       io.Writer
+	catalog   *i18n.Catalog
+}
+
+func NewCustomersHandler(customers *usecase.CustomerService, phones *phone.Parser, out io.Writer, catalog *i18n.Catalog) *CustomersHandler {
+	return &CustomersHandler{
+		customers: customers,
+		phones:    phones,
+		out:       out,
+		catalog:   catalog,
+	}
+}
+
+func (h *CustomersHandler) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(h.catalog.T("customers.usage"))
+	}
+
+	switch args[0] {
+	case "set":
+		return h.set(args[1:])
+	case "show":
+		return h.show(args[1:])
+	default:
+		return usageError(h.catalog.T("customers.usage"))
+	}
+}
+
+func (h *CustomersHandler) set(args []string) error {
+	var phoneNumber, email, messengerID, channels string
+
+	fs := newFlagSet(h.catalog, "customers set")
+	fs.StringVar(&phoneNumber, "phone", "", h.catalog.T("flag.customer_phone"))
+	fs.StringVar(&email, "email", "", h.catalog.T("flag.email"))
+	fs.StringVar(&messengerID, "messenger", "", h.catalog.T("flag.messenger"))
+	fs.StringVar(&channels, "channels", "", h.catalog.T("flag.channels"))
+
+	if err := parseFlags(h.catalog, fs, args); err != nil {
+		return err
+	}
+
+	if phoneNumber == "" {
+		return usageError(h.catalog.T("customers.usage"))
+	}
+
+	phoneNumber, err := parsePhoneNumber(h.phones, h.catalog, phoneNumber)
+	if err != nil {
+		return err
+	}
+
+	given := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { given[f.Name] = true })
+
+	if given["email"] {
+		if err := h.customers.SetEmail(phoneNumber, email); err != nil {
+			return err
+		}
+	}
+	if given["messenger"] {
+		if err := h.customers.SetMessengerID(phoneNumber, messengerID); err != nil {
+			return err
+		}
+	}
+	if given["channels"] {
+		parsed, err := parseChannels(channels)
+		if err != nil {
+			return err
+		}
+		if err := h.customers.SetChannels(phoneNumber, parsed); err != nil {
+			return err
+		}
+	}
+
+	return h.print(phoneNumber)
+}
+
+func (h *CustomersHandler) show(args []string) error {
+	var phoneNumber string
+
+	fs := newFlagSet(h.catalog, "customers show")
+	fs.StringVar(&phoneNumber, "phone", "", h.catalog.T("flag.customer_phone"))
+
+	if err := parseFlags(h.catalog, fs, args); err != nil {
+		return err
+	}
+
+	if phoneNumber == "" {
+		return usageError(h.catalog.T("customers.usage"))
+	}
+
+	phoneNumber, err := parsePhoneNumber(h.phones, h.catalog, phoneNumber)
+	if err != nil {
+		return err
+	}
+
+	return h.print(phoneNumber)
+}
+
+func (h *CustomersHandler) print(phoneNumber string) error {
+	customer, err := h.customers.Find(phoneNumber)
+	if err != nil {
+		return err
+	}
+
+	channels := make([]string, 0, len(customer.PreferredChannels()))
+	for _, channel := range customer.PreferredChannels() {
+		channels = append(channels, string(channel))
+	}
+
+	fmt.Fprintln(h.out, h.catalog.T("customers.phone", customer.PhoneNumber))
+	fmt.Fprintln(h.out, h.catalog.T("customers.email", orDash(customer.Email)))
+	fmt.Fprintln(h.out, h.catalog.T("customers.messenger", orDash(customer.MessengerID)))
+	fmt.Fprintln(h.out, h.catalog.T("customers.channels", strings.Join(channels, ", ")))
+	return nil
+}
+
+func parseChannels(value string) ([]domain.Channel, error) {
+	var channels []domain.Channel
+	for _, name := range strings.Split(value, ",") {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+		channel, err := domain.ParseChannel(name)
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, channel)
+	}
+	return channels, nil
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
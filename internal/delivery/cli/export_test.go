@@ -0,0 +1,16 @@
+package cli
+
+// SetTerminal lets the tests drive the prompts as if typed on a terminal.
+func (p *UserPrompter) SetTerminal(terminal bool) {
+	p.terminal = terminal
+}
+
+// DisplayWidth exposes the column count used to align result boxes.
+func DisplayWidth(text string) int {
+	return displayWidth(text)
+}
+
+// Truncate exposes how form lines are cut to the terminal width.
+func Truncate(line string, width int) string {
+	return truncate(line, width)
+}
@@ -2,68 +2,169 @@ package cli
 
 import (
 	"flag"
+	"fmt"
 	"strings"
 
 	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/i18n"
+	"github.com/icoder-new/installment-cli/internal/phone"
 )
 
 type Flags struct {
-	Help        bool
 	Interactive bool
 	ProductType string
 	Price       float64
 	PhoneNumber string
 	Months      int
+	SMSLanguage string
+	Consent     bool
+	Answers     string
+	TUI         bool
 }
 
 type FlagParser struct {
 	validator *FlagValidator
+	catalog   *i18n.Catalog
 }
 
-func NewFlagParser() *FlagParser {
+func NewFlagParser(phones *phone.Parser, catalog *i18n.Catalog) *FlagParser {
 	return &FlagParser{
-		validator: NewFlagValidator(),
+		validator: NewFlagValidator(phones, catalog),
+		catalog:   catalog,
 	}
 }
 
-func (fp *FlagParser) Parse() (*Flags, error) {
+// Parse parses the flags of calc or, without the contract options, of quote.
+func (fp *FlagParser) Parse(command string, args []string, contract bool) (*Flags, error) {
 	flags := &Flags{}
-	fp.defineFlags(flags)
-	flag.Parse()
+	fs := newFlagSet(fp.catalog, command)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), fp.catalog.T("calc.usage"), programName())
+	}
+	fp.defineFlags(fs, flags, contract)
+	if err := parseFlags(fp.catalog, fs, args); err != nil {
+		return nil, err
+	}
+	if flags.Answers != "" || flags.TUI {
+		flags.Interactive = true
+	}
 
 	return flags, fp.validator.Validate(flags)
 }
 
-func (fp *FlagParser) defineFlags(flags *Flags) {
-	flag.BoolVar(&flags.Help, "h", false, "Показать помощь")
-	flag.BoolVar(&flags.Help, "help", false, "Показать помощь (длинная форма)")
+// boolFlags are the flags of all commands that take no value. The argument
+// after any other flag written without "=" is that flag's value, so it is
+// left alone even when it looks like --lang or --plain.
+var boolFlags = map[string]bool{
+	"h": true, "help": true, "i": true, "interactive": true, "tui": true,
+	"consent": true, "transliterate": true, "plain": true,
+}
 
-	flag.BoolVar(&flags.Interactive, "i", false, "Интерактивный режим")
-	flag.BoolVar(&flags.Interactive, "interactive", false, "Интерактивный режим (длинная форма)")
+// ExtractLanguage removes the global --lang option from args so that it can
+// be given before or after a subcommand, and returns its value. Arguments
+// after "--" and values of other flags are kept as they are. --lang without
+// a value is a usage error.
+func ExtractLanguage(args []string) (string, []string, error) {
+	var lang string
+	rest := make([]string, 0, len(args))
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			return lang, append(rest, args[i:]...), nil
+		case arg == "-lang" || arg == "--lang":
+			if i+1 == len(args) {
+				return "", nil, usageError(fmt.Sprintf("flag needs an argument: %s", arg))
+			}
+			lang = args[i+1]
+			i++
+		case strings.HasPrefix(arg, "-lang=") || strings.HasPrefix(arg, "--lang="):
+			lang = arg[strings.Index(arg, "=")+1:]
+		case takesValue(arg) && i+1 < len(args):
+			rest = append(rest, arg, args[i+1])
+			i++
+		default:
+			rest = append(rest, arg)
+		}
+	}
 
-	flag.StringVar(&flags.ProductType, "p", "", "Тип товара (Смартфон, Компьютер, Телевизор)")
-	flag.StringVar(&flags.ProductType, "product", "", "Тип товара (длинная форма)")
+	return lang, rest, nil
+}
 
-	flag.Float64Var(&flags.Price, "c", 0, "Цена товара")
-	flag.Float64Var(&flags.Price, "cost", 0, "Цена товара (длинная форма)")
+// ExtractPlain removes the global --plain option, which prints results as
+// plain lines without boxes or colour, e.g. for screen readers. Like
+// ExtractLanguage it stops at "--" and skips values of other flags.
+func ExtractPlain(args []string) (bool, []string) {
+	var plain bool
+	rest := make([]string, 0, len(args))
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			return plain, append(rest, args[i:]...)
+		case arg == "-plain" || arg == "--plain":
+			plain = true
+		case takesValue(arg) && i+1 < len(args):
+			rest = append(rest, arg, args[i+1])
+			i++
+		default:
+			rest = append(rest, arg)
+		}
+	}
 
-	flag.StringVar(&flags.PhoneNumber, "n", "", "Номер телефона")
-	flag.StringVar(&flags.PhoneNumber, "number", "", "Номер телефона (длинная форма)")
+	return plain, rest
+}
 
-	flag.IntVar(&flags.Months, "m", 0, "Срок рассрочки")
-	flag.IntVar(&flags.Months, "months", 0, "Срок рассрочки (длинная форма)")
+// takesValue reports whether arg is a flag whose value is the next argument.
+func takesValue(arg string) bool {
+	name := strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-")
+	if name == arg || name == "" || strings.Contains(name, "=") {
+		return false
+	}
+	return !boolFlags[name]
+}
+
+func (fp *FlagParser) defineFlags(fs *flag.FlagSet, flags *Flags, contract bool) {
+	c := fp.catalog
+	long := func(key string) string {
+		return c.T("flag.long_form", c.T(key))
+	}
+
+	fs.BoolVar(&flags.Interactive, "i", false, c.T("flag.interactive"))
+	fs.BoolVar(&flags.Interactive, "interactive", false, long("flag.interactive"))
+
+	product := c.T("flag.product", c.Product(domain.Smartphone), c.Product(domain.Computer), c.Product(domain.TV))
+	fs.StringVar(&flags.ProductType, "p", "", product)
+	fs.StringVar(&flags.ProductType, "product", "", c.T("flag.long_form", product))
+
+	price := amountFlag{amount: &flags.Price, catalog: c}
+	fs.Var(price, "c", c.T("flag.price"))
+	fs.Var(price, "cost", long("flag.price"))
+
+	fs.StringVar(&flags.PhoneNumber, "n", "", c.T("flag.phone"))
+	fs.StringVar(&flags.PhoneNumber, "number", "", long("flag.phone"))
+
+	fs.IntVar(&flags.Months, "m", 0, c.T("flag.months"))
+	fs.IntVar(&flags.Months, "months", 0, long("flag.months"))
+
+	fs.StringVar(&flags.Answers, "answers", "", c.T("flag.answers"))
+
+	fs.BoolVar(&flags.TUI, "tui", false, c.T("flag.tui"))
+
+	if !contract {
+		return
+	}
+
+	fs.StringVar(&flags.SMSLanguage, "sms-lang", "", c.T("flag.sms_lang"))
+
+	fs.BoolVar(&flags.Consent, "consent", false, c.T("flag.consent"))
 }
 
 func (f *Flags) ToProduct() domain.Product {
-	var productType domain.ProductType
-	switch strings.ToLower(f.ProductType) {
-	case "1", "смартфон":
-		productType = domain.Smartphone
-	case "2", "компьютер":
-		productType = domain.Computer
-	case "3", "телевизор":
-		productType = domain.TV
-	default:
+	productType, ok := resolveProductType(f.ProductType)
+	if !ok {
 		productType = domain.ProductType(f.ProductType)
 	}
 
@@ -75,10 +176,21 @@ func (f *Flags) ToProduct() domain.Product {
 	}
 }
 
-func (f *Flags) HasPartialData() bool {
-	return f.ProductType != "" || f.Price > 0 || f.PhoneNumber != "" || f.Months > 0
-}
-
 func (f *Flags) IsComplete() bool {
 	return f.ProductType != "" && f.Price > 0 && f.PhoneNumber != "" && f.Months > 0
 }
+
+// resolveProductType accepts a menu number (1-3) or a product name in any
+// supported language.
+func resolveProductType(value string) (domain.ProductType, bool) {
+	switch strings.TrimSpace(value) {
+	case "1":
+		return domain.Smartphone, true
+	case "2":
+		return domain.Computer, true
+	case "3":
+		return domain.TV, true
+	}
+
+	return i18n.ParseProduct(value)
+}
@@ -0,0 +1,102 @@
+package cli_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/icoder-new/installment-cli/internal/delivery/cli"
+)
+
+func TestExtractLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantLang string
+		wantRest []string
+	}{
+		{
+			name:     "before the subcommand",
+			args:     []string{"--lang", "tg", "quote", "-m", "6"},
+			wantLang: "tg",
+			wantRest: []string{"quote", "-m", "6"},
+		},
+		{
+			name:     "after the subcommand with equals",
+			args:     []string{"quote", "-lang=en", "-m", "6"},
+			wantLang: "en",
+			wantRest: []string{"quote", "-m", "6"},
+		},
+		{
+			name:     "value of another flag",
+			args:     []string{"cancel", "--reason", "--lang", "--id", "42"},
+			wantRest: []string{"cancel", "--reason", "--lang", "--id", "42"},
+		},
+		{
+			name:     "after a flag without a value",
+			args:     []string{"calc", "--consent", "--lang", "en"},
+			wantLang: "en",
+			wantRest: []string{"calc", "--consent"},
+		},
+		{
+			name:     "after the end of flags",
+			args:     []string{"--lang", "ru", "quote", "--", "--lang", "tg"},
+			wantLang: "ru",
+			wantRest: []string{"quote", "--", "--lang", "tg"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lang, rest, err := cli.ExtractLanguage(tt.args)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLang, lang)
+			assert.Equal(t, tt.wantRest, rest)
+		})
+	}
+}
+
+func TestExtractLanguageWithoutValue(t *testing.T) {
+	_, _, err := cli.ExtractLanguage([]string{"quote", "--lang"})
+
+	var usage *cli.UsageError
+	require.ErrorAs(t, err, &usage)
+	assert.Equal(t, cli.ExitUsage, cli.ExitCode(err))
+}
+
+func TestExtractPlain(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		wantPlain bool
+		wantRest  []string
+	}{
+		{
+			name:      "anywhere among the flags",
+			args:      []string{"quote", "-m", "6", "--plain"},
+			wantPlain: true,
+			wantRest:  []string{"quote", "-m", "6"},
+		},
+		{
+			name:     "value of another flag",
+			args:     []string{"cancel", "--reason", "--plain"},
+			wantRest: []string{"cancel", "--reason", "--plain"},
+		},
+		{
+			name:     "after the end of flags",
+			args:     []string{"quote", "--", "-plain"},
+			wantRest: []string{"quote", "--", "-plain"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plain, rest := cli.ExtractPlain(tt.args)
+
+			assert.Equal(t, tt.wantPlain, plain)
+			assert.Equal(t, tt.wantRest, rest)
+		})
+	}
+}
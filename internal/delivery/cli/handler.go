@@ -1,75 +1,190 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 
 	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/i18n"
+	"github.com/icoder-new/installment-cli/internal/phone"
 	"github.com/icoder-new/installment-cli/internal/usecase"
 )
 
 type Handler struct {
 	calculator *usecase.InstallmentCalculator
+	contracts  *usecase.ContractService
+	customers  *usecase.CustomerService
+	consents   *usecase.ConsentService
+	phones     *phone.Parser
+	in         io.Reader
+	out        io.Writer
 	flagParser *FlagParser
 	prompter   *UserPrompter
 	printer    *ResultPrinter
+	catalog    *i18n.Catalog
 }
 
-func NewHandler(calculator *usecase.InstallmentCalculator) *Handler {
+func NewHandler(
+	calculator *usecase.InstallmentCalculator,
+	contracts *usecase.ContractService,
+	customers *usecase.CustomerService,
+	consents *usecase.ConsentService,
+	phones *phone.Parser,
+	printer *ResultPrinter,
+	in io.Reader,
+	out io.Writer,
+	catalog *i18n.Catalog,
+) *Handler {
 	return &Handler{
 		calculator: calculator,
-		flagParser: NewFlagParser(),
-		prompter:   NewUserPrompter(),
-		printer:    NewResultPrinter(),
+		contracts:  contracts,
+		customers:  customers,
+		consents:   consents,
+		phones:     phones,
+		in:         in,
+		out:        out,
+		flagParser: NewFlagParser(phones, catalog),
+		prompter:   NewUserPrompter(in, out, phones, catalog),
+		printer:    printer,
+		catalog:    catalog,
 	}
 }
 
-func (h *Handler) Run() error {
-	product, err := h.parseAndCollectInput()
+// Run is the calc command: it calculates the installment and opens the
+// contract.
+func (h *Handler) Run(ctx context.Context, args []string) error {
+	flags, err := h.flagParser.Parse("calc", args, true)
 	if err != nil {
 		return err
 	}
 
-	totalPayment, err := h.calculator.CalculateInstallment(product)
+	product, err := h.collectInput(flags, h.catalog.T("form.submit_contract"))
 	if err != nil {
-		return fmt.Errorf("ошибка при расчете рассрочки: %w", err)
+		return err
+	}
+
+	_, err = h.open(ctx, product, flags)
+	return err
+}
+
+// open calculates the installment for product and opens the contract with
+// the calc options in flags.
+func (h *Handler) open(ctx context.Context, product domain.Product, flags *Flags) (domain.Contract, error) {
+	if flags.SMSLanguage != "" {
+		lang, ok := i18n.ParseLanguage(flags.SMSLanguage)
+		if !ok {
+			return domain.Contract{}, errors.New(h.catalog.T("error.unknown_language", flags.SMSLanguage))
+		}
+		if err := h.customers.SetLanguage(product.PhoneNumber, string(lang)); err != nil {
+			return domain.Contract{}, err
+		}
+	}
+
+	totalPayment, err := h.calculator.CalculateInstallment(ctx, product)
+	if err != nil {
+		return domain.Contract{}, fmt.Errorf("%s: %w", h.catalog.T("error.calculation"), err)
+	}
+
+	var contract domain.Contract
+	if flags.Consent {
+		contract, err = h.openWithConsent(ctx, product, totalPayment)
+	} else {
+		contract, err = h.contracts.Open(ctx, product, totalPayment, nil)
+	}
+	if err != nil && contract.ID == "" {
+		return contract, err
 	}
 
 	h.printer.PrintInstallmentResult(product, totalPayment)
-	return nil
+	h.printer.PrintContractID(contract.ID)
+	return contract, err
 }
 
-func (h *Handler) parseAndCollectInput() (domain.Product, error) {
-	flags, err := h.flagParser.Parse()
+// Quote is the quote command: it only calculates the installment, without a
+// contract or SMS.
+func (h *Handler) Quote(ctx context.Context, args []string) error {
+	flags, err := h.flagParser.Parse("quote", args, false)
 	if err != nil {
-		return domain.Product{}, err
+		return err
 	}
 
-	if flags.Interactive {
-		return h.handleInteractiveMode(flags)
+	product, err := h.collectInput(flags, h.catalog.T("form.submit_quote"))
+	if err != nil {
+		return err
 	}
 
-	return flags.ToProduct(), nil
+	_, err = h.quote(ctx, product)
+	return err
 }
 
-func (h *Handler) handleInteractiveMode(flags *Flags) (domain.Product, error) {
-	if flags.HasPartialData() {
-		return h.collectInteractiveInputWithDefaults(flags), nil
+func (h *Handler) quote(ctx context.Context, product domain.Product) (float64, error) {
+	totalPayment, err := h.calculator.CalculateInstallment(ctx, product)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", h.catalog.T("error.calculation"), err)
 	}
-	return h.collectInteractiveInput(), nil
+
+	h.printer.PrintInstallmentResult(product, totalPayment)
+	return totalPayment, nil
 }
 
-func (h *Handler) collectInteractiveInputWithDefaults(flags *Flags) domain.Product {
-	product := domain.Product{
-		Type:        h.prompter.PromptProductType(flags.ProductType),
-		Price:       h.prompter.PromptPrice(flags.Price),
-		PhoneNumber: h.prompter.PromptPhoneNumber(flags.PhoneNumber),
+// withPrompter returns a copy of the handler that asks its questions through
+// prompter.
+func (h *Handler) withPrompter(prompter *UserPrompter) *Handler {
+	session := *h
+	session.prompter = prompter
+	return &session
+}
+
+// openWithConsent sends the customer a one-time code and asks the cashier to
+// enter it; a wrong code may be entered again while attempts remain.
+func (h *Handler) openWithConsent(ctx context.Context, product domain.Product, totalPayment float64) (domain.Contract, error) {
+	request, err := h.consents.Request(ctx, product, totalPayment)
+	if err != nil {
+		return domain.Contract{}, err
 	}
 
-	product.PeriodMonths = h.prompter.PromptInstallmentPeriod(flags.Months, product.Type)
+	fmt.Fprintln(h.out, h.catalog.T("consent.sent",
+		domain.MaskPhoneNumber(product.PhoneNumber), request.ExpiresAt.Format("15:04")))
 
-	return product
+	for {
+		code, err := h.prompter.PromptConsentCode()
+		if err != nil {
+			return domain.Contract{}, err
+		}
+
+		contract, err := h.consents.Confirm(ctx, request.ID, code)
+		if errors.Is(err, domain.ErrConsentCodeMismatch) {
+			h.prompter.printError(h.catalog.Error(err))
+			continue
+		}
+		return contract, err
+	}
 }
 
-func (h *Handler) collectInteractiveInput() domain.Product {
-	return h.collectInteractiveInputWithDefaults(&Flags{})
+// collectInput returns the product from the flags or asks for it: on the
+// full-screen form with --tui, where submit labels its button, or with the
+// prompts.
+func (h *Handler) collectInput(flags *Flags, submit string) (domain.Product, error) {
+	if !flags.Interactive {
+		return flags.ToProduct(), nil
+	}
+
+	if flags.Answers != "" {
+		answers, err := LoadAnswers(flags.Answers, h.catalog)
+		if err != nil {
+			return domain.Product{}, err
+		}
+		h.prompter.WithAnswers(answers)
+	} else if flags.TUI {
+		form, restore, ok := openTerminalForm(h.in, h.out, h.phones, h.catalog)
+		if ok {
+			defer restore()
+			return form.Run(flags.ToProduct(), submit)
+		}
+		fmt.Fprintln(h.out, h.catalog.T("form.unsupported"))
+	}
+
+	return h.prompter.PromptProduct(flags.ToProduct())
 }
@@ -1,12 +1,12 @@
 package cli
 
 import (
-	"fmt"
-	"regexp"
+	"errors"
 	"strconv"
-	"strings"
 
 	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/i18n"
+	"github.com/icoder-new/installment-cli/internal/phone"
 )
 
 type InputValidator interface {
@@ -17,68 +17,53 @@ type InputValidator interface {
 	GetInstallmentPeriodRange(productType domain.ProductType) (min, max int)
 }
 
-type inputValidator struct{}
+type inputValidator struct {
+	phones  *phone.Parser
+	catalog *i18n.Catalog
+}
 
-func NewInputValidator() InputValidator {
-	return &inputValidator{}
+func NewInputValidator(phones *phone.Parser, catalog *i18n.Catalog) InputValidator {
+	return &inputValidator{phones: phones, catalog: catalog}
 }
 
 func (v *inputValidator) ValidateProductType(input string) (domain.ProductType, error) {
-	switch strings.ToLower(input) {
-	case "смартфон":
-		return domain.Smartphone, nil
-	case "компьютер":
-		return domain.Computer, nil
-	case "телевизор":
-		return domain.TV, nil
-	default:
-		return "", fmt.Errorf("неверный тип товара. Допустимые значения: Смартфон, Компьютер, Телевизор")
+	if productType, ok := i18n.ParseProduct(input); ok {
+		return productType, nil
 	}
+
+	return "", productError(v.catalog, v.catalog.T("error.product_names",
+		v.catalog.Product(domain.Smartphone),
+		v.catalog.Product(domain.Computer),
+		v.catalog.Product(domain.TV)), input)
 }
 
 func (v *inputValidator) ValidatePrice(input string) (float64, error) {
-	price, err := strconv.ParseFloat(input, 64)
+	price, err := parseAmount(v.catalog, input)
 	if err != nil {
-		return 0, fmt.Errorf("введите корректное число")
+		return 0, err
 	}
 
 	if price <= 0 {
-		return 0, fmt.Errorf("цена должна быть положительным числом")
+		return 0, errors.New(v.catalog.T("error.price_positive"))
 	}
 
 	return price, nil
 }
 
-func (v *inputValidator) ValidatePhoneNumber(phone string) (string, error) {
-	cleanPhone := regexp.MustCompile(`[^0-9]`).ReplaceAllString(phone, "")
-
-	switch {
-	case strings.HasPrefix(cleanPhone, "992") && len(cleanPhone) == 12:
-	case len(cleanPhone) == 9:
-		cleanPhone = "992" + cleanPhone
-	case strings.HasPrefix(phone, "+") && len(cleanPhone) >= 10:
-		cleanPhone = cleanPhone[len(cleanPhone)-9:]
-		cleanPhone = "992" + cleanPhone
-	default:
-		return "", fmt.Errorf("неверный формат номера телефона. Используйте формат: 992XXXXXXXXX, 9XXXXXXXX, или +992XXXXXXXXX")
-	}
-
-	if len(cleanPhone) != 12 || !strings.HasPrefix(cleanPhone, "992") {
-		return "", fmt.Errorf("неверный формат номера телефона. Проверьте введенный номер")
-	}
-
-	return cleanPhone, nil
+// ValidatePhoneNumber returns the number in E.164.
+func (v *inputValidator) ValidatePhoneNumber(input string) (string, error) {
+	return parsePhoneNumber(v.phones, v.catalog, input)
 }
 
 func (v *inputValidator) ValidateInstallmentPeriod(input string, productType domain.ProductType) (int, error) {
 	months, err := strconv.Atoi(input)
 	if err != nil {
-		return 0, fmt.Errorf("введите корректное число")
+		return 0, errors.New(v.catalog.T("error.not_a_number"))
 	}
 
 	min, max := v.GetInstallmentPeriodRange(productType)
 	if months < min || months > max {
-		return 0, fmt.Errorf("срок рассрочки должен быть от %d до %d месяцев", min, max)
+		return 0, errors.New(v.catalog.T("error.period_bounds", min, max))
 	}
 
 	return months, nil
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/icoder-new/installment-cli/internal/i18n"
+	"github.com/icoder-new/installment-cli/internal/usecase"
+)
+
+type PayHandler struct {
+	contracts *usecase.ContractService
+	printer   *ResultPrinter
+	catalog   *i18n.Catalog
+}
+
+func NewPayHandler(contracts *usecase.ContractService, printer *ResultPrinter, catalog *i18n.Catalog) *PayHandler {
+	return &PayHandler{
+		contracts: contracts,
+		printer:   printer,
+		catalog:   catalog,
+	}
+}
+
+func (h *PayHandler) Run(ctx context.Context, args []string) error {
+	var contractID string
+	var amount float64
+
+	fs := newFlagSet(h.catalog, "pay")
+	fs.StringVar(&contractID, "contract", "", h.catalog.T("flag.contract"))
+	fs.Var(amountFlag{amount: &amount, catalog: h.catalog}, "amount", h.catalog.T("flag.amount"))
+
+	if err := parseFlags(h.catalog, fs, args); err != nil {
+		return err
+	}
+
+	if contractID == "" || amount == 0 {
+		return usageError(h.catalog.T("pay.required"))
+	}
+
+	contract, err := h.contracts.RecordPayment(ctx, contractID, amount)
+	if err != nil && contract.ID == "" {
+		return fmt.Errorf("%s: %w", h.catalog.T("error.pay"), err)
+	}
+
+	h.printer.PrintPayment(contract, amount)
+	return err
+}
@@ -2,44 +2,170 @@ package cli
 
 import (
 	"fmt"
+	"io"
+	"strings"
 
 	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/i18n"
+	"github.com/icoder-new/installment-cli/internal/usecase"
 )
 
-type ResultPrinter struct{}
+// boxWidth is the narrowest result box, so that short results keep the
+// familiar size.
+const boxWidth = 40
 
-func NewResultPrinter() *ResultPrinter {
-	return &ResultPrinter{}
+type ResultPrinter struct {
+	out     io.Writer
+	style   Style
+	catalog *i18n.Catalog
+}
+
+func NewResultPrinter(out io.Writer, style Style, catalog *i18n.Catalog) *ResultPrinter {
+	return &ResultPrinter{out: out, style: style, catalog: catalog}
+}
+
+type resultRow struct {
+	label string
+	value string
+	tone  tone
 }
 
 func (rp *ResultPrinter) PrintInstallmentResult(product domain.Product, totalPayment float64) {
-	rp.printHeader()
-	rp.printProductInfo(product)
-	rp.printSeparator()
-	rp.printTotalInfo(product, totalPayment)
-	rp.printFooter()
+	currency := rp.catalog.T("currency")
+
+	overpayment := toneNormal
+	if totalPayment > product.Price {
+		overpayment = toneWarning
+	}
+
+	rp.printTable(rp.catalog.T("result.title"),
+		[]resultRow{
+			{label: rp.catalog.T("result.price"), value: rp.catalog.Money(product.Price) + " " + currency},
+			{label: rp.catalog.T("result.period"), value: rp.catalog.T("result.months", product.PeriodMonths)},
+		},
+		[]resultRow{
+			{label: rp.catalog.T("result.total"), value: rp.catalog.Money(totalPayment) + " " + currency, tone: toneTotal},
+			{label: rp.catalog.T("result.overpayment"), value: rp.catalog.Money(totalPayment-product.Price) + " " + currency, tone: overpayment},
+		},
+	)
 }
 
-func (rp *ResultPrinter) printHeader() {
-	fmt.Println("\n╔════════════════════════════════════════╗")
-	fmt.Println("║            РАССРОЧКА                   ║")
+// printTable prints the sections in a box sized to the content. Plain style,
+// or a terminal too narrow for the box, gets one "label value" line per row.
+func (rp *ResultPrinter) printTable(title string, sections ...[]resultRow) {
+	var labelWidth, valueWidth int
+	for _, section := range sections {
+		for _, row := range section {
+			labelWidth = max(labelWidth, displayWidth(row.label))
+			valueWidth = max(valueWidth, displayWidth(row.value))
+		}
+	}
+	inner := max(labelWidth+valueWidth+4, displayWidth(title)+2)
+	fits := rp.style.Width == 0 || inner+2 <= rp.style.Width
+	if rp.style.Plain || !fits {
+		inner = 0
+	} else if inner < boxWidth {
+		inner = boxWidth
+		if rp.style.Width > 0 {
+			inner = min(boxWidth, rp.style.Width-2)
+		}
+	}
+
+	if inner == 0 {
+		fmt.Fprintln(rp.out, title)
+		for _, section := range sections {
+			for _, row := range section {
+				fmt.Fprintln(rp.out, row.label+" "+rp.style.paint(row.value, row.tone))
+			}
+		}
+		return
+	}
+
+	padding := (inner - displayWidth(title)) / 2
+	fmt.Fprintln(rp.out)
+	fmt.Fprintln(rp.out, "╔"+strings.Repeat("═", inner)+"╗")
+	fmt.Fprintln(rp.out, "║"+padRight(strings.Repeat(" ", padding)+title, inner)+"║")
+	for _, section := range sections {
+		fmt.Fprintln(rp.out, "╠"+strings.Repeat("═", inner)+"╣")
+		for _, row := range section {
+			gap := inner - 2 - displayWidth(row.label) - displayWidth(row.value)
+			fmt.Fprintln(rp.out, "║ "+row.label+strings.Repeat(" ", gap)+rp.style.paint(row.value, row.tone)+" ║")
+		}
+	}
+	fmt.Fprintln(rp.out, "╚"+strings.Repeat("═", inner)+"╝")
 }
 
-func (rp *ResultPrinter) printProductInfo(product domain.Product) {
-	fmt.Println("╠════════════════════════════════════════╣")
-	fmt.Printf("║ %-15s %15.2f сомони ║\n", "Цена товара:", product.Price)
-	fmt.Printf("║ %-16s %15s %-5d ║\n", "Срок:", "", product.PeriodMonths)
+func (rp *ResultPrinter) PrintContractID(contractID string) {
+	fmt.Fprintln(rp.out, rp.catalog.T("result.contract_id", contractID))
 }
 
-func (rp *ResultPrinter) printSeparator() {
-	fmt.Println("╠════════════════════════════════════════╣")
+func (rp *ResultPrinter) PrintCancellation(contract domain.Contract) {
+	fmt.Fprintln(rp.out, rp.catalog.T("cancel.done", contract.ID))
+	fmt.Fprintln(rp.out, rp.catalog.T("cancel.reason", contract.CancelReason))
+	fmt.Fprintln(rp.out, rp.catalog.T("cancel.refund", rp.catalog.Money(contract.Refund), rp.catalog.T("currency")))
 }
 
-func (rp *ResultPrinter) printTotalInfo(product domain.Product, totalPayment float64) {
-	fmt.Printf("║ %s %15.2f сомони ║\n", "Итоговая сумма:", totalPayment)
-	fmt.Printf("║ %-15s %15.2f сомони ║\n", "Переплата:", totalPayment-product.Price)
+func (rp *ResultPrinter) PrintPayment(contract domain.Contract, amount float64) {
+	fmt.Fprintln(rp.out, rp.catalog.T("pay.done", rp.catalog.Money(amount), rp.catalog.T("currency"), contract.ID))
+	if contract.IsPaidOff() {
+		fmt.Fprintln(rp.out, rp.style.paint(rp.catalog.T("pay.paid_off"), toneSuccess))
+		return
+	}
+	fmt.Fprintln(rp.out, rp.catalog.T("pay.balance", rp.catalog.Money(contract.Balance()), rp.catalog.T("currency")))
 }
 
-func (rp *ResultPrinter) printFooter() {
-	fmt.Println("╚════════════════════════════════════════╝")
+func (rp *ResultPrinter) PrintContract(contract domain.Contract) {
+	fmt.Fprintln(rp.out, rp.catalog.T("contracts.id", contract.ID))
+	fmt.Fprintln(rp.out, rp.catalog.T("contracts.status", rp.catalog.T("contracts.status."+string(contract.Status))))
+	fmt.Fprintln(rp.out, rp.catalog.T("contracts.product", rp.catalog.Product(contract.Product.Type), contract.Product.PhoneNumber))
+	fmt.Fprintln(rp.out, rp.catalog.T("contracts.total", rp.catalog.Money(contract.TotalPayment), rp.catalog.T("currency")))
+	fmt.Fprintln(rp.out, rp.catalog.T("contracts.balance", rp.catalog.Money(contract.Balance()), rp.catalog.T("currency")))
+	if contract.Consent != nil {
+		fmt.Fprintln(rp.out, rp.catalog.T("contracts.consent",
+			contract.Consent.ConfirmedAt.Format("02.01.2006 15:04"), contract.Consent.MaskedPhone))
+	}
+
+	if len(contract.Notifications) == 0 {
+		return
+	}
+
+	fmt.Fprintln(rp.out, rp.catalog.T("contracts.notifications"))
+	for _, notification := range contract.Notifications {
+		rp.printNotification(notification)
+	}
+}
+
+func (rp *ResultPrinter) PrintUndelivered(undelivered []usecase.UndeliveredNotification) {
+	if len(undelivered) == 0 {
+		fmt.Fprintln(rp.out, rp.catalog.T("contracts.all_delivered"))
+		return
+	}
+
+	for _, item := range undelivered {
+		fmt.Fprintf(rp.out, "%s  %s  ", item.Contract.ID, item.Contract.Product.PhoneNumber)
+		rp.printNotification(item.Notification)
+	}
+}
+
+func (rp *ResultPrinter) printNotification(notification domain.Notification) {
+	channel := notification.Channel
+	if channel == "" {
+		channel = domain.ChannelSMS
+	}
+
+	status := rp.catalog.T("delivery." + string(notification.Status))
+	if notification.Status == domain.DeliveryFailed || notification.Status == domain.DeliveryExpired {
+		status = rp.style.paint(padRight(status, 14), toneWarning)
+	}
+
+	line := fmt.Sprintf("  %s  %-9s %-12s %s %s",
+		notification.SentAt.Format("02.01.2006 15:04"),
+		channel,
+		notification.Event,
+		padRight(status, 14),
+		notification.MessageID)
+	if notification.Error != "" {
+		line += " (" + notification.Error + ")"
+	}
+	fmt.Fprintln(rp.out, line)
 }
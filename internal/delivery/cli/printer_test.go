@@ -0,0 +1,109 @@
+package cli_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icoder-new/installment-cli/internal/delivery/cli"
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/i18n"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want int
+	}{
+		{name: "latin", text: "Total", want: 5},
+		{name: "cyrillic", text: "Итоговая сумма", want: 14},
+		{name: "tajik letters", text: "Маблағи умумӣ", want: 13},
+		{name: "combining mark", text: "й", want: 1},
+		{name: "wide characters", text: "分期付款", want: 8},
+		{name: "fullwidth latin", text: "ＯＫ", want: 4},
+		{name: "halfwidth katakana", text: "ｶﾅ", want: 2},
+		{name: "emoji", text: "📱", want: 2},
+		{name: "colour codes", text: "\x1b[1;32m1030.00\x1b[0m", want: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, cli.DisplayWidth(tt.text))
+		})
+	}
+}
+
+func TestResultPrinterInstallmentResult(t *testing.T) {
+	product := domain.Product{Type: domain.Smartphone, Price: 1000, PhoneNumber: "+992931234567", PeriodMonths: 6}
+
+	tests := []struct {
+		name  string
+		lang  i18n.Language
+		style cli.Style
+		check func(t *testing.T, out string)
+	}{
+		{
+			name: "box lines have the same width",
+			lang: i18n.Tajik,
+			check: func(t *testing.T, out string) {
+				lines := strings.Split(strings.TrimSpace(out), "\n")
+				assert.Len(t, lines, 9)
+				for _, line := range lines {
+					assert.Equal(t, 42, cli.DisplayWidth(line), line)
+				}
+				assert.Contains(t, out, "6 моҳ")
+			},
+		},
+		{
+			name:  "plain style prints label and value lines",
+			lang:  i18n.Russian,
+			style: cli.Style{Plain: true},
+			check: func(t *testing.T, out string) {
+				assert.Equal(t, "РАССРОЧКА\nЦена товара: 1 000,00 сомони\nСрок: 6 мес.\n"+
+					"Итоговая сумма: 1 030,00 сомони\nПереплата: 30,00 сомони\n", out)
+			},
+		},
+		{
+			name:  "narrow terminal falls back to lines",
+			lang:  i18n.Russian,
+			style: cli.Style{Width: 30},
+			check: func(t *testing.T, out string) {
+				assert.NotContains(t, out, "╔")
+				assert.Contains(t, out, "Итоговая сумма: 1 030,00 сомони\n")
+			},
+		},
+		{
+			name:  "colour highlights the total and the overpayment",
+			lang:  i18n.Russian,
+			style: cli.Style{Color: true, Width: 90},
+			check: func(t *testing.T, out string) {
+				assert.Contains(t, out, "\x1b[1;32m1 030,00 сомони\x1b[0m")
+				assert.Contains(t, out, "\x1b[33m30,00 сомони\x1b[0m")
+				for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+					assert.Equal(t, 42, cli.DisplayWidth(line), line)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			printer := cli.NewResultPrinter(&out, tt.style, i18n.New(tt.lang))
+			printer.PrintInstallmentResult(product, 1030)
+			tt.check(t, out.String())
+		})
+	}
+}
+
+func TestDetectStyle(t *testing.T) {
+	env := map[string]string{"INSTALLMENT_PLAIN": "1"}
+	style := cli.DetectStyle(&bytes.Buffer{}, false, func(key string) string { return env[key] })
+	assert.Equal(t, cli.Style{Plain: true}, style)
+
+	style = cli.DetectStyle(&bytes.Buffer{}, false, func(string) string { return "" })
+	assert.Equal(t, cli.Style{}, style, "no colour outside a terminal")
+}
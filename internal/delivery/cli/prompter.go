@@ -2,19 +2,16 @@ package cli
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
 
 	"github.com/icoder-new/installment-cli/internal/domain"
-)
-
-const (
-	productTypePrompt       = "Выберите тип товара (1-Смартфон, 2-Компьютер, 3-Телевизор)"
-	pricePrompt             = "Введите цену товара (сомони)"
-	phonePrompt             = "Введите номер телефона (в формате 992XXXXXXXXX)"
-	installmentPeriodPrompt = "Введите срок рассрочки (доступно: %s)"
+	"github.com/icoder-new/installment-cli/internal/i18n"
+	"github.com/icoder-new/installment-cli/internal/phone"
 )
 
 var (
@@ -25,6 +22,14 @@ var (
 	}
 )
 
+const helpCommandInput = "?"
+
+// Form commands, returned by the prompts to PromptProduct.
+var (
+	errBack    = errors.New("back")
+	errRestart = errors.New("restart")
+)
+
 type PromptBuilder struct {
 	basePrompt   string
 	defaultValue string
@@ -47,65 +52,76 @@ func (pb *PromptBuilder) Build() string {
 	return prompt + ": "
 }
 
+// UserPrompter asks for the installment details. Answers come from the
+// answers file first and then from the input. Only a terminal gets another
+// try after a wrong answer: piped input or a file cannot correct itself, so
+// the prompter stops with an error instead, as it does at the end of input.
 type UserPrompter struct {
 	reader    *bufio.Reader
+	out       io.Writer
+	terminal  bool
+	answers   Answers
 	validator InputValidator
+	catalog   *i18n.Catalog
 }
 
-func NewUserPrompter() *UserPrompter {
+func NewUserPrompter(in io.Reader, out io.Writer, phones *phone.Parser, catalog *i18n.Catalog) *UserPrompter {
 	return &UserPrompter{
-		reader:    bufio.NewReader(os.Stdin),
-		validator: NewInputValidator(),
+		reader:    bufio.NewReader(in),
+		out:       out,
+		terminal:  isTerminal(in),
+		validator: NewInputValidator(phones, catalog),
+		catalog:   catalog,
 	}
 }
 
-func (p *UserPrompter) PromptProductType(defaultValue string) domain.ProductType {
-	defaultChoice := p.getDefaultProductTypeChoice(defaultValue)
-	promptBuilder := NewPromptBuilder(productTypePrompt).WithDefault(defaultChoice)
-
-	for {
-		fmt.Print(promptBuilder.Build())
-		input := p.readInput()
-		input = p.handleDefaultValue(input, defaultChoice)
+// WithAnswers makes the prompter take answers from a file. Each answer is
+// used once.
+func (p *UserPrompter) WithAnswers(answers Answers) *UserPrompter {
+	p.answers = answers
+	return p
+}
 
-		switch input {
-		case "1":
-			return domain.Smartphone
-		case "2":
-			return domain.Computer
-		case "3":
-			return domain.TV
-		}
+func (p *UserPrompter) PromptProductType(defaultValue string) (domain.ProductType, error) {
+	defaultChoice := p.getDefaultProductTypeChoice(defaultValue)
+	basePrompt := p.catalog.T("prompt.product",
+		p.catalog.Product(domain.Smartphone),
+		p.catalog.Product(domain.Computer),
+		p.catalog.Product(domain.TV))
+	promptBuilder := NewPromptBuilder(basePrompt).WithDefault(defaultChoice)
 
-		productType, err := p.validator.ValidateProductType(input)
-		if err == nil {
-			return productType
-		}
+	return promptWithValidation(p, AnswerProduct, promptBuilder, defaultChoice,
+		func(input string) (domain.ProductType, error) {
+			switch input {
+			case "1":
+				return domain.Smartphone, nil
+			case "2":
+				return domain.Computer, nil
+			case "3":
+				return domain.TV, nil
+			}
 
-		fmt.Println("Ошибка: выберите 1, 2 или 3, либо введите название товара")
-	}
+			if productType, err := p.validator.ValidateProductType(input); err == nil {
+				return productType, nil
+			}
+			return "", productError(p.catalog, p.catalog.T("error.product_choice"), input)
+		})
 }
 
-func (p *UserPrompter) PromptPrice(defaultValue float64) float64 {
+func (p *UserPrompter) PromptPrice(defaultValue float64) (float64, error) {
 	defaultChoice := p.getDefaultPriceChoice(defaultValue)
-	promptBuilder := NewPromptBuilder(pricePrompt).WithDefault(defaultChoice)
+	promptBuilder := NewPromptBuilder(p.catalog.T("prompt.price")).WithDefault(defaultChoice)
 
-	return p.promptFloat64WithValidation(promptBuilder, defaultChoice,
-		func(input string) (float64, error) {
-			return p.validator.ValidatePrice(input)
-		})
+	return promptWithValidation(p, AnswerPrice, promptBuilder, defaultChoice, p.validator.ValidatePrice)
 }
 
-func (p *UserPrompter) PromptPhoneNumber(defaultValue string) string {
-	promptBuilder := NewPromptBuilder(phonePrompt).WithDefault(defaultValue)
+func (p *UserPrompter) PromptPhoneNumber(defaultValue string) (string, error) {
+	promptBuilder := NewPromptBuilder(p.catalog.T("prompt.phone")).WithDefault(defaultValue)
 
-	return p.promptStringWithValidation(promptBuilder, defaultValue,
-		func(input string) (string, error) {
-			return p.validator.ValidatePhoneNumber(input)
-		})
+	return promptWithValidation(p, AnswerPhone, promptBuilder, defaultValue, p.validator.ValidatePhoneNumber)
 }
 
-func (p *UserPrompter) PromptInstallmentPeriod(defaultValue int, productType domain.ProductType) int {
+func (p *UserPrompter) PromptInstallmentPeriod(defaultValue int, productType domain.ProductType) (int, error) {
 	allowedPeriods := []int{3, 6, 9, 12, 18, 24}
 	var availablePeriods []string
 	var maxPeriod int
@@ -127,8 +143,7 @@ func (p *UserPrompter) PromptInstallmentPeriod(defaultValue int, productType dom
 		}
 	}
 
-	basePrompt := fmt.Sprintf("Выберите срок рассрочки (доступно: %s)",
-		strings.Join(availablePeriods, ", "))
+	basePrompt := p.catalog.T("prompt.period", strings.Join(availablePeriods, ", "))
 
 	defaultChoice := ""
 	if defaultValue > 0 {
@@ -137,110 +152,266 @@ func (p *UserPrompter) PromptInstallmentPeriod(defaultValue int, productType dom
 
 	promptBuilder := NewPromptBuilder(basePrompt).WithDefault(defaultChoice)
 
-	return p.promptIntWithValidation(promptBuilder, defaultChoice,
+	return promptWithValidation(p, AnswerMonths, promptBuilder, defaultChoice,
 		func(input string) (int, error) {
 			period, err := strconv.Atoi(input)
 			if err != nil {
-				return 0, fmt.Errorf("введите корректное число")
+				return 0, errors.New(p.catalog.T("error.not_a_number"))
 			}
 
 			valid := false
-			for _, p := range allowedPeriods {
-				if p == period && period <= maxPeriod {
+			for _, allowed := range allowedPeriods {
+				if allowed == period && period <= maxPeriod {
 					valid = true
 					break
 				}
 			}
 
 			if !valid {
-				return 0, fmt.Errorf("неверный срок рассрочки. Доступные значения: %v", availablePeriods)
+				return 0, errors.New(p.catalog.T("error.period_values", availablePeriods))
 			}
 
 			return period, nil
 		})
 }
 
-func (p *UserPrompter) promptStringWithValidation(
-	promptBuilder *PromptBuilder,
-	defaultValue string,
-	validator func(string) (string, error),
-) string {
-	for {
-		fmt.Print(promptBuilder.Build())
+// PromptProduct asks for the product details one by one, starting from the
+// values in initial. The cashier can go back a step, restart or, at the
+// review screen shown on a terminal, change a single field before the
+// installment is calculated.
+func (p *UserPrompter) PromptProduct(initial domain.Product) (domain.Product, error) {
+	product := initial
+	steps := p.productSteps(&product)
 
-		input := p.readInput()
-		input = p.handleDefaultValue(input, defaultValue)
+	fmt.Fprintln(p.out, p.catalog.T("prompt.navigation"))
 
-		if input == "" {
-			fmt.Println("Ошибка: поле не может быть пустым")
+	for step := 0; ; {
+		if step == len(steps) {
+			if !p.terminal {
+				return product, nil
+			}
+
+			field, err := p.promptReview(product)
+			switch {
+			case errors.Is(err, errBack):
+				step--
+				continue
+			case errors.Is(err, errRestart):
+				product, step = initial, 0
+				continue
+			case err != nil:
+				return product, err
+			case field == 0:
+				return product, nil
+			}
+
+			// Change one field and come back to the review. The allowed
+			// periods depend on the product, so a new product asks for the
+			// period again.
+			edit := []func() error{steps[field-1]}
+			if field == 1 {
+				edit = append(edit, steps[len(steps)-1])
+			}
+			for _, ask := range edit {
+				if err := ask(); err != nil {
+					if !isNavigation(err) {
+						return product, err
+					}
+					break
+				}
+			}
 			continue
 		}
 
-		result, err := validator(input)
-		if err != nil {
-			fmt.Printf("Ошибка: %s\n", err.Error())
-			continue
+		err := steps[step]()
+		switch {
+		case errors.Is(err, errBack):
+			step = max(step-1, 0)
+		case errors.Is(err, errRestart):
+			product, step = initial, 0
+		case err != nil:
+			return product, err
+		default:
+			step++
 		}
+	}
+}
 
-		return result
+// productSteps returns the questions of the form. Each one offers the
+// current value as the default, so going back keeps what was entered.
+func (p *UserPrompter) productSteps(product *domain.Product) []func() error {
+	return []func() error{
+		func() error {
+			productType, err := p.PromptProductType(string(product.Type))
+			if err == nil {
+				product.Type = productType
+			}
+			return err
+		},
+		func() error {
+			price, err := p.PromptPrice(product.Price)
+			if err == nil {
+				product.Price = price
+			}
+			return err
+		},
+		func() error {
+			phoneNumber, err := p.PromptPhoneNumber(product.PhoneNumber)
+			if err == nil {
+				product.PhoneNumber = phoneNumber
+			}
+			return err
+		},
+		func() error {
+			months, err := p.PromptInstallmentPeriod(product.PeriodMonths, product.Type)
+			if err == nil {
+				product.PeriodMonths = months
+			}
+			return err
+		},
 	}
 }
 
-func (p *UserPrompter) promptIntWithValidation(
-	promptBuilder *PromptBuilder,
-	defaultValue string,
-	validator func(string) (int, error),
-) int {
-	for {
-		fmt.Print(promptBuilder.Build())
+// promptReview lists the entered values and returns the number of the field
+// to change, or 0 to calculate.
+func (p *UserPrompter) promptReview(product domain.Product) (int, error) {
+	fmt.Fprintln(p.out)
+	fmt.Fprintln(p.out, p.catalog.T("review.title"))
+	for i, row := range [][2]string{
+		{p.catalog.T("review.product"), p.catalog.Product(product.Type)},
+		{p.catalog.T("review.price"), p.catalog.Money(product.Price) + " " + p.catalog.T("currency")},
+		{p.catalog.T("review.phone"), product.PhoneNumber},
+		{p.catalog.T("review.months"), strconv.Itoa(product.PeriodMonths)},
+	} {
+		fmt.Fprintf(p.out, "  %d. %s: %s\n", i+1, row[0], row[1])
+	}
 
-		input := p.readInput()
-		input = p.handleDefaultValue(input, defaultValue)
+	for {
+		fmt.Fprint(p.out, NewPromptBuilder(p.catalog.T("review.prompt")).Build())
 
-		if input == "" {
-			fmt.Println("Ошибка: поле не может быть пустым")
-			continue
+		input, _, err := p.readAnswer("", p.catalog.T("review.title"))
+		if err != nil {
+			return 0, err
 		}
 
-		result, err := validator(input)
-		if err != nil {
-			fmt.Printf("Ошибка: %s\n", err.Error())
+		switch input {
+		case "":
+			return 0, nil
+		case "1", "2", "3", "4":
+			return strconv.Atoi(input)
+		case helpCommandInput:
+			fmt.Fprintln(p.out, p.catalog.T("help.review"))
 			continue
 		}
-
-		return result
+		if err := p.navigation(input); err != nil {
+			return 0, err
+		}
+		p.printError(p.catalog.T("error.review_choice"))
 	}
 }
 
-func (p *UserPrompter) promptFloat64WithValidation(
+func isNavigation(err error) bool {
+	return errors.Is(err, errBack) || errors.Is(err, errRestart)
+}
+
+func (p *UserPrompter) PromptConsentCode() (string, error) {
+	promptBuilder := NewPromptBuilder(p.catalog.T("prompt.consent_code"))
+
+	return promptWithValidation(p, AnswerConsentCode, promptBuilder, "",
+		func(input string) (string, error) {
+			if len(input) != 6 || strings.Trim(input, "0123456789") != "" {
+				return "", errors.New(p.catalog.T("error.consent_code_format"))
+			}
+			return input, nil
+		})
+}
+
+// promptWithValidation asks until the answer passes validator. "?" prints the
+// help for the field; "назад" and "заново" return errBack and errRestart to
+// the form, except for the consent code, which is not part of it. It is a
+// function because methods cannot have type parameters.
+func promptWithValidation[T any](
+	p *UserPrompter,
+	key string,
 	promptBuilder *PromptBuilder,
 	defaultValue string,
-	validator func(string) (float64, error),
-) float64 {
+	validator func(string) (T, error),
+) (T, error) {
+	var zero T
 	for {
-		fmt.Print(promptBuilder.Build())
+		fmt.Fprint(p.out, promptBuilder.Build())
+
+		input, fromTerminal, err := p.readAnswer(key, promptBuilder.basePrompt)
+		if err != nil {
+			return zero, err
+		}
 
-		input := p.readInput()
+		if input == helpCommandInput {
+			fmt.Fprintln(p.out, p.catalog.T("help."+key))
+			continue
+		}
+		if err := p.navigation(input); err != nil && key != AnswerConsentCode {
+			return zero, err
+		}
 		input = p.handleDefaultValue(input, defaultValue)
 
+		var message string
 		if input == "" {
-			fmt.Println("Ошибка: поле не может быть пустым")
-			continue
+			message = p.catalog.T("error.empty_field")
+		} else {
+			result, err := validator(input)
+			if err == nil {
+				return result, nil
+			}
+			message = err.Error()
 		}
 
-		result, err := validator(input)
-		if err != nil {
-			fmt.Printf("Ошибка: %s\n", err.Error())
-			continue
+		if !fromTerminal {
+			return zero, errors.New(p.catalog.T("error.invalid_answer", promptBuilder.basePrompt, message))
 		}
+		p.printError(message)
+	}
+}
 
-		return result
+// navigation returns errBack or errRestart if input is one of the form
+// commands in the catalog language.
+func (p *UserPrompter) navigation(input string) error {
+	switch strings.ToLower(input) {
+	case p.catalog.T("prompt.back"):
+		return errBack
+	case p.catalog.T("prompt.restart"):
+		return errRestart
 	}
+	return nil
+}
+
+func (p *UserPrompter) printError(message string) {
+	fmt.Fprintln(p.out, p.catalog.T("error.prefix")+message)
 }
 
-func (p *UserPrompter) readInput() string {
-	input, _ := p.reader.ReadString('\n')
-	return strings.TrimSpace(input)
+// readAnswer returns the answer from the file or the next input line, and
+// whether it was typed on a terminal.
+func (p *UserPrompter) readAnswer(key, question string) (string, bool, error) {
+	if answer, ok := p.answers[key]; ok {
+		delete(p.answers, key)
+		fmt.Fprintln(p.out, answer)
+		return answer, false, nil
+	}
+
+	input, err := p.reader.ReadString('\n')
+	if errors.Is(err, io.EOF) && input == "" {
+		// Finish the prompt line so the error does not stick to it.
+		fmt.Fprintln(p.out)
+		return "", false, errors.New(p.catalog.T("error.input_closed", question))
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", false, err
+	}
+	if !p.terminal {
+		fmt.Fprintln(p.out, strings.TrimSpace(input))
+	}
+
+	return strings.TrimSpace(input), p.terminal, nil
 }
 
 func (p *UserPrompter) handleDefaultValue(input, defaultValue string) string {
@@ -255,17 +426,27 @@ func (p *UserPrompter) getDefaultProductTypeChoice(defaultValue string) string {
 		return ""
 	}
 
-	productType := domain.ProductType(strings.ToLower(defaultValue))
-	if command, exists := commandMap[productType]; exists {
-		return command
+	productType, ok := resolveProductType(defaultValue)
+	if !ok {
+		return ""
 	}
 
-	return ""
+	return commandMap[productType]
 }
 
 func (p *UserPrompter) getDefaultPriceChoice(defaultValue float64) string {
 	if defaultValue <= 0 {
 		return ""
 	}
-	return formatPrice(defaultValue)
+	return p.catalog.Money(defaultValue)
+}
+
+// isTerminal reports whether in is a character device, i.e. someone typing.
+func isTerminal(in io.Reader) bool {
+	file, ok := in.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
 }
@@ -0,0 +1,217 @@
+package cli_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/icoder-new/installment-cli/internal/delivery/cli"
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/i18n"
+	"github.com/icoder-new/installment-cli/internal/phone"
+)
+
+func newTestPrompter(input string) (*cli.UserPrompter, *bytes.Buffer) {
+	var out bytes.Buffer
+	parser, _ := phone.NewParser()
+	return cli.NewUserPrompter(strings.NewReader(input), &out, parser, i18n.New(i18n.Russian)), &out
+}
+
+func TestUserPrompterReadsPipedAnswers(t *testing.T) {
+	prompter, out := newTestPrompter("2\n1500\n+992 93 123 45 67\n12\n")
+
+	productType, err := prompter.PromptProductType("")
+	require.NoError(t, err)
+	price, err := prompter.PromptPrice(0)
+	require.NoError(t, err)
+	phoneNumber, err := prompter.PromptPhoneNumber("")
+	require.NoError(t, err)
+	months, err := prompter.PromptInstallmentPeriod(0, productType)
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.Computer, productType)
+	assert.Equal(t, 1500.0, price)
+	assert.Equal(t, "+992931234567", phoneNumber)
+	assert.Equal(t, 12, months)
+	assert.Contains(t, out.String(), "1500\n")
+}
+
+func TestUserPrompterDefaults(t *testing.T) {
+	prompter, _ := newTestPrompter("\n")
+
+	price, err := prompter.PromptPrice(2500)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2500.0, price)
+}
+
+func TestUserPrompterPriceFormats(t *testing.T) {
+	tests := []struct {
+		input string
+		price float64
+		err   string
+	}{
+		{input: "1 500,50", price: 1500.5},
+		{input: "1500 сомони", price: 1500},
+		{input: "1.500", price: 1500},
+		{input: "1,500.50", price: 1500.5},
+		{input: "1500,555", err: "не может быть больше двух знаков после запятой"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			prompter, _ := newTestPrompter(tt.input + "\n")
+
+			price, err := prompter.PromptPrice(0)
+			if tt.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.price, price)
+		})
+	}
+}
+
+func TestUserPrompterStopsOnEndOfInput(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		prompt func(p *cli.UserPrompter) error
+	}{
+		{
+			name:  "product",
+			input: "",
+			prompt: func(p *cli.UserPrompter) error {
+				_, err := p.PromptProductType("")
+				return err
+			},
+		},
+		{
+			name:  "consent code",
+			input: "",
+			prompt: func(p *cli.UserPrompter) error {
+				_, err := p.PromptConsentCode()
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompter, _ := newTestPrompter(tt.input)
+
+			err := tt.prompt(prompter)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "ввод закончился")
+		})
+	}
+}
+
+func TestUserPrompterRejectsInvalidPipedAnswer(t *testing.T) {
+	prompter, _ := newTestPrompter("дорого\n1000\n")
+
+	_, err := prompter.PromptPrice(0)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "неверный ответ")
+}
+
+func TestUserPrompterAnswersFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("product: Телевизор\nprice: 3000\nconsent_code: 012345\n"), 0o644))
+
+	answers, err := cli.LoadAnswers(path, i18n.New(i18n.Russian))
+	require.NoError(t, err)
+
+	prompter, _ := newTestPrompter("18\n")
+	prompter.WithAnswers(answers)
+
+	productType, err := prompter.PromptProductType("")
+	require.NoError(t, err)
+	price, err := prompter.PromptPrice(0)
+	require.NoError(t, err)
+	months, err := prompter.PromptInstallmentPeriod(0, productType)
+	require.NoError(t, err)
+	code, err := prompter.PromptConsentCode()
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.TV, productType)
+	assert.Equal(t, 3000.0, price)
+	assert.Equal(t, 18, months, "questions without an answer are read from the input")
+	assert.Equal(t, "012345", code)
+}
+
+func TestLoadAnswersRejectsUnknownKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("prise: 3000\n"), 0o644))
+
+	_, err := cli.LoadAnswers(path, i18n.New(i18n.Russian))
+
+	assert.Error(t, err)
+}
+
+func TestUserPrompterProductForm(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		terminal bool
+		want     domain.Product
+	}{
+		{
+			name:  "back keeps the entered values as defaults",
+			input: "1\n100\nназад\nназад\n\n1000\n931234567\n6\n",
+			want:  domain.Product{Type: domain.Smartphone, Price: 1000, PhoneNumber: "+992931234567", PeriodMonths: 6},
+		},
+		{
+			name:  "restart starts over",
+			input: "1\n100\nзаново\n2\n2000\n931234567\n12\n",
+			want:  domain.Product{Type: domain.Computer, Price: 2000, PhoneNumber: "+992931234567", PeriodMonths: 12},
+		},
+		{
+			name:  "help does not use up the question",
+			input: "?\n3\n3000\n931234567\n18\n",
+			want:  domain.Product{Type: domain.TV, Price: 3000, PhoneNumber: "+992931234567", PeriodMonths: 18},
+		},
+		{
+			name:     "review changes a single field",
+			input:    "1\n1000\n931234567\n6\n2\n1200\n\n",
+			terminal: true,
+			want:     domain.Product{Type: domain.Smartphone, Price: 1200, PhoneNumber: "+992931234567", PeriodMonths: 6},
+		},
+		{
+			name:     "a new product asks for the period again",
+			input:    "1\n1000\n931234567\n6\n1\n3\n18\n\n",
+			terminal: true,
+			want:     domain.Product{Type: domain.TV, Price: 1000, PhoneNumber: "+992931234567", PeriodMonths: 18},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompter, _ := newTestPrompter(tt.input)
+			prompter.SetTerminal(tt.terminal)
+
+			product, err := prompter.PromptProduct(domain.Product{})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, product)
+		})
+	}
+}
+
+func TestUserPrompterHelp(t *testing.T) {
+	prompter, out := newTestPrompter("?\n1000\n")
+
+	_, err := prompter.PromptPrice(0)
+
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "Цена товара в сомони")
+}
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/icoder-new/installment-cli/internal/i18n"
+	"github.com/icoder-new/installment-cli/internal/usecase"
+)
+
+type RemindHandler struct {
+	reminders *usecase.ReminderService
+	out       io.Writer
+	catalog   *i18n.Catalog
+}
+
+func NewRemindHandler(reminders *usecase.ReminderService, out io.Writer, catalog *i18n.Catalog) *RemindHandler {
+	return &RemindHandler{
+		reminders: reminders,
+		out:       out,
+		catalog:   catalog,
+	}
+}
+
+func (h *RemindHandler) Run(ctx context.Context, args []string) error {
+	var daysBefore, daysOverdue int
+
+	fs := newFlagSet(h.catalog, "remind")
+	fs.IntVar(&daysBefore, "days", 3, h.catalog.T("flag.days"))
+	fs.IntVar(&daysOverdue, "overdue", 1, h.catalog.T("flag.overdue"))
+
+	if err := parseFlags(h.catalog, fs, args); err != nil {
+		return err
+	}
+
+	if daysBefore < 0 || daysOverdue < 0 {
+		return errors.New(h.catalog.T("error.days_negative"))
+	}
+
+	sent, err := h.reminders.SendReminders(ctx, daysBefore, daysOverdue)
+	fmt.Fprintln(h.out, h.catalog.T("remind.sent", sent))
+	return err
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/delivery/httpapi"
+	"github.com/icoder-new/installment-cli/internal/i18n"
+	"github.com/icoder-new/installment-cli/internal/infra/webhook"
+	"github.com/icoder-new/installment-cli/internal/phone"
+	"github.com/icoder-new/installment-cli/internal/usecase"
+)
+
+const (
+	defaultServeAddr = ":8080"
+
+	// webhookRetryInterval is how often serve looks for queued webhooks
+	// that are due for another attempt.
+	webhookRetryInterval = 5 * time.Second
+)
+
+type ServeHandler struct {
+	deliveries *usecase.DeliveryService
+	calculator *usecase.InstallmentCalculator
+	consents   *usecase.ConsentService
+	webhooks   *webhook.Dispatcher
+	phones     *phone.Parser
+	dlrToken   string
+	apiToken   string
+	catalog    *i18n.Catalog
+}
+
+func NewServeHandler(
+	deliveries *usecase.DeliveryService,
+	calculator *usecase.InstallmentCalculator,
+	consents *usecase.ConsentService,
+	webhooks *webhook.Dispatcher,
+	phones *phone.Parser,
+	dlrToken string,
+	apiToken string,
+	catalog *i18n.Catalog,
+) *ServeHandler {
+	return &ServeHandler{
+		deliveries: deliveries,
+		calculator: calculator,
+		consents:   consents,
+		webhooks:   webhooks,
+		phones:     phones,
+		dlrToken:   dlrToken,
+		apiToken:   apiToken,
+		catalog:    catalog,
+	}
+}
+
+func (h *ServeHandler) Run(ctx context.Context, args []string) error {
+	var addr string
+
+	fs := newFlagSet(h.catalog, "serve")
+	fs.StringVar(&addr, "addr", defaultServeAddr, h.catalog.T("flag.addr"))
+
+	if err := parseFlags(h.catalog, fs, args); err != nil {
+		return err
+	}
+
+	if h.dlrToken == "" {
+		return errors.New(h.catalog.T("error.dlr_token"))
+	}
+	if h.apiToken == "" {
+		return errors.New(h.catalog.T("error.api_token"))
+	}
+
+	logger := log.New(os.Stderr, "", log.LstdFlags)
+	server := httpapi.NewServer(addr,
+		httpapi.RequireToken(h.dlrToken, httpapi.NewDeliveryReportHandler(h.deliveries, logger)),
+		httpapi.RequireToken(h.apiToken, httpapi.NewConsentHandler(h.calculator, h.consents, h.phones, logger)))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go h.webhooks.Run(ctx, webhookRetryInterval)
+
+	logger.Printf("прием отчетов о доставке на %s/sms/dlr, подтверждение согласия на %s/consents", addr, addr)
+	return server.Run(ctx)
+}
@@ -0,0 +1,280 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/i18n"
+)
+
+var shellCommands = []string{"new", "quote", "confirm", "history", "repeat", "help", "exit"}
+
+// quoteEntry is a calculation made in the shell session.
+type quoteEntry struct {
+	product      domain.Product
+	totalPayment float64
+	contractID   string
+}
+
+// ShellHandler runs a session at the counter: the storage, the SMS provider
+// and the settings are set up once and the calculations of the session are
+// remembered, so the program does not have to be started for every customer.
+// Commands other than the shell's own are passed to the app.
+type ShellHandler struct {
+	app         *App
+	handler     *Handler
+	in          io.Reader
+	out         io.Writer
+	historyPath string
+	catalog     *i18n.Catalog
+}
+
+func NewShellHandler(app *App, handler *Handler, in io.Reader, out io.Writer, historyPath string, catalog *i18n.Catalog) *ShellHandler {
+	return &ShellHandler{
+		app:         app,
+		handler:     handler,
+		in:          in,
+		out:         out,
+		historyPath: historyPath,
+		catalog:     catalog,
+	}
+}
+
+// shellSession is the state of one shell run.
+type shellSession struct {
+	*ShellHandler
+	handler *Handler
+	history []quoteEntry
+	// current is the index of the calculation confirm opens, or -1.
+	current int
+}
+
+func (h *ShellHandler) Run(ctx context.Context, args []string) error {
+	fs := newFlagSet(h.catalog, "shell")
+	if err := parseFlags(h.catalog, fs, args); err != nil {
+		return err
+	}
+
+	console, err := newConsole(h.in, h.out, h.historyPath, h.complete)
+	if err != nil {
+		return err
+	}
+
+	prompter := NewUserPrompter(console, console, h.handler.phones, h.catalog)
+	prompter.terminal = console.isTerminal()
+	session := &shellSession{
+		ShellHandler: h,
+		handler:      h.handler.withPrompter(prompter),
+		current:      -1,
+	}
+
+	fmt.Fprintln(h.out, h.catalog.T("shell.welcome"))
+	for {
+		line, err := console.ReadCommand(programName() + "> ")
+		if errors.Is(err, io.EOF) {
+			fmt.Fprintln(h.out)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "exit" || fields[0] == "quit" {
+			return nil
+		}
+
+		err = session.execute(ctx, fields[0], fields[1:])
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if errors.Is(err, flag.ErrHelp) {
+			printHelp(h.out, err)
+			continue
+		}
+		if err != nil {
+			fmt.Fprintf(h.out, "%s%s\n", h.catalog.T("error.prefix"), h.catalog.Error(err))
+		}
+	}
+}
+
+func (s *shellSession) execute(ctx context.Context, command string, args []string) error {
+	switch command {
+	case "new":
+		return s.quote(ctx, domain.Product{})
+	case "quote":
+		return s.quoteCommand(ctx, args)
+	case "confirm":
+		return s.confirm(ctx, args)
+	case "history":
+		s.printHistory()
+		return nil
+	case "repeat":
+		return s.repeat(ctx, args)
+	case "help":
+		if len(args) > 0 {
+			return s.app.Dispatch(ctx, append([]string{helpCommand}, args...))
+		}
+		s.printHelp()
+		return nil
+	case "calc":
+		// calc asks its questions, so it has to read them from the shell.
+		return s.handler.Run(ctx, args)
+	case "shell":
+		return errors.New(s.catalog.T("error.shell_nested"))
+	}
+	return s.app.Dispatch(ctx, append([]string{command}, args...))
+}
+
+// quoteCommand calculates from flags or, without them, lets the cashier
+// change the current calculation.
+func (s *shellSession) quoteCommand(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		if s.current < 0 {
+			return s.quote(ctx, domain.Product{})
+		}
+		return s.quote(ctx, s.history[s.current].product)
+	}
+
+	flags, err := s.handler.flagParser.Parse("quote", args, false)
+	if err != nil {
+		return err
+	}
+	product, err := s.handler.collectInput(flags, s.catalog.T("form.submit_quote"))
+	if err != nil {
+		return err
+	}
+	return s.calculate(ctx, product)
+}
+
+// quote asks for the product, starting from initial, and calculates it.
+func (s *shellSession) quote(ctx context.Context, initial domain.Product) error {
+	product, err := s.handler.prompter.PromptProduct(initial)
+	if err != nil {
+		return err
+	}
+	return s.calculate(ctx, product)
+}
+
+func (s *shellSession) calculate(ctx context.Context, product domain.Product) error {
+	totalPayment, err := s.handler.quote(ctx, product)
+	if err != nil {
+		return err
+	}
+
+	s.history = append(s.history, quoteEntry{product: product, totalPayment: totalPayment})
+	s.current = len(s.history) - 1
+	fmt.Fprintln(s.out, s.catalog.T("shell.quoted"))
+	return nil
+}
+
+// confirm opens the contract for the current calculation.
+func (s *shellSession) confirm(ctx context.Context, args []string) error {
+	flags := &Flags{}
+	fs := newFlagSet(s.catalog, "confirm")
+	fs.StringVar(&flags.SMSLanguage, "sms-lang", "", s.catalog.T("flag.sms_lang"))
+	fs.BoolVar(&flags.Consent, "consent", false, s.catalog.T("flag.consent"))
+	if err := parseFlags(s.catalog, fs, args); err != nil {
+		return err
+	}
+
+	if s.current < 0 {
+		return errors.New(s.catalog.T("error.shell_no_quote"))
+	}
+
+	contract, err := s.handler.open(ctx, s.history[s.current].product, flags)
+	if contract.ID != "" {
+		s.history[s.current].contractID = contract.ID
+		s.current = -1
+	}
+	return err
+}
+
+// repeat starts a new calculation from an earlier one, by default the last.
+func (s *shellSession) repeat(ctx context.Context, args []string) error {
+	if len(s.history) == 0 {
+		return errors.New(s.catalog.T("error.shell_no_history"))
+	}
+
+	n := len(s.history)
+	if len(args) > 0 {
+		var err error
+		n, err = strconv.Atoi(args[0])
+		if err != nil || n < 1 || n > len(s.history) {
+			return usageError(s.catalog.T("error.shell_history_number", args[0], len(s.history)))
+		}
+	}
+	return s.quote(ctx, s.history[n-1].product)
+}
+
+func (s *shellSession) printHistory() {
+	if len(s.history) == 0 {
+		fmt.Fprintln(s.out, s.catalog.T("shell.history_empty"))
+		return
+	}
+
+	for i, entry := range s.history {
+		fmt.Fprintf(s.out, "%d. %s\n", i+1, s.catalog.T("shell.history_row",
+			s.catalog.Product(entry.product.Type),
+			s.catalog.Money(entry.product.Price),
+			entry.product.PeriodMonths,
+			s.catalog.Money(entry.totalPayment),
+			s.catalog.T("currency"),
+			entry.product.PhoneNumber))
+		if entry.contractID != "" {
+			fmt.Fprintln(s.out, "   "+s.catalog.T("result.contract_id", entry.contractID))
+		}
+	}
+}
+
+func (s *shellSession) printHelp() {
+	for _, name := range shellCommands {
+		fmt.Fprintf(s.out, "  %-10s %s\n", name, s.catalog.T("shell.command."+name))
+	}
+	fmt.Fprintln(s.out, s.catalog.T("shell.help_footer"))
+}
+
+// complete returns the words Tab can complete to after before: command names
+// at the start of a command line, product names and periods after their
+// flags, and product names, periods and the form commands in the prompts.
+func (h *ShellHandler) complete(before string, command bool) []string {
+	products := []string{
+		h.catalog.Product(domain.Smartphone),
+		h.catalog.Product(domain.Computer),
+		h.catalog.Product(domain.TV),
+	}
+	periods := []string{"3", "6", "9", "12", "18", "24"}
+
+	if !command {
+		return append(append(products, periods...), h.catalog.T("prompt.back"), h.catalog.T("prompt.restart"))
+	}
+
+	fields := strings.Fields(before)
+	if len(fields) == 0 {
+		names := slices.Clone(shellCommands)
+		for _, name := range h.app.Commands() {
+			if name != "shell" && !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+
+	switch fields[len(fields)-1] {
+	case "-p", "--product", "-product":
+		return products
+	case "-m", "--months", "-months":
+		return periods
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/icoder-new/installment-cli/internal/delivery/cli"
+	"github.com/icoder-new/installment-cli/internal/i18n"
+	"github.com/icoder-new/installment-cli/internal/phone"
+	"github.com/icoder-new/installment-cli/internal/usecase"
+)
+
+func runShell(t *testing.T, input string) string {
+	t.Helper()
+
+	catalog := i18n.New(i18n.Russian)
+	parser, err := phone.NewParser()
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	calculator := usecase.NewInstallmentCalculator(usecase.NewEventBus(log.New(io.Discard, "", 0)))
+	printer := cli.NewResultPrinter(&out, cli.Style{}, catalog)
+	handler := cli.NewHandler(calculator, nil, nil, nil, parser, printer, strings.NewReader(""), &out, catalog)
+	app := cli.NewApp(&out, &out, catalog, "calc", cli.Command{Name: "calc", Run: handler.Run})
+
+	shell := cli.NewShellHandler(app, handler, strings.NewReader(input), &out, "", catalog)
+	require.NoError(t, shell.Run(context.Background(), nil))
+	return out.String()
+}
+
+func TestShellKeepsTheSessionHistory(t *testing.T) {
+	out := runShell(t, strings.Join([]string{
+		"new", "1", "1000", "931234567", "6",
+		"quote -p Телевизор -c 3000 -n 931234567 -m 12",
+		"history",
+	}, "\n")+"\n")
+
+	assert.Contains(t, out, "1. Смартфон, 1 000,00 на 6 мес.: 1 030,00 сомони, телефон +992931234567")
+	assert.Contains(t, out, "2. Телевизор, 3 000,00 на 12 мес.: 3 450,00 сомони, телефон +992931234567")
+}
+
+func TestShellRepeatStartsFromAnEarlierCalculation(t *testing.T) {
+	out := runShell(t, strings.Join([]string{
+		"new", "2", "2000", "931234567", "12",
+		"repeat 1", "", "2500", "", "",
+		"history",
+	}, "\n")+"\n")
+
+	assert.Contains(t, out, "Введите цену товара (сомони) [2 000,00]: 2500")
+	assert.Contains(t, out, "2. Компьютер, 2 500,00 на 12 мес.")
+}
+
+func TestShellReportsErrorsAndGoesOn(t *testing.T) {
+	out := runShell(t, "confirm\nrepeat\nrefund\nhistory\nexit\nhistory\n")
+
+	assert.Contains(t, out, "нет расчета для оформления")
+	assert.Contains(t, out, "в этом сеансе еще не было расчетов")
+	assert.Contains(t, out, `неизвестная команда "refund"`)
+	assert.Equal(t, 1, strings.Count(out, "В этом сеансе еще не было расчетов"), "exit ends the session")
+}
+
+func TestShellPrintsCommandHelp(t *testing.T) {
+	out := runShell(t, "help calc\nconfirm -h\n")
+
+	assert.Contains(t, out, "-c, --cost ЦЕНА")
+	assert.Contains(t, out, "Использование: cli.test confirm [ПАРАМЕТРЫ]")
+	assert.NotContains(t, out, "Ошибка")
+}
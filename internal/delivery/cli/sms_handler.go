@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/i18n"
+	"github.com/icoder-new/installment-cli/internal/infra/sms"
+	"github.com/icoder-new/installment-cli/internal/phone"
+	"github.com/icoder-new/installment-cli/internal/usecase"
+)
+
+type SMSHandler struct {
+	templatesDir string
+	policy       sms.CompactionPolicy
+	customers    *usecase.CustomerService
+	outbox       *usecase.OutboxService
+	phones       *phone.Parser
+	out          io.Writer
+	catalog      *i18n.Catalog
+}
+
+func NewSMSHandler(
+	templatesDir string,
+	policy sms.CompactionPolicy,
+	customers *usecase.CustomerService,
+	outbox *usecase.OutboxService,
+	phones *phone.Parser,
+	out io.Writer,
+	catalog *i18n.Catalog,
+) *SMSHandler {
+	return &SMSHandler{
+		templatesDir: templatesDir,
+		policy:       policy,
+		customers:    customers,
+		outbox:       outbox,
+		phones:       phones,
+		out:          out,
+		catalog:      catalog,
+	}
+}
+
+func (h *SMSHandler) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(h.catalog.T("sms.usage"))
+	}
+
+	switch args[0] {
+	case "preview":
+		return h.preview(args[1:])
+	case "opt-out", "opt-in":
+		return h.setOptOut(args[0], args[1:])
+	case "flush":
+		sent, err := h.outbox.Flush(ctx)
+		fmt.Fprintln(h.out, h.catalog.T("sms.flushed", sent))
+		return err
+	default:
+		return usageError(h.catalog.T("sms.usage"))
+	}
+}
+
+func (h *SMSHandler) setOptOut(command string, args []string) error {
+	var phoneNumber string
+	optedOut := command == "opt-out"
+
+	fs := newFlagSet(h.catalog, "sms "+command)
+	fs.StringVar(&phoneNumber, "phone", "", h.catalog.T("flag.customer_phone"))
+
+	if err := parseFlags(h.catalog, fs, args); err != nil {
+		return err
+	}
+
+	if phoneNumber == "" {
+		return usageError(h.catalog.T("sms.usage"))
+	}
+
+	phoneNumber, err := parsePhoneNumber(h.phones, h.catalog, phoneNumber)
+	if err != nil {
+		return err
+	}
+
+	if err := h.customers.SetOptOut(phoneNumber, optedOut); err != nil {
+		return err
+	}
+
+	if optedOut {
+		fmt.Fprintln(h.out, h.catalog.T("sms.opted_out", phoneNumber))
+	} else {
+		fmt.Fprintln(h.out, h.catalog.T("sms.opted_in", phoneNumber))
+	}
+	return nil
+}
+
+func (h *SMSHandler) preview(args []string) error {
+	var event, templatesDir, language string
+	policy := h.policy
+
+	fs := newFlagSet(h.catalog, "sms preview")
+	fs.StringVar(&event, "event", string(domain.EventPurchase), h.catalog.T("flag.event", eventNames()))
+	fs.StringVar(&templatesDir, "templates", h.templatesDir, h.catalog.T("flag.templates"))
+	fs.StringVar(&language, "sms-lang", string(i18n.DefaultLanguage), h.catalog.T("flag.sms_text_lang"))
+	fs.IntVar(&policy.MaxSegments, "max-segments", policy.MaxSegments, h.catalog.T("flag.max_segments"))
+	fs.BoolVar(&policy.Transliterate, "transliterate", policy.Transliterate, h.catalog.T("flag.transliterate"))
+
+	if err := parseFlags(h.catalog, fs, args); err != nil {
+		return err
+	}
+
+	renderer, err := sms.NewTemplateRenderer(templatesDir)
+	if err != nil {
+		return err
+	}
+
+	message, err := renderer.Render(language, domain.MessageEvent(event), sms.SampleMessageData())
+	if err != nil {
+		return fmt.Errorf("%w (доступные события: %s)", err, eventNames())
+	}
+
+	fmt.Fprintln(h.out, message)
+	h.printAnalysis(sms.Analyze(message))
+
+	if policy.Enabled() {
+		compacted, analysis := policy.Compact(message)
+		fmt.Fprintln(h.out)
+		fmt.Fprintln(h.out, h.catalog.T("sms.compacted", policy.MaxSegments))
+		fmt.Fprintln(h.out, compacted)
+		h.printAnalysis(analysis)
+	}
+
+	return nil
+}
+
+func (h *SMSHandler) printAnalysis(analysis sms.Analysis) {
+	fmt.Fprintln(h.out, h.catalog.T("sms.analysis", analysis.Encoding, analysis.Units, analysis.Segments, analysis.Remaining))
+}
+
+func eventNames() string {
+	names := make([]string, len(domain.MessageEvents))
+	for i, event := range domain.MessageEvents {
+		names[i] = string(event)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"io"
+	"os"
+	"strings"
+	"unicode"
+
+	"golang.org/x/term"
+	"golang.org/x/text/width"
+)
+
+// Style is how results are printed: with colour, as a plain list for screen
+// readers, and for which terminal width (0 when output is not a terminal).
+type Style struct {
+	Color bool
+	Plain bool
+	Width int
+}
+
+// DetectStyle chooses the style for out. Colour is used only on a terminal,
+// unless NO_COLOR is set or TERM is dumb; plain output is asked for with
+// --plain or INSTALLMENT_PLAIN.
+func DetectStyle(out io.Writer, plain bool, getenv func(string) string) Style {
+	style := Style{Plain: plain || getenv("INSTALLMENT_PLAIN") != ""}
+
+	file, ok := out.(*os.File)
+	if !ok || !term.IsTerminal(int(file.Fd())) {
+		return style
+	}
+
+	if width, _, err := term.GetSize(int(file.Fd())); err == nil {
+		style.Width = width
+	}
+	style.Color = !style.Plain && getenv("NO_COLOR") == "" && getenv("TERM") != "dumb"
+	return style
+}
+
+type tone int
+
+const (
+	toneNormal tone = iota
+	toneTotal
+	toneWarning
+	toneSuccess
+)
+
+var toneCodes = map[tone]string{
+	toneTotal:   "\x1b[1;32m",
+	toneWarning: "\x1b[33m",
+	toneSuccess: "\x1b[32m",
+}
+
+// paint colours s in tone when the style has colour.
+func (s Style) paint(text string, t tone) string {
+	code, ok := toneCodes[t]
+	if !s.Color || !ok {
+		return text
+	}
+	return code + text + "\x1b[0m"
+}
+
+// displayWidth returns how many terminal columns text takes: combining
+// marks take none, East Asian wide characters and emoji take two. Escape
+// sequences are not counted.
+func displayWidth(text string) int {
+	columns, escape := 0, false
+	for _, r := range text {
+		switch {
+		case r == '\x1b':
+			escape = true
+		case escape:
+			if r == 'm' {
+				escape = false
+			}
+		default:
+			columns += runeWidth(r)
+		}
+	}
+	return columns
+}
+
+// runeWidth returns how many columns r takes: none for combining marks and
+// control characters, two for East Asian wide and fullwidth characters,
+// which include most emoji, and one otherwise.
+func runeWidth(r rune) int {
+	if unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) || unicode.IsControl(r) {
+		return 0
+	}
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
+
+// padRight pads text with spaces to the given number of columns.
+func padRight(text string, columns int) string {
+	return text + strings.Repeat(" ", max(columns-displayWidth(text), 0))
+}
@@ -0,0 +1,490 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/i18n"
+	"github.com/icoder-new/installment-cli/internal/phone"
+)
+
+// The smallest screen the form fits on; smaller terminals get the prompts.
+const (
+	formMinWidth  = 60
+	formMinHeight = 20
+)
+
+const (
+	fieldProduct = iota
+	fieldPrice
+	fieldPhone
+	fieldPeriod
+	fieldSubmit
+	fieldCount
+)
+
+type formKey int
+
+const (
+	keyNone formKey = iota
+	keyRune
+	keyNext
+	keyPrev
+	keyLeft
+	keyRight
+	keyEnter
+	keyBackspace
+	keyCancel
+)
+
+var formProducts = []domain.ProductType{domain.Smartphone, domain.Computer, domain.TV}
+
+// TerminalForm is the full-screen form for the installment details. All
+// fields are on one screen and the total, the monthly payment and the
+// schedule are recalculated on every key, without publishing anything: the
+// quote itself is made once the form is submitted.
+//
+// It reads keys from a terminal in raw mode and redraws the whole screen
+// after each one.
+type TerminalForm struct {
+	keys    *bufio.Reader
+	out     io.Writer
+	size    func() (int, int)
+	now     func() time.Time
+	phones  *phone.Parser
+	catalog *i18n.Catalog
+
+	focus   int
+	product int
+	price   string
+	phone   string
+	period  int
+	message string
+}
+
+func NewTerminalForm(in io.Reader, out io.Writer, phones *phone.Parser, catalog *i18n.Catalog) *TerminalForm {
+	return &TerminalForm{
+		keys:    bufio.NewReader(in),
+		out:     out,
+		size:    func() (int, int) { return 80, 24 },
+		now:     time.Now,
+		phones:  phones,
+		catalog: catalog,
+	}
+}
+
+// Run shows the form filled in from initial and returns the product once
+// the submit button, labelled submit, is pressed with valid fields. Esc and
+// Ctrl+C return context.Canceled.
+func (f *TerminalForm) Run(initial domain.Product, submit string) (domain.Product, error) {
+	f.fill(initial)
+
+	for {
+		f.render(submit)
+
+		key, r, err := f.readKey()
+		if err != nil {
+			return domain.Product{}, err
+		}
+
+		switch key {
+		case keyCancel:
+			return domain.Product{}, context.Canceled
+		case keyEnter:
+			if f.focus != fieldSubmit {
+				f.focus++
+				continue
+			}
+			product, field, err := f.submit()
+			if err == nil {
+				return product, nil
+			}
+			f.focus, f.message = field, err.Error()
+		default:
+			f.handle(key, r)
+		}
+	}
+}
+
+func (f *TerminalForm) fill(initial domain.Product) {
+	f.product = slices.Index(formProducts, initial.Type)
+	if f.product < 0 {
+		f.product = 0
+	}
+	if initial.Price > 0 {
+		f.price = f.catalog.Money(initial.Price)
+	}
+	f.phone = initial.PhoneNumber
+
+	periods := f.periods()
+	f.period = max(slices.Index(periods, initial.PeriodMonths), 0)
+}
+
+func (f *TerminalForm) handle(key formKey, r rune) {
+	f.message = ""
+
+	switch key {
+	case keyNext:
+		f.focus = (f.focus + 1) % fieldCount
+	case keyPrev:
+		f.focus = (f.focus + fieldCount - 1) % fieldCount
+	case keyLeft, keyRight:
+		step := 1
+		if key == keyLeft {
+			step = -1
+		}
+		switch f.focus {
+		case fieldProduct:
+			f.selectProduct((f.product + step + len(formProducts)) % len(formProducts))
+		case fieldPeriod:
+			periods := f.periods()
+			f.period = (f.period + step + len(periods)) % len(periods)
+		}
+	case keyBackspace:
+		switch f.focus {
+		case fieldPrice:
+			f.price = dropLastRune(f.price)
+		case fieldPhone:
+			f.phone = dropLastRune(f.phone)
+		}
+	case keyRune:
+		switch {
+		case f.focus == fieldProduct && r >= '1' && r <= '3':
+			f.selectProduct(int(r - '1'))
+		case f.focus == fieldPrice && (r >= '0' && r <= '9' || r == '.' || r == ',' || r == ' '):
+			f.price += string(r)
+		case f.focus == fieldPhone && strings.ContainsRune("0123456789+ -()", r):
+			f.phone += string(r)
+		}
+	}
+}
+
+// selectProduct changes the product and keeps the period if the new product
+// allows it, or the longest allowed one otherwise.
+func (f *TerminalForm) selectProduct(index int) {
+	months := f.months()
+	f.product = index
+
+	periods := f.periods()
+	f.period = slices.Index(periods, months)
+	if f.period < 0 {
+		f.period = len(periods) - 1
+	}
+}
+
+func (f *TerminalForm) productType() domain.ProductType {
+	return formProducts[f.product]
+}
+
+func (f *TerminalForm) periods() []int {
+	product := domain.Product{Type: f.productType()}
+	return product.AllowedPeriods()
+}
+
+func (f *TerminalForm) months() int {
+	periods := f.periods()
+	if f.period >= len(periods) {
+		return periods[len(periods)-1]
+	}
+	return periods[f.period]
+}
+
+func (f *TerminalForm) parsePrice() (float64, error) {
+	price, err := parseAmount(f.catalog, f.price)
+	if err != nil {
+		return 0, err
+	}
+	if price <= 0 {
+		return 0, errors.New(f.catalog.T("error.price_positive"))
+	}
+	return price, nil
+}
+
+// submit returns the product, or the first invalid field and its error.
+func (f *TerminalForm) submit() (domain.Product, int, error) {
+	price, err := f.parsePrice()
+	if err != nil {
+		return domain.Product{}, fieldPrice, err
+	}
+
+	phoneNumber, err := parsePhoneNumber(f.phones, f.catalog, f.phone)
+	if err != nil {
+		return domain.Product{}, fieldPhone, err
+	}
+
+	return domain.Product{
+		Type:         f.productType(),
+		Price:        price,
+		PhoneNumber:  phoneNumber,
+		PeriodMonths: f.months(),
+	}, fieldSubmit, nil
+}
+
+func (f *TerminalForm) readKey() (formKey, rune, error) {
+	r, _, err := f.keys.ReadRune()
+	if err != nil {
+		return keyNone, 0, err
+	}
+
+	switch r {
+	case 3, 4: // Ctrl+C, Ctrl+D
+		return keyCancel, 0, nil
+	case '\t':
+		return keyNext, 0, nil
+	case '\r', '\n':
+		return keyEnter, 0, nil
+	case 127, 8:
+		return keyBackspace, 0, nil
+	case 27:
+		return f.readEscape()
+	}
+	return keyRune, r, nil
+}
+
+// readEscape decodes the arrow keys and Shift+Tab; other sequences are
+// ignored. A terminal sends a whole sequence at once, so an ESC with nothing
+// after it in the buffer is the Esc key itself, which cancels the form
+// instead of waiting for the next key.
+func (f *TerminalForm) readEscape() (formKey, rune, error) {
+	if f.keys.Buffered() == 0 {
+		return keyCancel, 0, nil
+	}
+	if next, err := f.keys.ReadByte(); err != nil || (next != '[' && next != 'O') {
+		return keyNone, 0, err
+	}
+	if f.keys.Buffered() == 0 {
+		return keyNone, 0, nil
+	}
+	final, err := f.keys.ReadByte()
+	if err != nil {
+		return keyNone, 0, err
+	}
+
+	switch final {
+	case 'A':
+		return keyPrev, 0, nil
+	case 'B':
+		return keyNext, 0, nil
+	case 'C':
+		return keyRight, 0, nil
+	case 'D':
+		return keyLeft, 0, nil
+	case 'Z':
+		return keyPrev, 0, nil
+	}
+	return keyNone, 0, nil
+}
+
+func (f *TerminalForm) render(submit string) {
+	width, height := f.size()
+	lines := []string{
+		" " + bold(f.catalog.T("result.title")),
+		"",
+		f.fieldLine(fieldProduct, f.catalog.T("review.product"),
+			"◀ "+f.catalog.Product(f.productType())+" ▶", ""),
+		f.fieldLine(fieldPrice, f.catalog.T("review.price"),
+			f.price, f.priceNote()),
+		f.fieldLine(fieldPhone, f.catalog.T("review.phone"),
+			f.phone, f.phoneNote()),
+		f.fieldLine(fieldPeriod, f.catalog.T("review.months"),
+			"◀ "+strconv.Itoa(f.months())+" ▶", f.catalog.T("form.periods", joinInts(f.periods()))),
+		"",
+		f.button(submit),
+		"",
+	}
+
+	footer := []string{"", f.catalog.T("form.keys")}
+	if f.message != "" {
+		footer = append(footer, f.catalog.T("error.prefix")+f.message)
+	}
+
+	lines = append(lines, f.preview(height-len(lines)-len(footer))...)
+	lines = append(lines, footer...)
+
+	var screen strings.Builder
+	screen.WriteString("\x1b[H\x1b[2J")
+	for i, line := range lines {
+		if i > 0 {
+			screen.WriteString("\r\n")
+		}
+		screen.WriteString(truncate(line, width))
+	}
+	_, _ = io.WriteString(f.out, screen.String())
+}
+
+func (f *TerminalForm) fieldLine(field int, label, value, note string) string {
+	marker := "  "
+	if f.focus == field {
+		marker = "> "
+		value = reverse(value + " ")
+	}
+
+	line := fmt.Sprintf("%s%-14s %s", marker, label+":", value)
+	if note != "" {
+		line += "  " + note
+	}
+	return line
+}
+
+func (f *TerminalForm) button(label string) string {
+	button := "[ " + label + " ]"
+	if f.focus == fieldSubmit {
+		return "> " + reverse(button)
+	}
+	return "  " + button
+}
+
+func (f *TerminalForm) priceNote() string {
+	if f.price == "" {
+		return ""
+	}
+	if _, err := f.parsePrice(); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+// phoneNote shows how the number was understood, or why it was not.
+func (f *TerminalForm) phoneNote() string {
+	if f.phone == "" {
+		return ""
+	}
+	number, err := f.phones.Parse(f.phone)
+	if err != nil {
+		return f.catalog.Error(err)
+	}
+	return number.String() + " (" + number.Operator + ")"
+}
+
+// preview returns the totals and as much of the schedule as fits in rows.
+func (f *TerminalForm) preview(rows int) []string {
+	price, err := f.parsePrice()
+	if err != nil {
+		return nil
+	}
+
+	product := domain.Product{Type: f.productType(), Price: price, PeriodMonths: f.months()}
+	totalPayment := product.CalculateTotalPayment()
+	contract := domain.NewContract("", product, totalPayment, f.now())
+	schedule := contract.Schedule()
+
+	currency := f.catalog.T("currency")
+	lines := []string{
+		fmt.Sprintf("  %-18s %12s %s", f.catalog.T("result.total"), f.catalog.Money(totalPayment), currency),
+		fmt.Sprintf("  %-18s %12s %s", f.catalog.T("result.overpayment"), f.catalog.Money(totalPayment-price), currency),
+		fmt.Sprintf("  %-18s %12s %s", f.catalog.T("form.monthly"), f.catalog.Money(schedule[0].Amount), currency),
+		"  " + f.catalog.T("form.schedule"),
+	}
+
+	shown := len(schedule)
+	if rows-len(lines) < shown {
+		shown = max(rows-len(lines)-1, 0)
+	}
+	for _, installment := range schedule[:shown] {
+		lines = append(lines, fmt.Sprintf("  %3d. %s %12s", installment.Number,
+			installment.DueDate.Format("02.01.2006"), f.catalog.Money(installment.Amount)))
+	}
+	if shown < len(schedule) {
+		lines = append(lines, "  "+f.catalog.T("form.more", len(schedule)-shown))
+	}
+	return lines
+}
+
+func joinInts(values []int) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = strconv.Itoa(value)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func dropLastRune(s string) string {
+	_, size := utf8.DecodeLastRuneInString(s)
+	return s[:len(s)-size]
+}
+
+// truncate cuts line to width terminal columns, not counting escape
+// sequences. A wide character that would not fit is dropped whole.
+func truncate(line string, width int) string {
+	var b strings.Builder
+	visible, escape := 0, false
+	for _, r := range line {
+		switch {
+		case r == '\x1b':
+			escape = true
+		case escape:
+			if r == 'm' {
+				escape = false
+			}
+		default:
+			if visible+runeWidth(r) > width {
+				b.WriteString("\x1b[0m")
+				return b.String()
+			}
+			visible += runeWidth(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func bold(s string) string {
+	return "\x1b[1m" + s + "\x1b[0m"
+}
+
+func reverse(s string) string {
+	return "\x1b[7m" + s + "\x1b[0m"
+}
+
+// openTerminalForm prepares the terminal for the form: raw mode and the
+// alternate screen, which restore gives back. ok is false when in and out are
+// not a terminal, or the terminal is dumb or too small.
+func openTerminalForm(in io.Reader, out io.Writer, phones *phone.Parser, catalog *i18n.Catalog) (form *TerminalForm, restore func(), ok bool) {
+	inFile, isFile := in.(*os.File)
+	if !isFile || !term.IsTerminal(int(inFile.Fd())) {
+		return nil, nil, false
+	}
+	outFile, isFile := out.(*os.File)
+	if !isFile || !term.IsTerminal(int(outFile.Fd())) {
+		return nil, nil, false
+	}
+	if terminal := os.Getenv("TERM"); terminal == "" || terminal == "dumb" {
+		return nil, nil, false
+	}
+
+	size := func() (int, int) {
+		width, height, err := term.GetSize(int(outFile.Fd()))
+		if err != nil {
+			return 0, 0
+		}
+		return width, height
+	}
+	if width, height := size(); width < formMinWidth || height < formMinHeight {
+		return nil, nil, false
+	}
+
+	state, err := term.MakeRaw(int(inFile.Fd()))
+	if err != nil {
+		return nil, nil, false
+	}
+	fmt.Fprint(out, "\x1b[?1049h\x1b[?25l")
+
+	form = NewTerminalForm(in, out, phones, catalog)
+	form.size = size
+	return form, func() {
+		fmt.Fprint(out, "\x1b[?25h\x1b[?1049l")
+		_ = term.Restore(int(inFile.Fd()), state)
+	}, true
+}
@@ -0,0 +1,136 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/icoder-new/installment-cli/internal/delivery/cli"
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/i18n"
+	"github.com/icoder-new/installment-cli/internal/phone"
+)
+
+const (
+	keyRight = "\x1b[C"
+	keyLeft  = "\x1b[D"
+	keyUp    = "\x1b[A"
+)
+
+func runTerminalForm(t *testing.T, initial domain.Product, keys string) (domain.Product, string, error) {
+	t.Helper()
+
+	parser, err := phone.NewParser()
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	form := cli.NewTerminalForm(strings.NewReader(keys), &out, parser, i18n.New(i18n.Russian))
+	product, err := form.Run(initial, "Рассчитать")
+	return product, out.String(), err
+}
+
+func TestTerminalForm(t *testing.T) {
+	tests := []struct {
+		name    string
+		initial domain.Product
+		keys    string
+		want    domain.Product
+	}{
+		{
+			name: "fill in every field",
+			keys: "3\t2500\t931 23 45 67\t" + keyRight + keyRight + "\r\r",
+			want: domain.Product{Type: domain.TV, Price: 2500, PhoneNumber: "+992931234567", PeriodMonths: 9},
+		},
+		{
+			name:    "a shorter product keeps the longest period it allows",
+			initial: domain.Product{Type: domain.Computer, Price: 2000, PhoneNumber: "+992931234567", PeriodMonths: 12},
+			keys:    keyLeft + "\r\r\r\r\r",
+			want:    domain.Product{Type: domain.Smartphone, Price: 2000, PhoneNumber: "+992931234567", PeriodMonths: 9},
+		},
+		{
+			name:    "a period the new product allows is kept",
+			initial: domain.Product{Type: domain.Smartphone, Price: 1000, PhoneNumber: "+992931234567", PeriodMonths: 6},
+			keys:    keyRight + keyUp + "\r",
+			want:    domain.Product{Type: domain.Computer, Price: 1000, PhoneNumber: "+992931234567", PeriodMonths: 6},
+		},
+		{
+			name:    "price is edited with backspace",
+			initial: domain.Product{Type: domain.Smartphone, Price: 1000, PhoneNumber: "+992931234567", PeriodMonths: 3},
+			keys:    "\t\x7f\x7f\x7f\x7f\x7f\x7f\x7f\x7f5\r\r\r\r",
+			want:    domain.Product{Type: domain.Smartphone, Price: 5, PhoneNumber: "+992931234567", PeriodMonths: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			product, _, err := runTerminalForm(t, tt.initial, tt.keys)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, product)
+		})
+	}
+}
+
+func TestTerminalFormShowsTheScheduleLive(t *testing.T) {
+	initial := domain.Product{Type: domain.Smartphone, Price: 1000, PhoneNumber: "+992931234567", PeriodMonths: 6}
+
+	_, out, err := runTerminalForm(t, initial, keyUp+"\r")
+
+	require.NoError(t, err)
+	assert.Contains(t, out, "1 030,00")
+	assert.Contains(t, out, "171,66")
+	assert.Contains(t, out, "171,70", "rounding goes to the last installment")
+}
+
+func TestTerminalFormKeepsInvalidInputOnScreen(t *testing.T) {
+	keys := "1\t100\t123" + keyUp + keyUp + keyUp + "\r" + // submit with a wrong phone
+		"\x7f\x7f\x7f931234567\r\r\r"
+
+	product, out, err := runTerminalForm(t, domain.Product{}, keys)
+
+	require.NoError(t, err)
+	assert.Contains(t, out, "неверный формат номера телефона")
+	assert.Equal(t, "+992931234567", product.PhoneNumber)
+}
+
+func TestTerminalFormCancel(t *testing.T) {
+	tests := []struct {
+		name string
+		keys string
+	}{
+		{name: "ctrl+c", keys: "1\t10\x03"},
+		{name: "a lone esc", keys: "1\t10\x1b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := runTerminalForm(t, domain.Product{}, tt.keys)
+
+			assert.ErrorIs(t, err, context.Canceled)
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name  string
+		line  string
+		width int
+		want  string
+	}{
+		{name: "short line", line: "Итог", width: 10, want: "Итог"},
+		{name: "cyrillic", line: "Итоговая сумма", width: 4, want: "Итог\x1b[0m"},
+		{name: "wide characters", line: "分期付款", width: 5, want: "分期\x1b[0m"},
+		{name: "colour codes", line: "\x1b[7mИтог\x1b[0m", width: 2, want: "\x1b[7mИт\x1b[0m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, cli.Truncate(tt.line, tt.width))
+		})
+	}
+}
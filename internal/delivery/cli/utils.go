@@ -1,7 +1,68 @@
 package cli
 
-import "strconv"
+import (
+	"errors"
+	"strconv"
 
+	"github.com/icoder-new/installment-cli/internal/i18n"
+	"github.com/icoder-new/installment-cli/internal/phone"
+)
+
+// formatPrice writes price with a dot and no grouping, for CSV. Amounts for
+// people are written by Catalog.Money.
 func formatPrice(price float64) string {
 	return strconv.FormatFloat(price, 'f', 2, 64)
 }
+
+// parseAmount reads an amount typed the way of any supported language, such
+// as "1 500,50" or "1500 сомони", or returns a localized error.
+func parseAmount(catalog *i18n.Catalog, input string) (float64, error) {
+	amount, err := i18n.ParseMoney(input)
+	if err != nil {
+		return 0, errors.New(catalog.Error(err))
+	}
+	return amount, nil
+}
+
+// amountFlag is a flag holding an amount read by parseAmount.
+type amountFlag struct {
+	amount  *float64
+	catalog *i18n.Catalog
+}
+
+func (f amountFlag) String() string {
+	if f.amount == nil || *f.amount == 0 {
+		return ""
+	}
+	return formatPrice(*f.amount)
+}
+
+func (f amountFlag) Set(value string) error {
+	amount, err := parseAmount(f.catalog, value)
+	if err != nil {
+		return err
+	}
+	*f.amount = amount
+	return nil
+}
+
+// parsePhoneNumber returns the number in E.164 or a localized error.
+func parsePhoneNumber(phones *phone.Parser, catalog *i18n.Catalog, input string) (string, error) {
+	number, err := phones.Parse(input)
+	if errors.Is(err, phone.ErrInvalidFormat) {
+		return "", errors.New(catalog.T("error.phone_format"))
+	}
+	if err != nil {
+		return "", errors.New(catalog.Error(err))
+	}
+	return number.E164(), nil
+}
+
+// productError returns message for a product name that was not recognized,
+// with a "did you mean" hint when the name is close to a known one.
+func productError(catalog *i18n.Catalog, message, input string) error {
+	if suggestion := catalog.ProductSuggestion(input); suggestion != "" {
+		message += ". " + suggestion
+	}
+	return errors.New(message)
+}
@@ -1,25 +1,15 @@
 package cli
 
 import (
-	"flag"
-	"fmt"
-	"os"
-	"regexp"
+	"errors"
 	"strings"
 
 	"github.com/icoder-new/installment-cli/internal/domain"
-)
-
-const (
-	phonePrefix      = "992"
-	localPhoneLength = 9
-	fullPhoneLength  = 12
+	"github.com/icoder-new/installment-cli/internal/i18n"
+	"github.com/icoder-new/installment-cli/internal/phone"
 )
 
 var (
-	phoneRegex     = regexp.MustCompile(`[^0-9]`)
-	phoneValidator = regexp.MustCompile(`^\+?992\d{9}$`)
-
 	validProductTypes = map[string]bool{
 		string(domain.Smartphone): true,
 		string(domain.Computer):   true,
@@ -27,18 +17,16 @@ var (
 	}
 )
 
-type FlagValidator struct{}
+type FlagValidator struct {
+	phones  *phone.Parser
+	catalog *i18n.Catalog
+}
 
-func NewFlagValidator() *FlagValidator {
-	return &FlagValidator{}
+func NewFlagValidator(phones *phone.Parser, catalog *i18n.Catalog) *FlagValidator {
+	return &FlagValidator{phones: phones, catalog: catalog}
 }
 
 func (fv *FlagValidator) Validate(flags *Flags) error {
-	if flags.Help {
-		flag.Usage()
-		os.Exit(0)
-	}
-
 	if err := fv.validateNonInteractiveMode(flags); err != nil {
 		return err
 	}
@@ -48,8 +36,7 @@ func (fv *FlagValidator) Validate(flags *Flags) error {
 
 func (fv *FlagValidator) validateNonInteractiveMode(flags *Flags) error {
 	if !flags.Interactive && !flags.IsComplete() {
-		flag.Usage()
-		return fmt.Errorf("все флаги обязательны в нон-интерактивном режиме или используйте интерактивный режим (-i/--interactive)")
+		return usageError(fv.catalog.T("error.flags_required"))
 	}
 	return nil
 }
@@ -63,9 +50,11 @@ func (fv *FlagValidator) validateFields(flags *Flags) error {
 		return err
 	}
 
-	if err := fv.validatePhoneNumber(flags.PhoneNumber); err != nil {
+	phoneNumber, err := fv.validatePhoneNumber(flags.PhoneNumber)
+	if err != nil {
 		return err
 	}
+	flags.PhoneNumber = phoneNumber
 
 	if flags.ProductType != "" && flags.Months > 0 {
 		if err := fv.validateMonthsForProduct(flags.Months, flags.ProductType); err != nil {
@@ -73,49 +62,42 @@ func (fv *FlagValidator) validateFields(flags *Flags) error {
 		}
 	}
 
+	// The period is asked for later in interactive mode.
+	if flags.Months == 0 {
+		return nil
+	}
 	return fv.validateMonths(flags.Months)
 }
 
 func (fv *FlagValidator) validateProductType(productType string) error {
-	if productType != "" {
-		switch strings.ToLower(productType) {
-		case "1", "смартфон":
-			return nil
-		case "2", "компьютер":
-			return nil
-		case "3", "телевизор":
-			return nil
-		default:
-			return fmt.Errorf("неверный тип товара: %s. Допустимые значения: 1/Смартфон, 2/Компьютер, 3/Телевизор", productType)
-		}
+	if productType == "" {
+		return nil
+	}
+
+	if _, ok := resolveProductType(productType); !ok {
+		return productError(fv.catalog, fv.catalog.T("error.product_flag", productType,
+			fv.catalog.Product(domain.Smartphone),
+			fv.catalog.Product(domain.Computer),
+			fv.catalog.Product(domain.TV)), productType)
 	}
 	return nil
 }
 
 func (fv *FlagValidator) validatePrice(price float64) error {
 	if price < 0 {
-		return fmt.Errorf("цена товара не может быть отрицательной")
+		return errors.New(fv.catalog.T("error.price_negative"))
 	}
 	return nil
 }
 
-func (fv *FlagValidator) validatePhoneNumber(phoneNumber string) error {
+// validatePhoneNumber returns the number in E.164 so that the contract and
+// the customer are keyed the same way whichever form was typed.
+func (fv *FlagValidator) validatePhoneNumber(phoneNumber string) (string, error) {
 	if phoneNumber == "" {
-		return nil
-	}
-
-	cleanPhone := phoneRegex.ReplaceAllString(phoneNumber, "")
-	
-	switch len(cleanPhone) {
-	case 9:
-		return nil
-	case 12:
-		if cleanPhone[:3] == "992" {
-			return nil
-		}
+		return "", nil
 	}
 
-	return fmt.Errorf("неверный формат номера телефона. Используйте формат: 992XXXXXXXXX, 9XXXXXXXX или +992XXXXXXXXX")
+	return parsePhoneNumber(fv.phones, fv.catalog, phoneNumber)
 }
 
 func (fv *FlagValidator) validateMonthsForProduct(months int, productType string) error {
@@ -123,20 +105,23 @@ func (fv *FlagValidator) validateMonthsForProduct(months int, productType string
 		return err
 	}
 
+	resolved, ok := resolveProductType(productType)
+	if !ok {
+		return productError(fv.catalog, fv.catalog.T("error.unknown_product", productType), productType)
+	}
+
 	var maxPeriod int
-	switch strings.ToLower(productType) {
-	case "1", "смартфон":
+	switch resolved {
+	case domain.Smartphone:
 		maxPeriod = 9
-	case "2", "компьютер":
+	case domain.Computer:
 		maxPeriod = 12
-	case "3", "телевизор":
+	case domain.TV:
 		maxPeriod = 18
-	default:
-		return fmt.Errorf("неизвестный тип товара: %s", productType)
 	}
 
 	if months > maxPeriod {
-		return fmt.Errorf("для %s максимальный срок рассрочки %d месяцев", productType, maxPeriod)
+		return errors.New(fv.catalog.T("error.period_max", fv.catalog.Product(resolved), maxPeriod))
 	}
 
 	return nil
@@ -144,7 +129,7 @@ func (fv *FlagValidator) validateMonthsForProduct(months int, productType string
 
 func (fv *FlagValidator) validateMonths(months int) error {
 	if months < 0 {
-		return fmt.Errorf("срок рассрочки не может быть отрицательным")
+		return errors.New(fv.catalog.T("error.months_negative"))
 	}
 
 	validPeriods := []int{3, 6, 9, 12, 18, 24}
@@ -157,7 +142,7 @@ func (fv *FlagValidator) validateMonths(months int) error {
 	}
 
 	if !valid {
-		return fmt.Errorf("неверный срок рассрочки. Допустимые значения: %v", validPeriods)
+		return errors.New(fv.catalog.T("error.period_values", validPeriods))
 	}
 
 	return nil
@@ -166,16 +151,3 @@ func (fv *FlagValidator) validateMonths(months int) error {
 func (fv *FlagValidator) isValidProductType(productType string) bool {
 	return validProductTypes[strings.ToLower(productType)]
 }
-
-func (fv *FlagValidator) isValidPhoneNumber(phone string) bool {
-	cleanPhone := phoneRegex.ReplaceAllString(phone, "")
-
-	switch len(cleanPhone) {
-	case localPhoneLength:
-		return true
-	case fullPhoneLength:
-		return strings.HasPrefix(cleanPhone, phonePrefix)
-	default:
-		return false
-	}
-}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/icoder-new/installment-cli/internal/i18n"
+	"github.com/icoder-new/installment-cli/internal/infra/webhook"
+)
+
+type WebhooksHandler struct {
+	dispatcher *webhook.Dispatcher
+	out        io.Writer
+	catalog    *i18n.Catalog
+}
+
+func NewWebhooksHandler(dispatcher *webhook.Dispatcher, out io.Writer, catalog *i18n.Catalog) *WebhooksHandler {
+	return &WebhooksHandler{
+		dispatcher: dispatcher,
+		out:        out,
+		catalog:    catalog,
+	}
+}
+
+func (h *WebhooksHandler) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(h.catalog.T("webhooks.usage"))
+	}
+
+	switch args[0] {
+	case "test":
+		return h.test(ctx, args[1:])
+	case "retry":
+		return h.retry(ctx)
+	case "pending":
+		return h.pending()
+	case "dead":
+		return h.dead()
+	default:
+		return usageError(h.catalog.T("webhooks.usage"))
+	}
+}
+
+func (h *WebhooksHandler) test(ctx context.Context, args []string) error {
+	var name string
+
+	fs := newFlagSet(h.catalog, "webhooks test")
+	fs.StringVar(&name, "name", "", h.catalog.T("flag.webhook_name"))
+
+	if err := parseFlags(h.catalog, fs, args); err != nil {
+		return err
+	}
+
+	if len(h.dispatcher.Subscriptions()) == 0 {
+		fmt.Fprintln(h.out, h.catalog.T("webhooks.none"))
+		return nil
+	}
+
+	results, err := h.dispatcher.Test(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	failed := false
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Fprintln(h.out, h.catalog.T("webhooks.failed", result.Subscription, result.Err))
+			failed = true
+			continue
+		}
+		fmt.Fprintln(h.out, h.catalog.T("webhooks.ok", result.Subscription))
+	}
+
+	if failed {
+		return errors.New(h.catalog.T("webhooks.test_failed"))
+	}
+	return nil
+}
+
+func (h *WebhooksHandler) retry(ctx context.Context) error {
+	result, err := h.dispatcher.Retry(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(h.out, h.catalog.T("webhooks.retried", result.Delivered, result.Requeued+result.Waiting, result.Dead))
+	return nil
+}
+
+func (h *WebhooksHandler) pending() error {
+	webhooks, err := h.dispatcher.Pending()
+	if err != nil {
+		return err
+	}
+
+	if len(webhooks) == 0 {
+		fmt.Fprintln(h.out, h.catalog.T("webhooks.no_pending"))
+		return nil
+	}
+
+	for _, pending := range webhooks {
+		fmt.Fprintf(h.out, "%s  %-12s %-22s %s  %d  %s\n",
+			pending.NextAttemptAt.Format("02.01.2006 15:04"), pending.Subscription, pending.Event,
+			pending.ID, pending.Attempts, pending.LastError)
+	}
+	return nil
+}
+
+func (h *WebhooksHandler) dead() error {
+	webhooks, err := h.dispatcher.DeadLetters()
+	if err != nil {
+		return err
+	}
+
+	if len(webhooks) == 0 {
+		fmt.Fprintln(h.out, h.catalog.T("webhooks.no_dead"))
+		return nil
+	}
+
+	for _, dead := range webhooks {
+		fmt.Fprintf(h.out, "%s  %-12s %-22s %s  %d  %s\n",
+			dead.FailedAt.Format("02.01.2006 15:04"), dead.Subscription, dead.Event,
+			dead.ID, dead.Attempts, dead.LastError)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package httpapi_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/icoder-new/installment-cli/internal/delivery/httpapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireToken(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name   string
+		token  string
+		target string
+		header string
+		want   int
+	}{
+		{name: "bearer token", token: "secret", target: "/sms/dlr", header: "Bearer secret", want: http.StatusOK},
+		{name: "query parameter", token: "secret", target: "/sms/dlr?token=secret&id=1", want: http.StatusOK},
+		{name: "wrong token", token: "secret", target: "/sms/dlr?token=guess", want: http.StatusUnauthorized},
+		{name: "no token", token: "secret", target: "/sms/dlr", want: http.StatusUnauthorized},
+		{name: "not configured", token: "", target: "/sms/dlr?token=", want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, tt.target, nil)
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+
+			httpapi.RequireToken(tt.token, ok).ServeHTTP(w, r)
+
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// maxBodyBytes bounds request bodies; the API only takes small JSON objects
+// and form posts.
+const maxBodyBytes = 64 << 10
+
+var errTrailingData = errors.New("лишние данные после JSON")
+
+// limitBody makes reads past maxBodyBytes fail.
+func limitBody(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+}
+
+// decodeJSON reads exactly one JSON object with only the known fields.
+func decodeJSON(body io.Reader, v any) error {
+	decoder := json.NewDecoder(body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return errTrailingData
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/i18n"
+	"github.com/icoder-new/installment-cli/internal/phone"
+	"github.com/icoder-new/installment-cli/internal/usecase"
+)
+
+type consentRequest struct {
+	Product     string  `json:"product"`
+	Price       float64 `json:"price"`
+	PhoneNumber string  `json:"phone_number"`
+	Months      int     `json:"months"`
+}
+
+type consentResponse struct {
+	ID           string    `json:"id"`
+	MaskedPhone  string    `json:"masked_phone"`
+	TotalPayment float64   `json:"total_payment"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+type confirmRequest struct {
+	Code string `json:"code"`
+}
+
+type confirmResponse struct {
+	ContractID string `json:"contract_id"`
+}
+
+type errorResponse struct {
+	Error             string `json:"error"`
+	RemainingAttempts *int   `json:"remaining_attempts,omitempty"`
+	// Suggestion is the product an unrecognized product name most likely
+	// means.
+	Suggestion domain.ProductType `json:"suggestion,omitempty"`
+}
+
+// ConsentHandler lets a point of sale open an installment with the customer's
+// consent: POST /consents quotes the installment and texts the code, POST
+// /consents/{id}/confirm checks the code and opens the contract.
+type ConsentHandler struct {
+	calculator *usecase.InstallmentCalculator
+	consents   *usecase.ConsentService
+	phones     *phone.Parser
+	logger     *log.Logger
+	mux        *http.ServeMux
+}
+
+func NewConsentHandler(
+	calculator *usecase.InstallmentCalculator,
+	consents *usecase.ConsentService,
+	phones *phone.Parser,
+	logger *log.Logger,
+) *ConsentHandler {
+	h := &ConsentHandler{
+		calculator: calculator,
+		consents:   consents,
+		phones:     phones,
+		logger:     logger,
+		mux:        http.NewServeMux(),
+	}
+	h.mux.HandleFunc("POST /consents", h.request)
+	h.mux.HandleFunc("POST /consents/{id}/confirm", h.confirm)
+	return h
+}
+
+func (h *ConsentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *ConsentHandler) request(w http.ResponseWriter, r *http.Request) {
+	limitBody(w, r)
+	var request consentRequest
+	if err := decodeJSON(r.Body, &request); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "неверный JSON"})
+		return
+	}
+
+	productType, ok := i18n.ParseProduct(request.Product)
+	if !ok {
+		suggestion, _ := i18n.SuggestProduct(request.Product)
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: domain.ErrInvalidProductType.Error(), Suggestion: suggestion})
+		return
+	}
+
+	number, err := h.phones.Parse(request.PhoneNumber)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+
+	product := domain.Product{
+		Type:         productType,
+		Price:        request.Price,
+		PhoneNumber:  number.E164(),
+		PeriodMonths: request.Months,
+	}
+
+	totalPayment, err := h.calculator.CalculateInstallment(r.Context(), product)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+
+	pending, err := h.consents.Request(r.Context(), product, totalPayment)
+	if errors.Is(err, domain.ErrSendLimited) {
+		writeJSON(w, http.StatusTooManyRequests, errorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		h.logger.Printf("не удалось запросить согласие %s: %v", domain.MaskPhoneNumber(product.PhoneNumber), err)
+		writeJSON(w, http.StatusBadGateway, errorResponse{Error: err.Error()})
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, consentResponse{
+		ID:           pending.ID,
+		MaskedPhone:  domain.MaskPhoneNumber(product.PhoneNumber),
+		TotalPayment: totalPayment,
+		ExpiresAt:    pending.ExpiresAt,
+	})
+}
+
+func (h *ConsentHandler) confirm(w http.ResponseWriter, r *http.Request) {
+	limitBody(w, r)
+	var request confirmRequest
+	if err := decodeJSON(r.Body, &request); err != nil || request.Code == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "необходимо указать code"})
+		return
+	}
+
+	id := r.PathValue("id")
+	contract, err := h.consents.Confirm(r.Context(), id, request.Code)
+	if err != nil && contract.ID == "" {
+		h.writeConfirmError(w, id, err)
+		return
+	}
+	if err != nil {
+		h.logger.Printf("договор %s открыт с ошибкой: %v", contract.ID, err)
+	}
+
+	writeJSON(w, http.StatusCreated, confirmResponse{ContractID: contract.ID})
+}
+
+func (h *ConsentHandler) writeConfirmError(w http.ResponseWriter, id string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrConsentNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case errors.Is(err, domain.ErrConsentExpired):
+		writeJSON(w, http.StatusGone, errorResponse{Error: err.Error()})
+	case errors.Is(err, domain.ErrConsentAttemptsExceeded):
+		writeJSON(w, http.StatusTooManyRequests, errorResponse{Error: err.Error()})
+	case errors.Is(err, domain.ErrConsentCodeMismatch):
+		response := errorResponse{Error: domain.ErrConsentCodeMismatch.Error()}
+		if pending, findErr := h.consents.Find(id); findErr == nil {
+			remaining := pending.RemainingAttempts()
+			response.RemainingAttempts = &remaining
+		}
+		writeJSON(w, http.StatusUnprocessableEntity, response)
+	default:
+		h.logger.Printf("не удалось подтвердить согласие %s: %v", id, err)
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "internal error"})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
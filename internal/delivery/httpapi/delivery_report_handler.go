@@ -0,0 +1,119 @@
+package httpapi
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/infra/sms"
+	"github.com/icoder-new/installment-cli/internal/usecase"
+)
+
+type deliveryReportRequest struct {
+	MessageID string    `json:"message_id"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error"`
+	DoneAt    time.Time `json:"done_at"`
+}
+
+// DeliveryReportHandler accepts delivery report callbacks from SMS gateways.
+// It understands a JSON body, query or form parameters (message_id/id,
+// status/stat, error/err) and a raw SMPP receipt in the "receipt" parameter.
+type DeliveryReportHandler struct {
+	deliveries *usecase.DeliveryService
+	logger     *log.Logger
+}
+
+func NewDeliveryReportHandler(deliveries *usecase.DeliveryService, logger *log.Logger) *DeliveryReportHandler {
+	return &DeliveryReportHandler{
+		deliveries: deliveries,
+		logger:     logger,
+	}
+}
+
+func (h *DeliveryReportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	limitBody(w, r)
+	report, err := h.parse(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.deliveries.Apply(report); err != nil {
+		if errors.Is(err, domain.ErrNotificationNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		h.logger.Printf("не удалось сохранить отчет о доставке %s: %v", report.MessageID, err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	h.logger.Printf("отчет о доставке %s: %s", report.MessageID, report.Status)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("OK"))
+}
+
+func (h *DeliveryReportHandler) parse(r *http.Request) (usecase.DeliveryReport, error) {
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		var request deliveryReportRequest
+		if err := decodeJSON(r.Body, &request); err != nil {
+			return usecase.DeliveryReport{}, sms.ErrInvalidDeliveryReceipt
+		}
+		return newDeliveryReport(request.MessageID, request.Status, request.Error, request.DoneAt)
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return usecase.DeliveryReport{}, sms.ErrInvalidDeliveryReceipt
+	}
+
+	if raw := r.Form.Get("receipt"); raw != "" {
+		receipt, err := sms.ParseSMPPReceipt(raw)
+		if err != nil {
+			return usecase.DeliveryReport{}, err
+		}
+		return usecase.DeliveryReport{
+			MessageID: receipt.MessageID,
+			Status:    receipt.Status,
+			At:        receipt.Done,
+			Error:     receipt.Error,
+		}, nil
+	}
+
+	return newDeliveryReport(
+		firstNonEmpty(r.Form.Get("message_id"), r.Form.Get("id")),
+		firstNonEmpty(r.Form.Get("status"), r.Form.Get("stat")),
+		firstNonEmpty(r.Form.Get("error"), r.Form.Get("err")),
+		time.Time{},
+	)
+}
+
+func newDeliveryReport(messageID, status, reason string, at time.Time) (usecase.DeliveryReport, error) {
+	if messageID == "" || status == "" {
+		return usecase.DeliveryReport{}, sms.ErrInvalidDeliveryReceipt
+	}
+
+	return usecase.DeliveryReport{
+		MessageID: messageID,
+		Status:    sms.DeliveryStatusFromCode(status),
+		At:        at,
+		Error:     reason,
+	}, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
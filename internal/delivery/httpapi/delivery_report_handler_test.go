@@ -0,0 +1,44 @@
+package httpapi_test
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/icoder-new/installment-cli/internal/delivery/httpapi"
+	"github.com/icoder-new/installment-cli/internal/infra/storage"
+	"github.com/icoder-new/installment-cli/internal/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeliveryReportHandler_JSONBody(t *testing.T) {
+	contracts := storage.NewFileContractRepository(filepath.Join(t.TempDir(), "contracts.json"))
+	handler := httpapi.NewDeliveryReportHandler(usecase.NewDeliveryService(contracts), log.New(io.Discard, "", 0))
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{name: "report for an unknown message", body: `{"message_id": "gw-1", "status": "DELIVRD"}`, want: http.StatusNotFound},
+		{name: "unknown field", body: `{"message_id": "gw-1", "status": "DELIVRD", "extra": 1}`, want: http.StatusBadRequest},
+		{name: "trailing data", body: `{"message_id": "gw-1", "status": "DELIVRD"} {}`, want: http.StatusBadRequest},
+		{name: "oversized body", body: `{"message_id": "` + strings.Repeat("1", 1<<20) + `", "status": "DELIVRD"}`, want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/sms/dlr", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.want, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+const shutdownTimeout = 5 * time.Second
+
+type Server struct {
+	server *http.Server
+}
+
+func NewServer(addr string, deliveryReports, consents http.Handler) *Server {
+	mux := http.NewServeMux()
+	mux.Handle("/sms/dlr", deliveryReports)
+	mux.Handle("/consents", consents)
+	mux.Handle("/consents/", consents)
+
+	return &Server{
+		server: &http.Server{
+			Addr:              addr,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+	}
+}
+
+// Run serves until ctx is cancelled and then shuts down gracefully.
+func (s *Server) Run(ctx context.Context) error {
+	errs := make(chan error, 1)
+	go func() {
+		errs <- s.server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := s.server.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+
+	if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
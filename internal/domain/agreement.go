@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+type DocumentFormat string
+
+const (
+	FormatHTML     DocumentFormat = "html"
+	FormatPDF      DocumentFormat = "pdf"
+	FormatMarkdown DocumentFormat = "md"
+)
+
+var DocumentFormats = []DocumentFormat{FormatHTML, FormatPDF, FormatMarkdown}
+
+var ErrInvalidDocumentFormat = errors.New("неверный формат документа")
+
+// ParseDocumentFormat accepts html, pdf and md.
+func ParseDocumentFormat(value string) (DocumentFormat, error) {
+	for _, format := range DocumentFormats {
+		if string(format) == value {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s, допустимые значения: html, pdf, md", ErrInvalidDocumentFormat, value)
+}
+
+// Store is the seller named in agreements. Details that are not configured
+// are left blank in the document to be filled in by hand.
+type Store struct {
+	Name    string
+	Address string
+	Phone   string
+	TaxID   string
+}
+
+// AgreementData is what a printed installment agreement states.
+type AgreementData struct {
+	ContractID   string
+	Date         time.Time
+	Store        Store
+	PhoneNumber  string
+	Product      ProductType
+	Price        float64
+	PeriodMonths int
+	// Overpayment is the total cost of credit: what the customer pays on top
+	// of the price, also given as a percentage of it.
+	Overpayment        float64
+	OverpaymentPercent float64
+	TotalPayment       float64
+	Schedule           []Installment
+}
+
+func NewAgreementData(contract Contract, store Store) AgreementData {
+	overpayment := contract.TotalPayment - contract.Product.Price
+
+	var percent float64
+	if contract.Product.Price > 0 {
+		percent = overpayment / contract.Product.Price * 100
+	}
+
+	return AgreementData{
+		ContractID:         contract.ID,
+		Date:               contract.CreatedAt,
+		Store:              store,
+		PhoneNumber:        contract.Product.PhoneNumber,
+		Product:            contract.Product.Type,
+		Price:              contract.Product.Price,
+		PeriodMonths:       contract.Product.PeriodMonths,
+		Overpayment:        overpayment,
+		OverpaymentPercent: percent,
+		TotalPayment:       contract.TotalPayment,
+		Schedule:           contract.Schedule(),
+	}
+}
+
+// AgreementRenderer renders the agreement in the given language and format;
+// an empty or unknown language selects the default one.
+type AgreementRenderer interface {
+	Render(language string, format DocumentFormat, data AgreementData) ([]byte, error)
+}
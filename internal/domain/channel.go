@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type Channel string
+
+const (
+	ChannelSMS       Channel = "sms"
+	ChannelEmail     Channel = "email"
+	ChannelMessenger Channel = "messenger"
+)
+
+var Channels = []Channel{ChannelSMS, ChannelEmail, ChannelMessenger}
+
+var (
+	ErrUnknownChannel = errors.New("неизвестный канал уведомлений")
+	ErrNoChannel      = errors.New("нет доступного канала уведомлений")
+)
+
+func ParseChannel(value string) (Channel, error) {
+	channel := Channel(strings.ToLower(strings.TrimSpace(value)))
+	for _, known := range Channels {
+		if channel == known {
+			return channel, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownChannel, value)
+}
+
+// HasDeliveryReports reports whether the channel tells us about delivery
+// later; messages on other channels count as delivered once accepted.
+func (c Channel) HasDeliveryReports() bool {
+	return c == ChannelSMS
+}
+
+// Receipt confirms that a channel accepted a message for delivery. A non-zero
+// ScheduledAt means the message was queued to be sent at that time.
+type Receipt struct {
+	Channel     Channel
+	MessageID   string
+	Segments    int
+	AcceptedAt  time.Time
+	ScheduledAt time.Time
+}
+
+// Message is a customer message rendered for one event.
+type Message struct {
+	Event    MessageEvent
+	Language string
+	Text     string
+}
+
+// Notifier delivers messages to customers over one channel.
+type Notifier interface {
+	Channel() Channel
+	Notify(ctx context.Context, customer Customer, message Message) (Receipt, error)
+}
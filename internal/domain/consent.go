@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrConsentNotFound         = errors.New("запрос подтверждения не найден")
+	ErrConsentExpired          = errors.New("срок действия кода истек")
+	ErrConsentCodeMismatch     = errors.New("неверный код подтверждения")
+	ErrConsentAttemptsExceeded = errors.New("превышено число попыток ввода кода")
+)
+
+// ConsentRequest is a pending installment waiting for the one-time code the
+// customer received by SMS. Only a hash of the code is kept.
+type ConsentRequest struct {
+	ID           string
+	Product      Product
+	TotalPayment float64
+	CodeHash     string
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+	Attempts     int
+	MaxAttempts  int
+}
+
+func NewConsentRequest(id, code string, product Product, totalPayment float64, now time.Time, ttl time.Duration, maxAttempts int) ConsentRequest {
+	return ConsentRequest{
+		ID:           id,
+		Product:      product,
+		TotalPayment: totalPayment,
+		CodeHash:     HashConsentCode(id, code),
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(ttl),
+		MaxAttempts:  maxAttempts,
+	}
+}
+
+func (r *ConsentRequest) RemainingAttempts() int {
+	return max(r.MaxAttempts-r.Attempts, 0)
+}
+
+// Verify checks the code entered by the cashier and counts the attempt.
+func (r *ConsentRequest) Verify(code string, now time.Time) (Consent, error) {
+	if now.After(r.ExpiresAt) {
+		return Consent{}, ErrConsentExpired
+	}
+
+	if r.RemainingAttempts() == 0 {
+		return Consent{}, ErrConsentAttemptsExceeded
+	}
+	r.Attempts++
+
+	hash := HashConsentCode(r.ID, strings.TrimSpace(code))
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(r.CodeHash)) != 1 {
+		if r.RemainingAttempts() == 0 {
+			return Consent{}, ErrConsentAttemptsExceeded
+		}
+		return Consent{}, ErrConsentCodeMismatch
+	}
+
+	return Consent{
+		RequestID:   r.ID,
+		ConfirmedAt: now,
+		MaskedPhone: MaskPhoneNumber(r.Product.PhoneNumber),
+		CodeHash:    r.CodeHash,
+	}, nil
+}
+
+// Consent is the evidence that the phone owner agreed to the installment.
+type Consent struct {
+	RequestID   string
+	ConfirmedAt time.Time
+	MaskedPhone string
+	CodeHash    string
+}
+
+type ConsentRepository interface {
+	Save(request ConsentRequest) error
+	FindByID(id string) (ConsentRequest, error)
+	Delete(id string) error
+	// Update applies update to the stored request atomically, so concurrent
+	// confirmations see each other's attempts. The changed request is kept
+	// when update asks for it and removed otherwise; update's error is
+	// returned either way.
+	Update(id string, update func(*ConsentRequest) (keep bool, err error)) error
+}
+
+// HashConsentCode salts the code with the request ID so that equal codes of
+// different requests do not share a hash.
+func HashConsentCode(requestID, code string) string {
+	sum := sha256.Sum256([]byte(requestID + ":" + code))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// MaskPhoneNumber hides all but the country code and the last four digits.
+func MaskPhoneNumber(phoneNumber string) string {
+	runes := []rune(phoneNumber)
+	if len(runes) <= 8 {
+		return strings.Repeat("*", len(runes))
+	}
+	return string(runes[:4]) + strings.Repeat("*", len(runes)-8) + string(runes[len(runes)-4:])
+}
@@ -0,0 +1,188 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+type ContractStatus string
+
+const (
+	ContractActive    ContractStatus = "active"
+	ContractCancelled ContractStatus = "cancelled"
+)
+
+var (
+	ErrContractNotFound    = errors.New("договор не найден")
+	ErrContractNotActive   = errors.New("договор не активен")
+	ErrEmptyCancelReason   = errors.New("необходимо указать причину отмены")
+	ErrReturnWindowExpired = errors.New("срок возврата товара истек")
+	ErrInvalidPayment      = errors.New("сумма платежа должна быть больше 0")
+	ErrOverpayment         = errors.New("сумма платежа превышает остаток по договору")
+)
+
+type ContractRepository interface {
+	Save(contract Contract) error
+	FindByID(id string) (Contract, error)
+	FindAll() ([]Contract, error)
+	// Update loads the contract, applies update to it and saves the result
+	// as one step, so concurrent updates do not overwrite each other. Nothing
+	// is saved when update returns an error.
+	Update(id string, update func(*Contract) error) error
+}
+
+type Payment struct {
+	Amount float64
+	PaidAt time.Time
+}
+
+type Contract struct {
+	ID            string
+	Product       Product
+	TotalPayment  float64
+	Status        ContractStatus
+	CreatedAt     time.Time
+	Payments      []Payment
+	CancelledAt   time.Time
+	CancelReason  string
+	Refund        float64
+	Notifications []Notification
+	Consent       *Consent
+}
+
+func NewContract(id string, product Product, totalPayment float64, createdAt time.Time) Contract {
+	return Contract{
+		ID:           id,
+		Product:      product,
+		TotalPayment: totalPayment,
+		Status:       ContractActive,
+		CreatedAt:    createdAt,
+	}
+}
+
+func (c *Contract) RecordNotification(event MessageEvent, receipt Receipt) {
+	c.Notifications = append(c.Notifications, NewNotification(event, receipt))
+}
+
+func (c *Contract) IsActive() bool {
+	return c.Status == ContractActive
+}
+
+func (c *Contract) TotalPaid() float64 {
+	var paid float64
+	for _, payment := range c.Payments {
+		paid += payment.Amount
+	}
+	return paid
+}
+
+// Balance returns the amount still owed. Cancelled contracts owe nothing.
+func (c *Contract) Balance() float64 {
+	if !c.IsActive() {
+		return 0
+	}
+	return c.TotalPayment - c.TotalPaid()
+}
+
+// IsPaidOff reports whether an active contract has been paid in full.
+func (c *Contract) IsPaidOff() bool {
+	return c.IsActive() && c.Balance() < 0.005
+}
+
+// AddPayment records a payment towards the outstanding balance.
+func (c *Contract) AddPayment(amount float64, paidAt time.Time) (Payment, error) {
+	if !c.IsActive() {
+		return Payment{}, fmt.Errorf("%w: %s", ErrContractNotActive, c.ID)
+	}
+
+	if amount <= 0 {
+		return Payment{}, ErrInvalidPayment
+	}
+
+	if amount > c.Balance()+0.005 {
+		return Payment{}, fmt.Errorf("%w: остаток %.2f", ErrOverpayment, c.Balance())
+	}
+
+	payment := Payment{Amount: amount, PaidAt: paidAt}
+	c.Payments = append(c.Payments, payment)
+	return payment, nil
+}
+
+func (c *Contract) ReturnDeadline() time.Time {
+	return c.CreatedAt.AddDate(0, 0, c.Product.ReturnWindowDays())
+}
+
+// Cancel closes the contract after a product return and records the refund,
+// which equals everything the customer has paid so far.
+func (c *Contract) Cancel(reason string, now time.Time) error {
+	if !c.IsActive() {
+		return fmt.Errorf("%w: %s", ErrContractNotActive, c.ID)
+	}
+
+	if reason == "" {
+		return ErrEmptyCancelReason
+	}
+
+	if now.After(c.ReturnDeadline()) {
+		return fmt.Errorf("%w: для %s возврат возможен в течение %d дней",
+			ErrReturnWindowExpired, c.Product.Type, c.Product.ReturnWindowDays())
+	}
+
+	c.Status = ContractCancelled
+	c.CancelledAt = now
+	c.CancelReason = reason
+	c.Refund = c.TotalPaid()
+
+	return nil
+}
+
+func ActiveContracts(contracts []Contract) []Contract {
+	var active []Contract
+	for _, contract := range contracts {
+		if contract.IsActive() {
+			active = append(active, contract)
+		}
+	}
+	return active
+}
+
+type Installment struct {
+	Number  int
+	DueDate time.Time
+	Amount  float64
+	Paid    bool
+}
+
+// Schedule splits the total payment into equal monthly installments, the
+// first one due a month after the contract date. Rounding is absorbed by the
+// last installment; payments are applied to installments in order.
+func (c *Contract) Schedule() []Installment {
+	months := c.Product.PeriodMonths
+	if months <= 0 {
+		return nil
+	}
+
+	monthly := math.Floor(c.TotalPayment/float64(months)*100) / 100
+	paid := c.TotalPaid()
+	covered := 0.0
+
+	schedule := make([]Installment, months)
+	for i := range schedule {
+		amount := monthly
+		if i == months-1 {
+			amount = c.TotalPayment - monthly*float64(months-1)
+		}
+		covered += amount
+
+		schedule[i] = Installment{
+			Number:  i + 1,
+			DueDate: c.CreatedAt.AddDate(0, i+1, 0),
+			Amount:  amount,
+			Paid:    paid+0.005 >= covered,
+		}
+	}
+
+	return schedule
+}
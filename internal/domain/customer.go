@@ -0,0 +1,46 @@
+package domain
+
+import "errors"
+
+var (
+	ErrCustomerNotFound = errors.New("клиент не найден")
+	ErrInvalidEmail     = errors.New("неверный адрес электронной почты")
+)
+
+// Customer holds per-customer preferences keyed by phone number.
+type Customer struct {
+	PhoneNumber string
+	Language    string
+	OptedOut    bool
+	Email       string
+	MessengerID string
+	Channels    []Channel
+}
+
+// PreferredChannels returns the channels to notify the customer on; SMS
+// unless the customer chose otherwise.
+func (c Customer) PreferredChannels() []Channel {
+	if len(c.Channels) == 0 {
+		return []Channel{ChannelSMS}
+	}
+	return c.Channels
+}
+
+// CanReceive reports whether the customer has the contact the channel needs.
+func (c Customer) CanReceive(channel Channel) bool {
+	switch channel {
+	case ChannelSMS:
+		return c.PhoneNumber != ""
+	case ChannelEmail:
+		return c.Email != ""
+	case ChannelMessenger:
+		return c.MessengerID != ""
+	default:
+		return false
+	}
+}
+
+type CustomerRepository interface {
+	Save(customer Customer) error
+	FindByPhone(phoneNumber string) (Customer, error)
+}
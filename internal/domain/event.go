@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Event is something that happened in the installment process. Side effects
+// such as customer notifications and webhooks subscribe to events instead of
+// being called by the usecases directly.
+type Event interface {
+	EventName() string
+	OccurredAt() time.Time
+}
+
+const (
+	EventNameInstallmentQuoted    = "installment.quoted"
+	EventNameInstallmentConfirmed = "installment.confirmed"
+	EventNamePaymentReceived      = "payment.received"
+	EventNameInstallmentCancelled = "installment.cancelled"
+	EventNameNotificationFailed   = "notification.failed"
+)
+
+// InstallmentQuoted is raised when the installment terms have been calculated,
+// before a contract exists.
+type InstallmentQuoted struct {
+	Product      Product
+	TotalPayment float64
+	QuotedAt     time.Time
+}
+
+func (InstallmentQuoted) EventName() string       { return EventNameInstallmentQuoted }
+func (e InstallmentQuoted) OccurredAt() time.Time { return e.QuotedAt }
+
+type InstallmentConfirmed struct {
+	Contract Contract
+}
+
+func (InstallmentConfirmed) EventName() string       { return EventNameInstallmentConfirmed }
+func (e InstallmentConfirmed) OccurredAt() time.Time { return e.Contract.CreatedAt }
+
+type PaymentReceived struct {
+	Contract Contract
+	Payment  Payment
+}
+
+func (PaymentReceived) EventName() string       { return EventNamePaymentReceived }
+func (e PaymentReceived) OccurredAt() time.Time { return e.Payment.PaidAt }
+
+type InstallmentCancelled struct {
+	Contract Contract
+}
+
+func (InstallmentCancelled) EventName() string       { return EventNameInstallmentCancelled }
+func (e InstallmentCancelled) OccurredAt() time.Time { return e.Contract.CancelledAt }
+
+// NotificationFailed is raised when a customer could not be notified on any
+// channel.
+type NotificationFailed struct {
+	ContractID  string
+	PhoneNumber string
+	Event       MessageEvent
+	Error       string
+	FailedAt    time.Time
+}
+
+func (NotificationFailed) EventName() string       { return EventNameNotificationFailed }
+func (e NotificationFailed) OccurredAt() time.Time { return e.FailedAt }
+
+// EventPublisher passes events on to their subscribers and returns the errors
+// of those that handle events synchronously.
+type EventPublisher interface {
+	Publish(ctx context.Context, event Event) error
+}
@@ -0,0 +1,72 @@
+package domain
+
+import "time"
+
+type MessageEvent string
+
+const (
+	EventPurchase     MessageEvent = "purchase"
+	EventReminder     MessageEvent = "reminder"
+	EventOverdue      MessageEvent = "overdue"
+	EventPayoff       MessageEvent = "payoff"
+	EventCancellation MessageEvent = "cancellation"
+	EventConsent      MessageEvent = "consent"
+)
+
+var MessageEvents = []MessageEvent{
+	EventPurchase,
+	EventReminder,
+	EventOverdue,
+	EventPayoff,
+	EventCancellation,
+	EventConsent,
+}
+
+// IsMandatory reports whether the customer must get the message even after
+// opting out: purchase terms, debt notices and cancellations are required by
+// law, reminders and congratulations are not.
+func (e MessageEvent) IsMandatory() bool {
+	switch e {
+	case EventPurchase, EventOverdue, EventCancellation, EventConsent:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsUrgent reports whether the message must go out immediately rather than
+// wait for the end of quiet hours.
+func (e MessageEvent) IsUrgent() bool {
+	return e == EventPurchase || e == EventCancellation || e == EventConsent
+}
+
+// IsPerishable reports whether the message is useless once delayed, so it is
+// refused rather than queued when it cannot go out now.
+func (e MessageEvent) IsPerishable() bool {
+	return e == EventConsent
+}
+
+// MessageData is the single set of fields available to every customer
+// message template; fields irrelevant to an event are left zero.
+type MessageData struct {
+	ContractID        string
+	Product           ProductType
+	Price             float64
+	PeriodMonths      int
+	Overpayment       float64
+	TotalPayment      float64
+	InstallmentNumber int
+	DueDate           time.Time
+	Amount            float64
+	Days              int
+	Reason            string
+	Refund            float64
+	Code              string
+	ValidMinutes      int
+}
+
+// MessageRenderer renders the message for an event in the given language;
+// an empty or unknown language selects the default one.
+type MessageRenderer interface {
+	Render(language string, event MessageEvent, data MessageData) (string, error)
+}
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+type DeliveryStatus string
+
+const (
+	DeliveryQueued    DeliveryStatus = "queued"
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryFailed    DeliveryStatus = "failed"
+	DeliveryExpired   DeliveryStatus = "expired"
+)
+
+var ErrNotificationNotFound = errors.New("сообщение не найдено")
+
+// Notification tracks delivery of one customer message sent for a contract.
+type Notification struct {
+	Channel   Channel
+	MessageID string
+	Event     MessageEvent
+	Status    DeliveryStatus
+	Segments  int
+	SentAt    time.Time
+	UpdatedAt time.Time
+	Error     string
+}
+
+func NewNotification(event MessageEvent, receipt Receipt) Notification {
+	notification := Notification{Event: event}
+	notification.accept(receipt)
+	return notification
+}
+
+func (n *Notification) accept(receipt Receipt) {
+	n.Channel = receipt.Channel
+	n.MessageID = receipt.MessageID
+	n.Status = DeliveryPending
+	if receipt.Channel != "" && !receipt.Channel.HasDeliveryReports() {
+		n.Status = DeliveryDelivered
+	}
+	n.Segments = receipt.Segments
+	n.SentAt = receipt.AcceptedAt
+	n.UpdatedAt = receipt.AcceptedAt
+
+	if !receipt.ScheduledAt.IsZero() {
+		n.Status = DeliveryQueued
+		n.SentAt = receipt.ScheduledAt
+	}
+}
+
+func (s DeliveryStatus) IsFinal() bool {
+	return s == DeliveryDelivered || s == DeliveryFailed || s == DeliveryExpired
+}
+
+// IsLost reports whether the customer never got the message: the gateway
+// gave up on it, or no report arrived within the given time.
+func (n *Notification) IsLost(now time.Time, maxPending time.Duration) bool {
+	switch n.Status {
+	case DeliveryFailed, DeliveryExpired:
+		return true
+	case DeliveryPending, DeliveryQueued:
+		return now.Sub(n.SentAt) > maxPending
+	default:
+		return false
+	}
+}
+
+// HasMessage reports whether one of the contract notifications was sent as
+// messageID.
+func (c *Contract) HasMessage(messageID string) bool {
+	for _, notification := range c.Notifications {
+		if notification.MessageID == messageID {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateDelivery applies a delivery report to the notification with the given
+// message ID. Reports arriving after a final status are ignored, since
+// gateways may repeat or reorder them.
+func (c *Contract) UpdateDelivery(messageID string, status DeliveryStatus, at time.Time, reason string) bool {
+	for i := range c.Notifications {
+		notification := &c.Notifications[i]
+		if notification.MessageID != messageID {
+			continue
+		}
+
+		if !notification.Status.IsFinal() {
+			notification.Status = status
+			notification.UpdatedAt = at
+			notification.Error = reason
+		}
+		return true
+	}
+	return false
+}
+
+// ReplaceMessage points the notification sent as messageID at a new message,
+// e.g. when a queued message is finally handed to a provider. A receipt
+// without a channel keeps the notification's channel.
+func (c *Contract) ReplaceMessage(messageID string, receipt Receipt) bool {
+	for i := range c.Notifications {
+		if c.Notifications[i].MessageID == messageID {
+			if receipt.Channel == "" {
+				receipt.Channel = c.Notifications[i].Channel
+			}
+			c.Notifications[i].accept(receipt)
+			return true
+		}
+	}
+	return false
+}
@@ -22,6 +22,35 @@ var (
 
 var validPeriods = []int{3, 6, 9, 12, 18, 24}
 
+// PeriodRangeError reports a period outside the range allowed for a product
+// type. It keeps the bounds so that callers can present them in any language.
+type PeriodRangeError struct {
+	Type     ProductType
+	Min, Max int
+}
+
+func (e *PeriodRangeError) Error() string {
+	return fmt.Sprintf("%s: для %s допустимый срок от %d до %d месяцев",
+		ErrInvalidPeriod, e.Type, e.Min, e.Max)
+}
+
+func (e *PeriodRangeError) Unwrap() error {
+	return ErrInvalidPeriod
+}
+
+// PeriodValuesError reports a period that is not one of the allowed values.
+type PeriodValuesError struct {
+	Allowed []int
+}
+
+func (e *PeriodValuesError) Error() string {
+	return fmt.Sprintf("%s: допустимые значения: %v", ErrInvalidPeriod, e.Allowed)
+}
+
+func (e *PeriodValuesError) Unwrap() error {
+	return ErrInvalidPeriod
+}
+
 type Product struct {
 	Type         ProductType
 	Price        float64
@@ -46,8 +75,7 @@ func (p *Product) Validate() error {
 
 	min, max := p.getValidPeriods()
 	if p.PeriodMonths < min || p.PeriodMonths > max {
-		return fmt.Errorf("%w: для %s допустимый срок от %d до %d месяцев",
-			ErrInvalidPeriod, p.Type, min, max)
+		return &PeriodRangeError{Type: p.Type, Min: min, Max: max}
 	}
 
 	validPeriod := false
@@ -59,12 +87,26 @@ func (p *Product) Validate() error {
 	}
 
 	if !validPeriod {
-		return fmt.Errorf("%w: допустимые значения: %v", ErrInvalidPeriod, validPeriods)
+		return &PeriodValuesError{Allowed: validPeriods}
 	}
 
 	return nil
 }
 
+// AllowedPeriods returns the periods in months that can be chosen for the
+// product type.
+func (p *Product) AllowedPeriods() []int {
+	min, max := p.getValidPeriods()
+
+	var periods []int
+	for _, period := range validPeriods {
+		if period >= min && period <= max {
+			periods = append(periods, period)
+		}
+	}
+	return periods
+}
+
 func (p *Product) getValidPeriods() (int, int) {
 	switch p.Type {
 	case Smartphone:
@@ -78,6 +120,17 @@ func (p *Product) getValidPeriods() (int, int) {
 	}
 }
 
+func (p *Product) ReturnWindowDays() int {
+	switch p.Type {
+	case Smartphone, Computer:
+		return 14
+	case TV:
+		return 7
+	default:
+		return 0
+	}
+}
+
 func (p *Product) GetInterestRate() float64 {
 	switch p.Type {
 	case Smartphone:
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type ReminderKind string
+
+const (
+	ReminderDue     ReminderKind = "due"
+	ReminderOverdue ReminderKind = "overdue"
+)
+
+// ReminderLog remembers which reminders were already sent so that repeated
+// or overlapping runs never text the customer twice about the same
+// installment. A reminder is claimed before it is sent and released only if
+// sending fails.
+type ReminderLog interface {
+	// Claim records the reminder unless it is already recorded and reports
+	// whether the caller got it.
+	Claim(key string, at time.Time) (bool, error)
+	Release(key string) error
+}
+
+func ReminderKey(contractID string, installmentNumber int, kind ReminderKind) string {
+	return fmt.Sprintf("%s/%d/%s", contractID, installmentNumber, kind)
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrMessageSuppressed is returned for optional messages to customers who opted
+// out of them.
+var ErrMessageSuppressed = errors.New("клиент отказался от необязательных сообщений")
+
+// ErrSendLimited is returned for perishable messages that the send policy
+// would hold back.
+var ErrSendLimited = errors.New("превышено число смс на номер, повторите позже")
+
+type messageEventKey struct{}
+
+// WithMessageEvent tells senders which event a message belongs to, so that
+// send policies can tell required messages from optional ones.
+func WithMessageEvent(ctx context.Context, event MessageEvent) context.Context {
+	return context.WithValue(ctx, messageEventKey{}, event)
+}
+
+func MessageEventFromContext(ctx context.Context) (MessageEvent, bool) {
+	event, ok := ctx.Value(messageEventKey{}).(MessageEvent)
+	return event, ok
+}
+
+// QueuedSMS is a message held back until NotBefore.
+type QueuedSMS struct {
+	ID          string
+	PhoneNumber string
+	Message     string
+	Event       MessageEvent
+	NotBefore   time.Time
+	QueuedAt    time.Time
+}
+
+type SMSOutbox interface {
+	Enqueue(message QueuedSMS) error
+	Due(now time.Time) ([]QueuedSMS, error)
+	Remove(id string) error
+}
+
+// SMSSendLog remembers when messages were sent to each phone number.
+type SMSSendLog interface {
+	SentSince(phoneNumber string, since time.Time) ([]time.Time, error)
+	Record(phoneNumber string, sentAt time.Time) error
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "context"
+
 type SMSSender interface {
-	SendSMS(phoneNumber string, message string) error
+	SendSMS(ctx context.Context, phoneNumber string, message string) (Receipt, error)
 }
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrWebhookNotFound = errors.New("вебхук не найден")
+
+// DeadWebhook is a webhook delivery that failed on every attempt.
+type DeadWebhook struct {
+	ID           string
+	Subscription string
+	URL          string
+	Event        string
+	Payload      string
+	Attempts     int
+	LastError    string
+	FailedAt     time.Time
+}
+
+type DeadWebhookRepository interface {
+	Add(webhook DeadWebhook) error
+	FindAll() ([]DeadWebhook, error)
+}
+
+// PendingWebhook is a webhook delivery that failed and waits for its next
+// attempt.
+type PendingWebhook struct {
+	ID            string
+	Subscription  string
+	URL           string
+	Event         string
+	Payload       string
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+}
+
+// PendingWebhookRepository keys deliveries by event ID and subscription.
+type PendingWebhookRepository interface {
+	Add(webhook PendingWebhook) error
+	FindAll() ([]PendingWebhook, error)
+	Update(webhook PendingWebhook) error
+	Remove(id, subscription string) error
+}
@@ -0,0 +1,220 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/money"
+	"github.com/icoder-new/installment-cli/internal/phone"
+)
+
+type Language string
+
+const (
+	Russian Language = "ru"
+	Tajik   Language = "tg"
+	English Language = "en"
+
+	DefaultLanguage = Russian
+)
+
+var Languages = []Language{Russian, Tajik, English}
+
+var bundles = map[Language]map[string]string{
+	Russian: russian,
+	Tajik:   tajik,
+	English: english,
+}
+
+// ParseLanguage accepts language codes as well as POSIX locale names such as
+// "tg_TJ.UTF-8". The country code "tj" is accepted as an alias for Tajik.
+func ParseLanguage(value string) (Language, bool) {
+	code := strings.ToLower(value)
+	if i := strings.IndexAny(code, "_.-@"); i >= 0 {
+		code = code[:i]
+	}
+
+	switch code {
+	case "ru":
+		return Russian, true
+	case "tg", "tj":
+		return Tajik, true
+	case "en":
+		return English, true
+	default:
+		return "", false
+	}
+}
+
+// Detect picks the language from the explicit --lang value first and then from
+// the environment locale variables, falling back to Russian.
+func Detect(flagValue string, env func(string) string) Language {
+	candidates := []string{flagValue}
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		candidates = append(candidates, env(key))
+	}
+
+	for _, candidate := range candidates {
+		if lang, ok := ParseLanguage(candidate); ok {
+			return lang
+		}
+	}
+
+	return DefaultLanguage
+}
+
+type Catalog struct {
+	lang Language
+}
+
+func New(lang Language) *Catalog {
+	if _, ok := bundles[lang]; !ok {
+		lang = DefaultLanguage
+	}
+	return &Catalog{lang: lang}
+}
+
+func (c *Catalog) Language() Language {
+	return c.lang
+}
+
+// T returns the message for key formatted with args. Missing translations
+// fall back to Russian and then to the key itself.
+func (c *Catalog) T(key string, args ...any) string {
+	message, ok := bundles[c.lang][key]
+	if !ok {
+		message, ok = bundles[DefaultLanguage][key]
+	}
+	if !ok {
+		message = key
+	}
+
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+func (c *Catalog) Product(productType domain.ProductType) string {
+	switch productType {
+	case domain.Smartphone:
+		return c.T("product.smartphone")
+	case domain.Computer:
+		return c.T("product.computer")
+	case domain.TV:
+		return c.T("product.tv")
+	default:
+		return string(productType)
+	}
+}
+
+// Money formats an amount the way it is written in the catalog language,
+// without the currency.
+func (c *Catalog) Money(amount float64) string {
+	return money.Locale{Group: c.T("money.group"), Decimal: c.T("money.decimal")}.Format(amount)
+}
+
+// MoneyWords writes an amount in words for agreements and receipts, e.g.
+// "одна тысяча шестьдесят сомони 00 дирам".
+func (c *Catalog) MoneyWords(amount float64) string {
+	switch c.lang {
+	case Tajik:
+		return money.TajikWords(amount)
+	case English:
+		return money.EnglishWords(amount)
+	default:
+		return money.RussianWords(amount)
+	}
+}
+
+// ParseMoney reads an amount written the way of any supported language,
+// optionally with the currency name.
+func ParseMoney(input string) (float64, error) {
+	currencies := []string{"TJS", "смн"}
+	for _, lang := range Languages {
+		currencies = append(currencies, bundles[lang]["currency"])
+	}
+	return money.Parse(input, currencies...)
+}
+
+// Error renders err in the catalog language. Cancellation and timeouts are
+// always translated. Otherwise Russian keeps the original, fully detailed
+// text; other languages translate the domain error found in the chain and
+// fall back to the original text for anything else.
+func (c *Catalog) Error(err error) string {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return c.T("error.interrupted")
+	case errors.Is(err, context.DeadlineExceeded):
+		return c.T("error.timeout")
+	case c.lang == Russian:
+		return err.Error()
+	}
+
+	var rangeErr *domain.PeriodRangeError
+	if errors.As(err, &rangeErr) {
+		return c.T("error.period_range", c.Product(rangeErr.Type), rangeErr.Min, rangeErr.Max)
+	}
+
+	var valuesErr *domain.PeriodValuesError
+	if errors.As(err, &valuesErr) {
+		return c.T("error.period_values", valuesErr.Allowed)
+	}
+
+	for _, known := range domainErrors {
+		if errors.Is(err, known.err) {
+			return c.T(known.key)
+		}
+	}
+
+	return err.Error()
+}
+
+// domainErrors is checked in order, so an error that wraps several of them
+// is always translated by the first one listed.
+var domainErrors = []struct {
+	err error
+	key string
+}{
+	{domain.ErrInvalidPrice, "error.invalid_price"},
+	{domain.ErrInvalidPhoneNumber, "error.missing_phone"},
+	{domain.ErrInvalidProductType, "error.invalid_product"},
+	{domain.ErrInvalidPeriod, "error.invalid_period"},
+	{domain.ErrContractNotFound, "error.contract_not_found"},
+	{domain.ErrContractNotActive, "error.contract_not_active"},
+	{domain.ErrEmptyCancelReason, "error.empty_cancel_reason"},
+	{domain.ErrReturnWindowExpired, "error.return_window_expired"},
+	{domain.ErrNotificationNotFound, "error.notification_not_found"},
+	{domain.ErrInvalidEmail, "error.invalid_email"},
+	{domain.ErrUnknownChannel, "error.unknown_channel"},
+	{domain.ErrNoChannel, "error.no_channel"},
+	{domain.ErrMessageSuppressed, "error.message_suppressed"},
+	{domain.ErrSendLimited, "error.send_limited"},
+	{domain.ErrInvalidPayment, "error.invalid_payment"},
+	{domain.ErrOverpayment, "error.overpayment"},
+	{domain.ErrWebhookNotFound, "error.unknown_webhook"},
+	{domain.ErrConsentNotFound, "error.consent_not_found"},
+	{domain.ErrConsentExpired, "error.consent_expired"},
+	{domain.ErrConsentCodeMismatch, "error.consent_code_mismatch"},
+	{domain.ErrConsentAttemptsExceeded, "error.consent_attempts_exceeded"},
+	{domain.ErrInvalidDocumentFormat, "error.document_format"},
+	{phone.ErrInvalidFormat, "error.phone_format"},
+	{phone.ErrUnknownOperator, "error.phone_operator"},
+	{phone.ErrCountryNotAllowed, "error.phone_country"},
+	{money.ErrInvalidFormat, "error.amount_format"},
+	{money.ErrTooPrecise, "error.amount_precision"},
+}
+
+// Keys lists the message keys defined for lang.
+func Keys(lang Language) []string {
+	keys := make([]string, 0, len(bundles[lang]))
+	for key := range bundles[lang] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,111 @@
+package i18n_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/i18n"
+	"github.com/icoder-new/installment-cli/internal/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalog_BundlesAreComplete(t *testing.T) {
+	for _, lang := range i18n.Languages {
+		t.Run(string(lang), func(t *testing.T) {
+			assert.ElementsMatch(t, i18n.Keys(i18n.Russian), i18n.Keys(lang))
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		flag     string
+		env      map[string]string
+		expected i18n.Language
+	}{
+		{name: "Flag wins", flag: "en", env: map[string]string{"LANG": "tg_TJ.UTF-8"}, expected: i18n.English},
+		{name: "LANG locale", env: map[string]string{"LANG": "tg_TJ.UTF-8"}, expected: i18n.Tajik},
+		{name: "LC_ALL before LANG", env: map[string]string{"LC_ALL": "en_US", "LANG": "ru_RU"}, expected: i18n.English},
+		{name: "Country alias", flag: "tj", expected: i18n.Tajik},
+		{name: "Unsupported falls back", env: map[string]string{"LANG": "C.UTF-8"}, expected: i18n.Russian},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := func(key string) string { return tt.env[key] }
+			assert.Equal(t, tt.expected, i18n.Detect(tt.flag, env))
+		})
+	}
+}
+
+func TestParseProduct(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected domain.ProductType
+		ok       bool
+	}{
+		{input: "смартфон", expected: domain.Smartphone, ok: true},
+		{input: "Компютер", expected: domain.Computer, ok: true},
+		{input: "TV", expected: domain.TV, ok: true},
+		{input: "Computer", expected: domain.Computer, ok: true},
+		{input: "холодильник", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			productType, ok := i18n.ParseProduct(tt.input)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, productType)
+		})
+	}
+}
+
+func TestCatalog_Error(t *testing.T) {
+	product := domain.Product{Type: domain.Smartphone, Price: 100, PhoneNumber: "992001002005", PeriodMonths: 12}
+	err := fmt.Errorf("wrapped: %w", product.Validate())
+
+	assert.Equal(t, err.Error(), i18n.New(i18n.Russian).Error(err))
+	assert.Equal(t,
+		"invalid installment period: for Smartphone the allowed period is 3 to 9 months",
+		i18n.New(i18n.English).Error(err))
+	assert.Equal(t, "нарх бояд аз 0 зиёд бошад", i18n.New(i18n.Tajik).Error(domain.ErrInvalidPrice))
+}
+
+func TestCatalog_ErrorIsDeterministic(t *testing.T) {
+	err := errors.Join(domain.ErrContractNotFound, fmt.Errorf("wrapped: %w", domain.ErrInvalidPrice))
+	catalog := i18n.New(i18n.English)
+
+	want := catalog.Error(domain.ErrInvalidPrice)
+	for range 20 {
+		assert.Equal(t, want, catalog.Error(err), "the first error in the list wins")
+	}
+}
+
+func TestCatalog_Money(t *testing.T) {
+	assert.Equal(t, "28 000,50", i18n.New(i18n.Russian).Money(28000.5))
+	assert.Equal(t, "28 000,50", i18n.New(i18n.Tajik).Money(28000.5))
+	assert.Equal(t, "28,000.50", i18n.New(i18n.English).Money(28000.5))
+}
+
+func TestCatalog_MoneyWords(t *testing.T) {
+	assert.Equal(t, "одна тысяча шестьдесят сомони 00 дирам", i18n.New(i18n.Russian).MoneyWords(1060))
+	assert.Equal(t, "як ҳазору шаст сомонӣ 00 дирам", i18n.New(i18n.Tajik).MoneyWords(1060))
+	assert.Equal(t, "one thousand sixty somoni 00 dirams", i18n.New(i18n.English).MoneyWords(1060))
+}
+
+func TestParseMoney(t *testing.T) {
+	for _, input := range []string{"1 500,50", "1500.50 сомонӣ", "1,500.50 somoni", "1500,5 TJS", "1 500,50 смн"} {
+		amount, err := i18n.ParseMoney(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, 1500.5, amount, input)
+	}
+
+	_, err := i18n.ParseMoney("1,555")
+	assert.ErrorIs(t, err, money.ErrTooPrecise, "a lone comma before three digits may mean decimals")
+	_, err = i18n.ParseMoney("1,5555")
+	assert.Equal(t, "an amount cannot have more than two decimal places", i18n.New(i18n.English).Error(err))
+}
@@ -0,0 +1,322 @@
+package i18n
+
+var english = map[string]string{
+	"product.smartphone": "Smartphone",
+	"product.computer":   "Computer",
+	"product.tv":         "TV",
+	"currency":           "somoni",
+	"money.group":        ",",
+	"money.decimal":      ".",
+
+	"prompt.product":      "Choose the product type (1-%s, 2-%s, 3-%s)",
+	"prompt.price":        "Enter the product price (somoni)",
+	"prompt.phone":        "Enter the phone number (e.g. +992 93 123 45 67)",
+	"prompt.period":       "Choose the installment period (available: %s)",
+	"prompt.consent_code": "Enter the code from the customer's SMS",
+	"prompt.back":         "back",
+	"prompt.restart":      "restart",
+	"prompt.navigation":   "Commands: back - previous question, restart - start over, ? - help",
+
+	"help.product":      "Product type: a number from the list or the name. The product sets the markup and the maximum period",
+	"help.price":        "Product price in somoni, e.g. 1500 or 1499.90",
+	"help.phone":        "Customer mobile number: +992XXXXXXXXX, 992XXXXXXXXX or 9 digits without the country code. Spaces and dashes are allowed",
+	"help.months":       "Installment period in months from the list. Smartphone up to 9, computer up to 12, TV up to 18 months",
+	"help.consent_code": "The six-digit code from the SMS the customer received",
+	"help.review":       "Enter - calculate, field number - change it, back - return to the last question, restart - start over",
+
+	"review.title":   "Check the details:",
+	"review.product": "Product",
+	"review.price":   "Price",
+	"review.phone":   "Phone",
+	"review.months":  "Period, months",
+	"review.prompt":  "Enter - calculate, 1-4 - change a field",
+
+	"form.periods":         "available: %s",
+	"form.monthly":         "Monthly:",
+	"form.schedule":        "Payment schedule:",
+	"form.more":            "... and %d more",
+	"form.keys":            "Tab/↑↓ - field, ←→ - choose, Enter - next, Esc/Ctrl+C - cancel",
+	"form.submit_contract": "Open and send SMS",
+	"form.submit_quote":    "Calculate",
+	"form.unsupported":     "The terminal does not support the full-screen form, using the prompts",
+
+	"result.title":       "INSTALLMENT",
+	"result.price":       "Price:",
+	"result.period":      "Period:",
+	"result.months":      "%d months",
+	"result.total":       "Total amount:",
+	"result.overpayment": "Overpayment:",
+	"result.contract_id": "Contract number: %s",
+
+	"consent.sent": "Confirmation code sent to %s, valid until %s",
+
+	"cancel.done":     "Contract %s cancelled",
+	"cancel.reason":   "Reason: %s",
+	"cancel.refund":   "Refund: %s %s",
+	"cancel.required": "--contract and --reason are required",
+
+	"pay.done":     "Payment of %s %s accepted for contract %s",
+	"pay.balance":  "Balance: %s %s",
+	"pay.paid_off": "The installment is paid off",
+	"pay.required": "--contract and --amount are required",
+
+	"remind.sent": "Reminders sent: %d",
+
+	"contracts.usage":            "usage: contracts show --id ID | contracts render --id ID --format html|pdf|md [-o FILE] | contracts undelivered [--older-than 24h]",
+	"contracts.id":               "Contract: %s",
+	"contracts.status":           "Status: %s",
+	"contracts.status.active":    "active",
+	"contracts.status.cancelled": "cancelled",
+	"contracts.product":          "Product: %s, phone %s",
+	"contracts.total":            "Total amount: %s %s",
+	"contracts.balance":          "Balance: %s %s",
+	"contracts.consent":          "Customer consent: %s, phone %s",
+	"contracts.notifications":    "Notifications:",
+	"contracts.all_delivered":    "All notifications delivered",
+	"contracts.saved":            "Agreement saved to %s",
+	"contracts.pdf_terminal":     "cannot write a PDF to the terminal, give a file: -o %s.pdf",
+
+	"document.title":               "Installment agreement No. %s",
+	"document.date":                "Date",
+	"document.parties":             "1. Parties",
+	"document.seller":              "Seller",
+	"document.address":             "Address",
+	"document.phone":               "Phone",
+	"document.tax_id":              "Tax ID",
+	"document.buyer":               "Buyer",
+	"document.subject":             "2. Subject of the agreement",
+	"document.product":             "Product",
+	"document.price":               "Price",
+	"document.period":              "Installment period",
+	"document.cost":                "3. Cost of the installment plan",
+	"document.overpayment":         "Cost of credit",
+	"document.overpayment_percent": "of the price",
+	"document.total":               "Total amount payable",
+	"document.words":               "In words",
+	"document.schedule":            "4. Payment schedule",
+	"document.number":              "No.",
+	"document.due_date":            "Due date",
+	"document.amount":              "Amount",
+	"document.schedule_total":      "Total",
+	"document.terms":               "The buyer pays the installments no later than the dates in the schedule. Early repayment is allowed at no extra charge.",
+	"document.signatures":          "5. Signatures",
+	"document.signature":           "signature",
+
+	"delivery.queued":    "queued",
+	"delivery.pending":   "pending",
+	"delivery.delivered": "delivered",
+	"delivery.failed":    "failed",
+	"delivery.expired":   "expired",
+
+	"email.subject.purchase":     "Your installment purchase",
+	"email.subject.reminder":     "Payment reminder",
+	"email.subject.overdue":      "Overdue payment",
+	"email.subject.payoff":       "Installment paid off",
+	"email.subject.cancellation": "Installment contract cancelled",
+	"email.subject.consent":      "Installment confirmation code",
+
+	"customers.usage":     "usage: customers set --phone PHONE [--email ADDRESS] [--messenger ID] [--channels sms,email,messenger] | customers show --phone PHONE",
+	"customers.phone":     "Phone: %s",
+	"customers.email":     "Email: %s",
+	"customers.messenger": "Messenger: %s",
+	"customers.channels":  "Notification channels: %s",
+
+	"sms.usage":     "usage: sms preview --event EVENT [--templates DIR] | sms opt-out|opt-in --phone PHONE | sms flush",
+	"sms.analysis":  "[%s, characters: %d, segments: %d, left in last: %d]",
+	"sms.flushed":   "Deferred messages sent: %d",
+	"sms.opted_out": "Customer %s opted out of optional messages",
+	"sms.opted_in":  "Customer %s receives all messages again",
+	"sms.compacted": "Compacted to %d segment(s):",
+
+	"webhooks.usage":       "usage: webhooks test [--name NAME] | webhooks retry | webhooks pending | webhooks dead",
+	"webhooks.retried":     "Delivered: %d, queued: %d, undelivered: %d",
+	"webhooks.no_pending":  "No queued webhooks",
+	"webhooks.none":        "No webhooks configured",
+	"webhooks.ok":          "%s: delivered",
+	"webhooks.failed":      "%s: error: %s",
+	"webhooks.no_dead":     "No undelivered webhooks",
+	"webhooks.test_failed": "not every webhook accepted the test event",
+
+	"command.calc":       "calculate an installment and open a contract (default)",
+	"command.quote":      "calculate an installment without a contract or SMS",
+	"command.batch":      "calculate installments for a CSV list of products",
+	"command.contracts":  "show or print a contract, undelivered notifications",
+	"command.pay":        "record a payment on a contract",
+	"command.cancel":     "cancel a contract when the product is returned",
+	"command.remind":     "send payment reminders",
+	"command.customers":  "customer contacts and notification channels",
+	"command.sms":        "preview templates, opt-outs, deferred SMS",
+	"command.webhooks":   "test webhooks and list undelivered ones",
+	"command.serve":      "start the HTTP API",
+	"command.config":     "show the current settings",
+	"command.shell":      "counter session: calculations without restarting the program",
+	"command.completion": "print the completion script for bash, zsh or fish",
+	"command.help":       "help on commands",
+	"app.help_hint":      "Help: %s help",
+	"app.flags":          "Usage: %s %s [OPTIONS]",
+
+	"flag.long_form":      "%s (long form)",
+	"flag.interactive":    "Interactive mode",
+	"flag.product":        "Product type (%s, %s, %s)",
+	"flag.price":          "Product price, e.g. 1500 or \"1,500.50\"",
+	"flag.phone":          "Phone number",
+	"flag.months":         "Installment period",
+	"flag.answers":        "YAML file with answers for interactive mode",
+	"flag.tui":            "Full-screen form instead of step-by-step questions",
+	"flag.sms_lang":       "Customer SMS language (ru, tg, en)",
+	"flag.consent":        "Confirm customer consent with an SMS code",
+	"flag.customer_phone": "Customer phone number",
+	"flag.event":          "Event: %s",
+	"flag.templates":      "Templates directory",
+	"flag.sms_text_lang":  "SMS language (ru, tg, en)",
+	"flag.max_segments":   "Shorten the text to this many segments",
+	"flag.transliterate":  "Allow transliteration when shortening",
+	"flag.contract":       "Contract number",
+	"flag.reason":         "Cancellation reason",
+	"flag.amount":         "Payment amount in somoni",
+	"flag.days":           "Days before a payment to send a reminder",
+	"flag.overdue":        "Days overdue before sending a reminder",
+	"flag.format":         "Format: html, pdf or md",
+	"flag.output":         "Output file (standard output by default)",
+	"flag.older_than":     "How long to wait for a delivery report",
+	"flag.batch_file":     "CSV file with product,price,phone,months columns (- for standard input)",
+	"flag.email":          "Email address",
+	"flag.messenger":      "Messenger ID",
+	"flag.channels":       "Notification channels, comma-separated: sms, email, messenger",
+	"flag.webhook_name":   "Webhook name (all by default)",
+	"flag.addr":           "HTTP API address",
+
+	"completion.usage": "usage: completion bash|zsh|fish",
+
+	"batch.failed":   "failed to calculate %d of %d rows",
+	"config.env":     "from environment",
+	"config.default": "default",
+
+	"shell.welcome":         "Counter session. help - list of commands, exit - quit",
+	"shell.quoted":          "confirm - open the contract, quote - change the calculation, new - next customer",
+	"shell.history_empty":   "No calculations in this session yet",
+	"shell.history_row":     "%s, %s for %d months: %s %s, phone %s",
+	"shell.command.new":     "new customer: enter the details and calculate",
+	"shell.command.quote":   "change the current calculation or calculate with the quote options",
+	"shell.command.confirm": "open the contract for the current calculation [--consent] [--sms-lang LANG]",
+	"shell.command.history": "calculations of this session",
+	"shell.command.repeat":  "repeat calculation [NUMBER] from the history, the last by default",
+	"shell.command.help":    "this help, help COMMAND - help on a command",
+	"shell.command.exit":    "end the session",
+	"shell.help_footer":     "The other commands of the program (pay, cancel, contracts and so on) work too. Tab completes commands, products and periods",
+
+	"error.prefix":             "Error: ",
+	"error.calculation":        "installment calculation failed",
+	"error.cancel":             "installment cancellation failed",
+	"error.pay":                "payment failed",
+	"error.empty_field":        "the field cannot be empty",
+	"error.not_a_number":       "enter a valid number",
+	"error.product_choice":     "choose 1, 2 or 3, or type the product name",
+	"error.product_names":      "invalid product type. Allowed values: %s, %s, %s",
+	"error.product_flag":       "invalid product type: %s. Allowed values: 1/%s, 2/%s, 3/%s",
+	"error.unknown_product":    "unknown product type: %s",
+	"error.product_suggestion": "Did you mean \"%s\"?",
+	"error.price_positive":     "the price must be a positive number",
+	"error.price_negative":     "the price cannot be negative",
+	"error.phone_format":       "invalid phone number format. Use +992XXXXXXXXX, 992XXXXXXXXX or 9XXXXXXXX",
+	"error.phone_operator":     "the number does not belong to a mobile operator. Check the number",
+	"error.phone_country":      "numbers of this country are not accepted",
+	"error.amount_format":      "invalid amount, enter e.g. 1500 or 1,500.50",
+	"error.amount_precision":   "an amount cannot have more than two decimal places",
+	"error.period_bounds":      "the installment period must be from %d to %d months",
+	"error.period_max":         "the maximum installment period for %s is %d months",
+	"error.period_values":      "invalid installment period. Allowed values: %v",
+	"error.period_range":       "invalid installment period: for %s the allowed period is %d to %d months",
+	"error.months_negative":    "the installment period cannot be negative",
+	"error.days_negative":      "the number of days cannot be negative",
+	"error.flags_required":     "all flags are required in non-interactive mode, or use interactive mode (-i/--interactive)",
+	"error.unknown_language":   "unknown language: %s. Allowed values: ru, tg, en",
+	"error.env_value":          "invalid value of %s: %s",
+	"error.interrupted":        "operation interrupted",
+	"error.timeout":            "operation timed out",
+
+	"error.invalid_price":             "the price must be greater than 0",
+	"error.missing_phone":             "a phone number is required",
+	"error.invalid_product":           "invalid product type",
+	"error.invalid_period":            "invalid installment period",
+	"error.contract_not_found":        "contract not found",
+	"error.contract_not_active":       "the contract is not active",
+	"error.empty_cancel_reason":       "a cancellation reason is required",
+	"error.return_window_expired":     "the return period has expired",
+	"error.notification_not_found":    "message not found",
+	"error.message_suppressed":        "the customer opted out of optional messages",
+	"error.send_limited":              "too many SMS to this number, try again later",
+	"error.invalid_email":             "invalid email address",
+	"error.unknown_channel":           "unknown notification channel. Allowed values: sms, email, messenger",
+	"error.no_channel":                "no notification channel available: set the customer's contact first",
+	"error.invalid_payment":           "payment amount must be greater than 0",
+	"error.overpayment":               "payment exceeds the contract balance",
+	"error.unknown_webhook":           "webhook not found",
+	"error.consent_code_format":       "the code must be 6 digits",
+	"error.consent_not_found":         "confirmation request not found",
+	"error.consent_expired":           "the code has expired, start the installment again",
+	"error.consent_code_mismatch":     "wrong confirmation code",
+	"error.consent_attempts_exceeded": "too many wrong codes, start the installment again",
+	"error.document_format":           "invalid document format. Allowed values: html, pdf, md",
+	"error.dlr_token":                 "INSTALLMENT_DLR_TOKEN is not set: without a shared secret anyone could post delivery reports",
+	"error.api_token":                 "INSTALLMENT_API_TOKEN is not set: without it anyone could start an installment and text a code to any number",
+	"error.unknown_command":           "unknown command %q",
+	"error.unexpected_argument":       "unexpected argument %q",
+	"error.batch_open":                "failed to open the file",
+	"error.batch_read":                "failed to read CSV",
+	"error.input_closed":              "input ended without an answer to %q",
+	"error.invalid_answer":            "invalid answer to %q: %s",
+	"error.answers_open":              "failed to open the answers file",
+	"error.answers_parse":             "invalid answers file %s",
+	"error.review_choice":             "choose a field number from 1 to 4 or press Enter",
+	"error.shell_nested":              "the session is already running",
+	"error.shell_no_quote":            "nothing to confirm, run new or quote first",
+	"error.shell_no_history":          "no calculations in this session yet",
+	"error.shell_history_number":      "no calculation %s in the history, numbers are 1 to %d",
+
+	"usage": `Usage: %[1]s [COMMAND] [OPTIONS]
+
+Commands:
+`,
+	"usage.footer": `
+Without a command %[2]s runs, so the original invocation keeps working:
+  %[1]s -p Smartphone -c 1000 -n +992931234567 -m 6
+
+Command help: %[1]s help COMMAND or %[1]s COMMAND -h
+The global option --lang LANG sets the interface language (ru, tg, en), defaults to LANG.
+The global option --plain prints results as plain lines without boxes or colour.
+Exit codes: 0 - success, 1 - failure, 2 - wrong invocation, 130 - interrupted.
+`,
+	"calc.usage": `Usage: %[1]s [calc] [OPTIONS]
+       %[1]s quote [OPTIONS]
+
+calc calculates the installment and opens a contract, quote only shows the calculation.
+
+Options:
+  -h, --help             Show this help
+  -i, --interactive      Interactive mode
+  -p, --product TYPE     Product type (Smartphone, Computer, TV)
+  -c, --cost PRICE       Product price in somoni, e.g. 1500 or "1,500.50"
+  -n, --number PHONE     Customer phone number
+  -m, --months MONTHS    Installment period in months
+  --answers FILE         Take the answers from a YAML file (implies -i)
+  --tui                  Full-screen form instead of the prompts (implies -i)
+  --sms-lang LANG        Customer SMS language (ru, tg, en), calc only
+  --consent              Confirm customer consent with a code sent by SMS, calc only
+
+Examples:
+  %[1]s -p Smartphone -c 1000 -n +992931234567 -m 6
+  %[1]s calc --product=Computer --cost=2000 --number=+992931234567 --months=12
+  %[1]s quote -p TV -c 3000 -n +992931234567 -m 12
+  %[1]s -i
+
+In interactive mode some options can be given on the command line
+and the rest entered in the dialog:
+  %[1]s -p TV -i
+
+The answers can be written to a file in advance, so the dialog runs
+unattended and the same way every time:
+  %[1]s --answers answers.yaml
+
+`,
+}
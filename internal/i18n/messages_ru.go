@@ -0,0 +1,322 @@
+package i18n
+
+var russian = map[string]string{
+	"product.smartphone": "Смартфон",
+	"product.computer":   "Компьютер",
+	"product.tv":         "Телевизор",
+	"currency":           "сомони",
+	"money.group":        " ",
+	"money.decimal":      ",",
+
+	"prompt.product":      "Выберите тип товара (1-%s, 2-%s, 3-%s)",
+	"prompt.price":        "Введите цену товара (сомони)",
+	"prompt.phone":        "Введите номер телефона (например, +992 93 123 45 67)",
+	"prompt.period":       "Выберите срок рассрочки (доступно: %s)",
+	"prompt.consent_code": "Введите код из смс клиента",
+	"prompt.back":         "назад",
+	"prompt.restart":      "заново",
+	"prompt.navigation":   "Команды: назад - предыдущий вопрос, заново - начать сначала, ? - подсказка",
+
+	"help.product":      "Тип товара: номер из списка или название. От товара зависит наценка и максимальный срок",
+	"help.price":        "Цена товара в сомони, например 1500 или 1499.90",
+	"help.phone":        "Мобильный номер клиента: +992XXXXXXXXX, 992XXXXXXXXX или 9 цифр без кода страны. Пробелы и дефисы допускаются",
+	"help.months":       "Срок рассрочки в месяцах из списка. Смартфон до 9, компьютер до 12, телевизор до 18 месяцев",
+	"help.consent_code": "Шестизначный код из смс, которое получил клиент",
+	"help.review":       "Enter - рассчитать, номер поля - изменить его, назад - вернуться к последнему вопросу, заново - начать сначала",
+
+	"review.title":   "Проверьте данные:",
+	"review.product": "Товар",
+	"review.price":   "Цена",
+	"review.phone":   "Телефон",
+	"review.months":  "Срок, мес.",
+	"review.prompt":  "Enter - рассчитать, 1-4 - изменить поле",
+
+	"form.periods":         "доступно: %s",
+	"form.monthly":         "Ежемесячно:",
+	"form.schedule":        "График платежей:",
+	"form.more":            "... и еще %d",
+	"form.keys":            "Tab/↑↓ - поле, ←→ - выбор, Enter - далее, Esc/Ctrl+C - отмена",
+	"form.submit_contract": "Оформить и отправить смс",
+	"form.submit_quote":    "Рассчитать",
+	"form.unsupported":     "Терминал не поддерживает полноэкранную форму, используется пошаговый ввод",
+
+	"result.title":       "РАССРОЧКА",
+	"result.price":       "Цена товара:",
+	"result.period":      "Срок:",
+	"result.months":      "%d мес.",
+	"result.total":       "Итоговая сумма:",
+	"result.overpayment": "Переплата:",
+	"result.contract_id": "Номер договора: %s",
+
+	"consent.sent": "Код подтверждения отправлен на %s, действует до %s",
+
+	"cancel.done":     "Договор %s отменен",
+	"cancel.reason":   "Причина: %s",
+	"cancel.refund":   "К возврату: %s %s",
+	"cancel.required": "необходимо указать --contract и --reason",
+
+	"pay.done":     "Принят платеж %s %s по договору %s",
+	"pay.balance":  "Остаток: %s %s",
+	"pay.paid_off": "Рассрочка полностью погашена",
+	"pay.required": "необходимо указать --contract и --amount",
+
+	"remind.sent": "Отправлено напоминаний: %d",
+
+	"contracts.usage":            "использование: contracts show --id НОМЕР | contracts render --id НОМЕР --format html|pdf|md [-o ФАЙЛ] | contracts undelivered [--older-than 24h]",
+	"contracts.id":               "Договор: %s",
+	"contracts.status":           "Статус: %s",
+	"contracts.status.active":    "активен",
+	"contracts.status.cancelled": "отменен",
+	"contracts.product":          "Товар: %s, телефон %s",
+	"contracts.total":            "Итоговая сумма: %s %s",
+	"contracts.balance":          "Остаток: %s %s",
+	"contracts.consent":          "Согласие клиента: %s, телефон %s",
+	"contracts.notifications":    "Уведомления:",
+	"contracts.all_delivered":    "Все уведомления доставлены",
+	"contracts.saved":            "Договор сохранен в %s",
+	"contracts.pdf_terminal":     "PDF нельзя вывести в терминал, укажите файл: -o %s.pdf",
+
+	"document.title":               "Договор рассрочки № %s",
+	"document.date":                "Дата",
+	"document.parties":             "1. Стороны",
+	"document.seller":              "Продавец",
+	"document.address":             "Адрес",
+	"document.phone":               "Телефон",
+	"document.tax_id":              "ИНН",
+	"document.buyer":               "Покупатель",
+	"document.subject":             "2. Предмет договора",
+	"document.product":             "Товар",
+	"document.price":               "Цена товара",
+	"document.period":              "Срок рассрочки",
+	"document.cost":                "3. Стоимость рассрочки",
+	"document.overpayment":         "Стоимость кредита",
+	"document.overpayment_percent": "от цены товара",
+	"document.total":               "Полная сумма к оплате",
+	"document.words":               "Прописью",
+	"document.schedule":            "4. График платежей",
+	"document.number":              "№",
+	"document.due_date":            "Дата платежа",
+	"document.amount":              "Сумма",
+	"document.schedule_total":      "Итого",
+	"document.terms":               "Покупатель вносит платежи не позднее дат, указанных в графике. Досрочное погашение допускается без дополнительной платы.",
+	"document.signatures":          "5. Подписи сторон",
+	"document.signature":           "подпись",
+
+	"delivery.queued":    "отложено",
+	"delivery.pending":   "ожидает",
+	"delivery.delivered": "доставлено",
+	"delivery.failed":    "не доставлено",
+	"delivery.expired":   "истекло",
+
+	"email.subject.purchase":     "Детали покупки в рассрочку",
+	"email.subject.reminder":     "Напоминание о платеже",
+	"email.subject.overdue":      "Просроченный платеж",
+	"email.subject.payoff":       "Рассрочка погашена",
+	"email.subject.cancellation": "Договор рассрочки отменен",
+	"email.subject.consent":      "Код подтверждения рассрочки",
+
+	"customers.usage":     "использование: customers set --phone НОМЕР [--email АДРЕС] [--messenger ID] [--channels sms,email,messenger] | customers show --phone НОМЕР",
+	"customers.phone":     "Телефон: %s",
+	"customers.email":     "Эл. почта: %s",
+	"customers.messenger": "Мессенджер: %s",
+	"customers.channels":  "Каналы уведомлений: %s",
+
+	"sms.usage":     "использование: sms preview --event СОБЫТИЕ [--templates КАТАЛОГ] | sms opt-out|opt-in --phone НОМЕР | sms flush",
+	"sms.analysis":  "[%s, символов: %d, сегментов: %d, осталось в последнем: %d]",
+	"sms.flushed":   "Отправлено отложенных смс: %d",
+	"sms.opted_out": "Клиент %s отказался от необязательных сообщений",
+	"sms.opted_in":  "Клиент %s снова получает все сообщения",
+	"sms.compacted": "После сокращения до %d сегм.:",
+
+	"webhooks.usage":       "использование: webhooks test [--name ИМЯ] | webhooks retry | webhooks pending | webhooks dead",
+	"webhooks.retried":     "Доставлено: %d, в очереди: %d, не доставлено: %d",
+	"webhooks.no_pending":  "Вебхуков в очереди нет",
+	"webhooks.none":        "Вебхуки не настроены",
+	"webhooks.ok":          "%s: доставлено",
+	"webhooks.failed":      "%s: ошибка: %s",
+	"webhooks.no_dead":     "Недоставленных вебхуков нет",
+	"webhooks.test_failed": "не все вебхуки приняли тестовое событие",
+
+	"command.calc":       "рассчитать рассрочку и оформить договор (по умолчанию)",
+	"command.quote":      "рассчитать рассрочку без договора и смс",
+	"command.batch":      "рассчитать рассрочку для списка товаров из CSV",
+	"command.contracts":  "показать или распечатать договор, недоставленные уведомления",
+	"command.pay":        "принять платеж по договору",
+	"command.cancel":     "отменить договор при возврате товара",
+	"command.remind":     "отправить напоминания о платежах",
+	"command.customers":  "контакты и каналы уведомлений клиента",
+	"command.sms":        "предпросмотр шаблонов, отказ от рассылки, отложенные смс",
+	"command.webhooks":   "проверить вебхуки и показать недоставленные",
+	"command.serve":      "запустить HTTP API",
+	"command.config":     "показать текущие настройки",
+	"command.shell":      "сеанс кассира: расчеты без перезапуска программы",
+	"command.completion": "скрипт автодополнения для bash, zsh или fish",
+	"command.help":       "справка по командам",
+	"app.help_hint":      "Справка: %s help",
+	"app.flags":          "Использование: %s %s [ПАРАМЕТРЫ]",
+
+	"flag.long_form":      "%s (длинная форма)",
+	"flag.interactive":    "Интерактивный режим",
+	"flag.product":        "Тип товара (%s, %s, %s)",
+	"flag.price":          "Цена товара, например 1500 или \"1 500,50\"",
+	"flag.phone":          "Номер телефона",
+	"flag.months":         "Срок рассрочки",
+	"flag.answers":        "YAML-файл с ответами для интерактивного режима",
+	"flag.tui":            "Полноэкранная форма вместо пошаговых вопросов",
+	"flag.sms_lang":       "Язык смс для клиента (ru, tg, en)",
+	"flag.consent":        "Подтвердить согласие клиента кодом из смс",
+	"flag.customer_phone": "Номер телефона клиента",
+	"flag.event":          "Событие: %s",
+	"flag.templates":      "Каталог с шаблонами",
+	"flag.sms_text_lang":  "Язык смс (ru, tg, en)",
+	"flag.max_segments":   "Сократить текст до указанного числа сегментов",
+	"flag.transliterate":  "Разрешить транслитерацию при сокращении",
+	"flag.contract":       "Номер договора",
+	"flag.reason":         "Причина отмены",
+	"flag.amount":         "Сумма платежа в сомони",
+	"flag.days":           "За сколько дней до платежа напоминать",
+	"flag.overdue":        "Через сколько дней просрочки напоминать",
+	"flag.format":         "Формат: html, pdf или md",
+	"flag.output":         "Файл для сохранения (по умолчанию стандартный вывод)",
+	"flag.older_than":     "Сколько ждать отчета о доставке",
+	"flag.batch_file":     "CSV-файл с колонками product,price,phone,months (- для стандартного ввода)",
+	"flag.email":          "Адрес электронной почты",
+	"flag.messenger":      "Идентификатор в мессенджере",
+	"flag.channels":       "Каналы уведомлений через запятую: sms, email, messenger",
+	"flag.webhook_name":   "Имя вебхука (по умолчанию все)",
+	"flag.addr":           "Адрес HTTP API",
+
+	"completion.usage": "использование: completion bash|zsh|fish",
+
+	"batch.failed":   "не удалось рассчитать строк: %d из %d",
+	"config.env":     "из окружения",
+	"config.default": "по умолчанию",
+
+	"shell.welcome":         "Сеанс кассира. help - список команд, exit - выход",
+	"shell.quoted":          "confirm - оформить договор, quote - изменить расчет, new - следующий клиент",
+	"shell.history_empty":   "В этом сеансе еще не было расчетов",
+	"shell.history_row":     "%s, %s на %d мес.: %s %s, телефон %s",
+	"shell.command.new":     "новый клиент: ввести данные и рассчитать",
+	"shell.command.quote":   "изменить текущий расчет или рассчитать по параметрам quote",
+	"shell.command.confirm": "оформить договор по текущему расчету [--consent] [--sms-lang ЯЗЫК]",
+	"shell.command.history": "расчеты этого сеанса",
+	"shell.command.repeat":  "повторить расчет [НОМЕР] из истории, по умолчанию последний",
+	"shell.command.help":    "эта справка, help КОМАНДА - справка по команде",
+	"shell.command.exit":    "завершить сеанс",
+	"shell.help_footer":     "Остальные команды программы (pay, cancel, contracts и другие) тоже доступны. Tab дополняет команды, товары и сроки",
+
+	"error.prefix":             "Ошибка: ",
+	"error.calculation":        "ошибка при расчете рассрочки",
+	"error.cancel":             "ошибка при отмене рассрочки",
+	"error.pay":                "ошибка при приеме платежа",
+	"error.empty_field":        "поле не может быть пустым",
+	"error.not_a_number":       "введите корректное число",
+	"error.product_choice":     "выберите 1, 2 или 3, либо введите название товара",
+	"error.product_names":      "неверный тип товара. Допустимые значения: %s, %s, %s",
+	"error.product_flag":       "неверный тип товара: %s. Допустимые значения: 1/%s, 2/%s, 3/%s",
+	"error.unknown_product":    "неизвестный тип товара: %s",
+	"error.product_suggestion": "Возможно, вы имели в виду «%s»?",
+	"error.price_positive":     "цена должна быть положительным числом",
+	"error.price_negative":     "цена товара не может быть отрицательной",
+	"error.phone_format":       "неверный формат номера телефона. Используйте формат: +992XXXXXXXXX, 992XXXXXXXXX или 9XXXXXXXX",
+	"error.phone_operator":     "номер не принадлежит мобильному оператору. Проверьте введенный номер",
+	"error.phone_country":      "номера этой страны не принимаются",
+	"error.amount_format":      "неверный формат суммы, введите, например, 1500 или 1 500,50",
+	"error.amount_precision":   "в сумме не может быть больше двух знаков после запятой",
+	"error.period_bounds":      "срок рассрочки должен быть от %d до %d месяцев",
+	"error.period_max":         "для %s максимальный срок рассрочки %d месяцев",
+	"error.period_values":      "неверный срок рассрочки. Допустимые значения: %v",
+	"error.period_range":       "неверный срок рассрочки: для %s допустимый срок от %d до %d месяцев",
+	"error.months_negative":    "срок рассрочки не может быть отрицательным",
+	"error.days_negative":      "количество дней не может быть отрицательным",
+	"error.flags_required":     "все флаги обязательны в нон-интерактивном режиме или используйте интерактивный режим (-i/--interactive)",
+	"error.unknown_language":   "неизвестный язык: %s. Допустимые значения: ru, tg, en",
+	"error.env_value":          "неверное значение %s: %s",
+	"error.interrupted":        "операция прервана",
+	"error.timeout":            "превышено время ожидания",
+
+	"error.invalid_price":             "цена должна быть больше 0",
+	"error.missing_phone":             "необходимо указать номер телефона",
+	"error.invalid_product":           "неверный тип продукта",
+	"error.invalid_period":            "неверный срок рассрочки",
+	"error.contract_not_found":        "договор не найден",
+	"error.contract_not_active":       "договор не активен",
+	"error.empty_cancel_reason":       "необходимо указать причину отмены",
+	"error.return_window_expired":     "срок возврата товара истек",
+	"error.notification_not_found":    "сообщение не найдено",
+	"error.message_suppressed":        "клиент отказался от необязательных сообщений",
+	"error.send_limited":              "превышено число смс на номер, повторите позже",
+	"error.invalid_email":             "неверный адрес электронной почты",
+	"error.unknown_channel":           "неизвестный канал уведомлений. Допустимые значения: sms, email, messenger",
+	"error.no_channel":                "нет доступного канала уведомлений: укажите контакт клиента",
+	"error.invalid_payment":           "сумма платежа должна быть больше 0",
+	"error.overpayment":               "сумма платежа превышает остаток по договору",
+	"error.unknown_webhook":           "вебхук не найден",
+	"error.consent_code_format":       "код должен состоять из 6 цифр",
+	"error.consent_not_found":         "запрос подтверждения не найден",
+	"error.consent_expired":           "срок действия кода истек, оформите рассрочку заново",
+	"error.consent_code_mismatch":     "неверный код подтверждения",
+	"error.consent_attempts_exceeded": "превышено число попыток ввода кода, оформите рассрочку заново",
+	"error.document_format":           "неверный формат документа. Допустимые значения: html, pdf, md",
+	"error.dlr_token":                 "не задан INSTALLMENT_DLR_TOKEN: без общего секрета отчеты о доставке может прислать кто угодно",
+	"error.api_token":                 "не задан INSTALLMENT_API_TOKEN: без него оформить рассрочку и отправить код на любой номер может кто угодно",
+	"error.unknown_command":           "неизвестная команда %q",
+	"error.unexpected_argument":       "лишний аргумент %q",
+	"error.batch_open":                "не удалось открыть файл",
+	"error.batch_read":                "не удалось прочитать CSV",
+	"error.input_closed":              "ввод закончился, не получен ответ на вопрос «%s»",
+	"error.invalid_answer":            "неверный ответ на вопрос «%s»: %s",
+	"error.answers_open":              "не удалось открыть файл ответов",
+	"error.answers_parse":             "неверный файл ответов %s",
+	"error.review_choice":             "выберите номер поля от 1 до 4 или нажмите Enter",
+	"error.shell_nested":              "сеанс уже запущен",
+	"error.shell_no_quote":            "нет расчета для оформления, выполните new или quote",
+	"error.shell_no_history":          "в этом сеансе еще не было расчетов",
+	"error.shell_history_number":      "нет расчета %s в истории, доступны номера от 1 до %d",
+
+	"usage": `Использование: %[1]s [КОМАНДА] [ПАРАМЕТРЫ]
+
+Команды:
+`,
+	"usage.footer": `
+Без команды выполняется %[2]s, поэтому прежний вызов работает как раньше:
+  %[1]s -p Смартфон -c 1000 -n +992931234567 -m 6
+
+Справка по команде: %[1]s help КОМАНДА или %[1]s КОМАНДА -h
+Общий параметр --lang ЯЗЫК задает язык интерфейса (ru, tg, en), по умолчанию из LANG.
+Общий параметр --plain выводит результаты простыми строками без рамок и цвета.
+Коды выхода: 0 - успешно, 1 - ошибка выполнения, 2 - неверный вызов, 130 - прервано.
+`,
+	"calc.usage": `Использование: %[1]s [calc] [ПАРАМЕТРЫ]
+       %[1]s quote [ПАРАМЕТРЫ]
+
+calc рассчитывает рассрочку и оформляет договор, quote только показывает расчет.
+
+Параметры:
+  -h, --help             Показать эту справку
+  -i, --interactive      Включить интерактивный режим
+  -p, --product ТОВАР    Тип товара (Смартфон, Компьютер, Телевизор)
+  -c, --cost ЦЕНА       Цена товара в сомони, например 1500 или "1 500,50"
+  -n, --number НОМЕР    Номер телефона клиента
+  -m, --months МЕСЯЦЫ   Срок рассрочки в месяцах
+  --answers ФАЙЛ         Ответы на вопросы из YAML-файла (включает -i)
+  --tui                  Полноэкранная форма вместо вопросов (включает -i)
+  --sms-lang ЯЗЫК        Язык смс для клиента (ru, tg, en), только calc
+  --consent              Подтвердить согласие клиента кодом из смс, только calc
+
+Примеры:
+  %[1]s -p Смартфон -c 1000 -n +992931234567 -m 6
+  %[1]s calc --product=Компьютер --cost=2000 --number=+992931234567 --months=12
+  %[1]s quote -p Телевизор -c 3000 -n +992931234567 -m 12
+  %[1]s -i
+
+Для интерактивного режима можно указать часть параметров,
+а остальные ввести в диалоговом режиме:
+  %[1]s -p Телевизор -i
+
+Ответы можно заранее записать в файл, тогда диалог проходит без участия
+кассира и всегда одинаково:
+  %[1]s --answers ответы.yaml
+
+`,
+}
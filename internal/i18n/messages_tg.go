@@ -0,0 +1,322 @@
+package i18n
+
+var tajik = map[string]string{
+	"product.smartphone": "Смартфон",
+	"product.computer":   "Компютер",
+	"product.tv":         "Телевизор",
+	"currency":           "сомонӣ",
+	"money.group":        " ",
+	"money.decimal":      ",",
+
+	"prompt.product":      "Навъи молро интихоб кунед (1-%s, 2-%s, 3-%s)",
+	"prompt.price":        "Нархи молро ворид кунед (сомонӣ)",
+	"prompt.phone":        "Рақами телефонро ворид кунед (масалан, +992 93 123 45 67)",
+	"prompt.period":       "Мӯҳлати насияро интихоб кунед (дастрас: %s)",
+	"prompt.consent_code": "Рамзи аз SMS-и мизоҷро ворид кунед",
+	"prompt.back":         "ба қафо",
+	"prompt.restart":      "аз нав",
+	"prompt.navigation":   "Фармонҳо: ба қафо - саволи қаблӣ, аз нав - аз аввал сар кардан, ? - маслиҳат",
+
+	"help.product":      "Навъи мол: рақам аз рӯйхат ё ном. Аз мол фоиз ва мӯҳлати ниҳоӣ вобаста аст",
+	"help.price":        "Нархи мол бо сомонӣ, масалан 1500 ё 1499.90",
+	"help.phone":        "Рақами мобилии мизоҷ: +992XXXXXXXXX, 992XXXXXXXXX ё 9 рақам бе рамзи кишвар. Фосила ва дефис иҷозат аст",
+	"help.months":       "Мӯҳлати насия бо моҳ аз рӯйхат. Смартфон то 9, компютер то 12, телевизор то 18 моҳ",
+	"help.consent_code": "Рамзи шашрақама аз SMS-е, ки мизоҷ гирифт",
+	"help.review":       "Enter - ҳисоб кардан, рақами майдон - тағйир додани он, ба қафо - ба саволи охирин, аз нав - аз аввал сар кардан",
+
+	"review.title":   "Маълумотро санҷед:",
+	"review.product": "Мол",
+	"review.price":   "Нарх",
+	"review.phone":   "Телефон",
+	"review.months":  "Мӯҳлат, моҳ",
+	"review.prompt":  "Enter - ҳисоб кардан, 1-4 - тағйир додани майдон",
+
+	"form.periods":         "дастрас: %s",
+	"form.monthly":         "Ҳар моҳ:",
+	"form.schedule":        "Ҷадвали пардохтҳо:",
+	"form.more":            "... ва боз %d",
+	"form.keys":            "Tab/↑↓ - майдон, ←→ - интихоб, Enter - минбаъд, Esc/Ctrl+C - бекор",
+	"form.submit_contract": "Бастан ва фиристодани SMS",
+	"form.submit_quote":    "Ҳисоб кардан",
+	"form.unsupported":     "Терминал шакли пурраэкранро дастгирӣ намекунад, вориди қадам ба қадам истифода мешавад",
+
+	"result.title":       "НАСИЯ",
+	"result.price":       "Нархи мол:",
+	"result.period":      "Мӯҳлат:",
+	"result.months":      "%d моҳ",
+	"result.total":       "Маблағи умумӣ:",
+	"result.overpayment": "Пардохти иловагӣ:",
+	"result.contract_id": "Рақами шартнома: %s",
+
+	"consent.sent": "Рамзи тасдиқ ба %s фиристода шуд, то %s эътибор дорад",
+
+	"cancel.done":     "Шартномаи %s бекор карда шуд",
+	"cancel.reason":   "Сабаб: %s",
+	"cancel.refund":   "Баргардонидан: %s %s",
+	"cancel.required": "--contract ва --reason ҳатмӣ мебошанд",
+
+	"pay.done":     "Пардохти %s %s аз рӯи шартномаи %s қабул шуд",
+	"pay.balance":  "Бақия: %s %s",
+	"pay.paid_off": "Насия пурра пардохт шуд",
+	"pay.required": "--contract ва --amount ҳатмӣ мебошанд",
+
+	"remind.sent": "Хотиррасонҳо фиристода шуданд: %d",
+
+	"contracts.usage":            "истифода: contracts show --id РАҚАМ | contracts render --id РАҚАМ --format html|pdf|md [-o ФАЙЛ] | contracts undelivered [--older-than 24h]",
+	"contracts.id":               "Шартнома: %s",
+	"contracts.status":           "Ҳолат: %s",
+	"contracts.status.active":    "фаъол",
+	"contracts.status.cancelled": "бекоршуда",
+	"contracts.product":          "Мол: %s, телефон %s",
+	"contracts.total":            "Маблағи умумӣ: %s %s",
+	"contracts.balance":          "Бақия: %s %s",
+	"contracts.consent":          "Розигии мизоҷ: %s, телефон %s",
+	"contracts.notifications":    "Огоҳиномаҳо:",
+	"contracts.all_delivered":    "Ҳамаи огоҳиномаҳо расонида шуданд",
+	"contracts.saved":            "Шартнома дар %s нигоҳ дошта шуд",
+	"contracts.pdf_terminal":     "PDF-ро ба терминал баровардан мумкин нест, файлро нишон диҳед: -o %s.pdf",
+
+	"document.title":               "Шартномаи насия № %s",
+	"document.date":                "Сана",
+	"document.parties":             "1. Тарафҳо",
+	"document.seller":              "Фурӯшанда",
+	"document.address":             "Суроға",
+	"document.phone":               "Телефон",
+	"document.tax_id":              "РМА",
+	"document.buyer":               "Харидор",
+	"document.subject":             "2. Мавзӯи шартнома",
+	"document.product":             "Мол",
+	"document.price":               "Нархи мол",
+	"document.period":              "Мӯҳлати насия",
+	"document.cost":                "3. Арзиши насия",
+	"document.overpayment":         "Арзиши қарз",
+	"document.overpayment_percent": "аз нархи мол",
+	"document.total":               "Маблағи умумии пардохт",
+	"document.words":               "Бо ҳарф",
+	"document.schedule":            "4. Ҷадвали пардохтҳо",
+	"document.number":              "№",
+	"document.due_date":            "Санаи пардохт",
+	"document.amount":              "Маблағ",
+	"document.schedule_total":      "Ҳамагӣ",
+	"document.terms":               "Харидор пардохтҳоро на дертар аз санаҳои дар ҷадвал нишондодашуда ворид мекунад. Пардохти пеш аз мӯҳлат бе пардохти иловагӣ иҷозат дода мешавад.",
+	"document.signatures":          "5. Имзоҳои тарафҳо",
+	"document.signature":           "имзо",
+
+	"delivery.queued":    "мавқуф",
+	"delivery.pending":   "интизор",
+	"delivery.delivered": "расонида шуд",
+	"delivery.failed":    "расонида нашуд",
+	"delivery.expired":   "мӯҳлат гузашт",
+
+	"email.subject.purchase":     "Тафсилоти хариди насия",
+	"email.subject.reminder":     "Хотиррасонӣ дар бораи пардохт",
+	"email.subject.overdue":      "Пардохти мӯҳлаташ гузашта",
+	"email.subject.payoff":       "Насия пурра пардохт шуд",
+	"email.subject.cancellation": "Шартномаи насия бекор карда шуд",
+	"email.subject.consent":      "Рамзи тасдиқи насия",
+
+	"customers.usage":     "истифода: customers set --phone РАҚАМ [--email СУРОҒА] [--messenger ID] [--channels sms,email,messenger] | customers show --phone РАҚАМ",
+	"customers.phone":     "Телефон: %s",
+	"customers.email":     "Почтаи электронӣ: %s",
+	"customers.messenger": "Мессенҷер: %s",
+	"customers.channels":  "Каналҳои огоҳинома: %s",
+
+	"sms.usage":     "истифода: sms preview --event ҲОДИСА [--templates ФЕҲРИСТ] | sms opt-out|opt-in --phone РАҚАМ | sms flush",
+	"sms.analysis":  "[%s, аломатҳо: %d, қисмҳо: %d, дар охирин боқӣ: %d]",
+	"sms.flushed":   "SMS-ҳои мавқуфгузошта фиристода шуданд: %d",
+	"sms.opted_out": "Мизоҷ %s аз SMS-ҳои ихтиёрӣ даст кашид",
+	"sms.opted_in":  "Мизоҷ %s боз ҳамаи SMS-ҳоро мегирад",
+	"sms.compacted": "Пас аз кӯтоҳкунӣ то %d қисм:",
+
+	"webhooks.usage":       "истифода: webhooks test [--name НОМ] | webhooks retry | webhooks pending | webhooks dead",
+	"webhooks.retried":     "Расонида шуд: %d, дар навбат: %d, нарасонида: %d",
+	"webhooks.no_pending":  "Дар навбат вебхук нест",
+	"webhooks.none":        "Вебхукҳо танзим нашудаанд",
+	"webhooks.ok":          "%s: расонида шуд",
+	"webhooks.failed":      "%s: хато: %s",
+	"webhooks.no_dead":     "Вебхукҳои нарасонидашуда нестанд",
+	"webhooks.test_failed": "на ҳамаи вебхукҳо рӯйдоди санҷиширо қабул карданд",
+
+	"command.calc":       "ҳисоби насия ва бастани шартнома (бо пешфарз)",
+	"command.quote":      "ҳисоби насия бе шартнома ва SMS",
+	"command.batch":      "ҳисоби насия барои рӯйхати мол аз CSV",
+	"command.contracts":  "намоиш ё чопи шартнома, огоҳиномаҳои нарасида",
+	"command.pay":        "қабули пардохт аз рӯи шартнома",
+	"command.cancel":     "бекор кардани шартнома ҳангоми баргардонидани мол",
+	"command.remind":     "фиристодани хотиррасониҳо оид ба пардохт",
+	"command.customers":  "тамосҳо ва каналҳои огоҳии мизоҷ",
+	"command.sms":        "пешнамоиши қолабҳо, рад аз паёмҳо, SMS-ҳои мавқуф",
+	"command.webhooks":   "санҷиши вебхукҳо ва намоиши нарасидаҳо",
+	"command.serve":      "оғози HTTP API",
+	"command.config":     "намоиши танзимоти ҷорӣ",
+	"command.shell":      "ҷаласаи кассир: ҳисобҳо бе аз нав оғоз кардани барнома",
+	"command.completion": "скрипти пуркунии худкор барои bash, zsh ё fish",
+	"command.help":       "маълумот оид ба фармонҳо",
+	"app.help_hint":      "Маълумот: %s help",
+	"app.flags":          "Истифода: %s %s [ПАРАМЕТРҲО]",
+
+	"flag.long_form":      "%s (шакли пурра)",
+	"flag.interactive":    "Реҷаи интерактивӣ",
+	"flag.product":        "Навъи мол (%s, %s, %s)",
+	"flag.price":          "Нархи мол, масалан 1500 ё \"1 500,50\"",
+	"flag.phone":          "Рақами телефон",
+	"flag.months":         "Мӯҳлати насия",
+	"flag.answers":        "Файли YAML бо ҷавобҳо барои реҷаи интерактивӣ",
+	"flag.tui":            "Шакли пурраэкран ба ҷои саволҳои пайдарпай",
+	"flag.sms_lang":       "Забони SMS барои мизоҷ (ru, tg, en)",
+	"flag.consent":        "Тасдиқи розигии мизоҷ бо рамз аз SMS",
+	"flag.customer_phone": "Рақами телефони мизоҷ",
+	"flag.event":          "Ҳодиса: %s",
+	"flag.templates":      "Феҳристи қолабҳо",
+	"flag.sms_text_lang":  "Забони SMS (ru, tg, en)",
+	"flag.max_segments":   "Матнро то шумораи додашудаи сегментҳо кӯтоҳ кардан",
+	"flag.transliterate":  "Ҳангоми кӯтоҳкунӣ транслитератсияро иҷозат додан",
+	"flag.contract":       "Рақами шартнома",
+	"flag.reason":         "Сабаби бекоркунӣ",
+	"flag.amount":         "Маблағи пардохт бо сомонӣ",
+	"flag.days":           "Чанд рӯз пеш аз пардохт хотиррасон кардан",
+	"flag.overdue":        "Пас аз чанд рӯзи таъхир хотиррасон кардан",
+	"flag.format":         "Формат: html, pdf ё md",
+	"flag.output":         "Файл барои нигоҳдорӣ (бо пешфарз баромади стандартӣ)",
+	"flag.older_than":     "Чӣ қадар ҳисоботи расониданро интизор шудан",
+	"flag.batch_file":     "Файли CSV бо сутунҳои product,price,phone,months (- барои вуруди стандартӣ)",
+	"flag.email":          "Суроғаи почтаи электронӣ",
+	"flag.messenger":      "Идентификатор дар мессенҷер",
+	"flag.channels":       "Каналҳои огоҳинома бо вергул: sms, email, messenger",
+	"flag.webhook_name":   "Номи вебхук (бо пешфарз ҳама)",
+	"flag.addr":           "Суроғаи HTTP API",
+
+	"completion.usage": "истифода: completion bash|zsh|fish",
+
+	"batch.failed":   "ҳисоб кардани сатрҳо муяссар нашуд: %d аз %d",
+	"config.env":     "аз муҳит",
+	"config.default": "бо пешфарз",
+
+	"shell.welcome":         "Ҷаласаи кассир. help - рӯйхати фармонҳо, exit - баромад",
+	"shell.quoted":          "confirm - бастани шартнома, quote - тағйир додани ҳисоб, new - мизоҷи навбатӣ",
+	"shell.history_empty":   "Дар ин ҷаласа ҳанӯз ҳисоб набуд",
+	"shell.history_row":     "%s, %s ба %d моҳ: %s %s, телефон %s",
+	"shell.command.new":     "мизоҷи нав: ворид кардани маълумот ва ҳисоб",
+	"shell.command.quote":   "тағйир додани ҳисоби ҷорӣ ё ҳисоб бо параметрҳои quote",
+	"shell.command.confirm": "бастани шартнома аз рӯи ҳисоби ҷорӣ [--consent] [--sms-lang ЗАБОН]",
+	"shell.command.history": "ҳисобҳои ин ҷаласа",
+	"shell.command.repeat":  "такрори ҳисоб [РАҚАМ] аз таърих, бе рақам охирин",
+	"shell.command.help":    "ҳамин маълумот, help ФАРМОН - маълумот оид ба фармон",
+	"shell.command.exit":    "анҷоми ҷаласа",
+	"shell.help_footer":     "Дигар фармонҳои барнома (pay, cancel, contracts ва ғ.) низ дастрасанд. Tab фармонҳо, молҳо ва мӯҳлатҳоро пур мекунад",
+
+	"error.prefix":             "Хато: ",
+	"error.calculation":        "ҳисоби насия иҷро нашуд",
+	"error.cancel":             "бекор кардани насия иҷро нашуд",
+	"error.pay":                "қабули пардохт иҷро нашуд",
+	"error.empty_field":        "майдон холӣ буда наметавонад",
+	"error.not_a_number":       "рақами дурустро ворид кунед",
+	"error.product_choice":     "1, 2 ё 3-ро интихоб кунед ё номи молро нависед",
+	"error.product_names":      "навъи мол нодуруст аст. Қиматҳои иҷозатдодашуда: %s, %s, %s",
+	"error.product_flag":       "навъи мол нодуруст аст: %s. Қиматҳои иҷозатдодашуда: 1/%s, 2/%s, 3/%s",
+	"error.unknown_product":    "навъи моли номаълум: %s",
+	"error.product_suggestion": "Шояд шумо «%s»-ро дар назар доштед?",
+	"error.price_positive":     "нарх бояд рақами мусбат бошад",
+	"error.price_negative":     "нархи мол манфӣ буда наметавонад",
+	"error.phone_format":       "шакли рақами телефон нодуруст аст. Шаклҳои +992XXXXXXXXX, 992XXXXXXXXX ё 9XXXXXXXX-ро истифода баред",
+	"error.phone_operator":     "рақам ба оператори мобилӣ тааллуқ надорад. Рақамро санҷед",
+	"error.phone_country":      "рақамҳои ин кишвар қабул карда намешаванд",
+	"error.amount_format":      "формати маблағ нодуруст аст, масалан 1500 ё 1 500,50 ворид кунед",
+	"error.amount_precision":   "дар маблағ зиёда аз ду рақам пас аз вергул буда наметавонад",
+	"error.period_bounds":      "мӯҳлати насия бояд аз %d то %d моҳ бошад",
+	"error.period_max":         "барои %s мӯҳлати ниҳоии насия %d моҳ аст",
+	"error.period_values":      "мӯҳлати насия нодуруст аст. Қиматҳои иҷозатдодашуда: %v",
+	"error.period_range":       "мӯҳлати насия нодуруст аст: барои %s мӯҳлат аз %d то %d моҳ иҷозат дода мешавад",
+	"error.months_negative":    "мӯҳлати насия манфӣ буда наметавонад",
+	"error.days_negative":      "шумораи рӯзҳо манфӣ буда наметавонад",
+	"error.flags_required":     "дар реҷаи ғайриинтерактивӣ ҳамаи параметрҳо ҳатмӣ мебошанд, ё реҷаи интерактивиро истифода баред (-i/--interactive)",
+	"error.unknown_language":   "забони номаълум: %s. Қиматҳои иҷозатдодашуда: ru, tg, en",
+	"error.env_value":          "қимати нодурусти %s: %s",
+	"error.interrupted":        "амалиёт қатъ карда шуд",
+	"error.timeout":            "вақти интизорӣ гузашт",
+
+	"error.invalid_price":             "нарх бояд аз 0 зиёд бошад",
+	"error.missing_phone":             "рақами телефонро нишон додан зарур аст",
+	"error.invalid_product":           "навъи мол нодуруст аст",
+	"error.invalid_period":            "мӯҳлати насия нодуруст аст",
+	"error.contract_not_found":        "шартнома ёфт нашуд",
+	"error.contract_not_active":       "шартнома фаъол нест",
+	"error.empty_cancel_reason":       "сабаби бекоркуниро нишон додан зарур аст",
+	"error.return_window_expired":     "мӯҳлати баргардонидани мол гузаштааст",
+	"error.notification_not_found":    "паём ёфт нашуд",
+	"error.message_suppressed":        "мизоҷ аз паёмҳои ихтиёрӣ даст кашидааст",
+	"error.send_limited":              "шумораи смс ба рақам аз ҳад гузашт, баъдтар такрор кунед",
+	"error.invalid_email":             "суроғаи почтаи электронӣ нодуруст аст",
+	"error.unknown_channel":           "канали номаълуми огоҳинома. Қиматҳои иҷозатдодашуда: sms, email, messenger",
+	"error.no_channel":                "канали дастраси огоҳинома нест: тамоси мизоҷро нишон диҳед",
+	"error.invalid_payment":           "маблағи пардохт бояд аз 0 зиёд бошад",
+	"error.overpayment":               "маблағи пардохт аз бақияи шартнома зиёд аст",
+	"error.unknown_webhook":           "вебхук ёфт нашуд",
+	"error.consent_code_format":       "рамз бояд аз 6 рақам иборат бошад",
+	"error.consent_not_found":         "дархости тасдиқ ёфт нашуд",
+	"error.consent_expired":           "мӯҳлати рамз гузашт, насияро аз нав расмӣ кунед",
+	"error.consent_code_mismatch":     "рамзи тасдиқ нодуруст аст",
+	"error.consent_attempts_exceeded": "шумораи кӯшишҳо тамом шуд, насияро аз нав расмӣ кунед",
+	"error.document_format":           "формати нодурусти ҳуҷҷат. Қиматҳои иҷозатдодашуда: html, pdf, md",
+	"error.dlr_token":                 "INSTALLMENT_DLR_TOKEN муайян нашудааст: бе сирри умумӣ ҳисоботи расониданро ҳар кас фиристода метавонад",
+	"error.api_token":                 "INSTALLMENT_API_TOKEN муайян нашудааст: бе он ҳар кас метавонад насия расмӣ кунад ва ба ҳар рақам рамз фиристад",
+	"error.unknown_command":           "фармони номаълум %q",
+	"error.unexpected_argument":       "аргументи зиёдатӣ %q",
+	"error.batch_open":                "кушодани файл муяссар нашуд",
+	"error.batch_read":                "хондани CSV муяссар нашуд",
+	"error.input_closed":              "ворид анҷом ёфт, ба саволи «%s» ҷавоб гирифта нашуд",
+	"error.invalid_answer":            "ҷавоби нодуруст ба саволи «%s»: %s",
+	"error.answers_open":              "кушодани файли ҷавобҳо муяссар нашуд",
+	"error.answers_parse":             "файли ҷавобҳои нодуруст %s",
+	"error.review_choice":             "рақами майдонро аз 1 то 4 интихоб кунед ё Enter-ро пахш кунед",
+	"error.shell_nested":              "ҷаласа аллакай оғоз шудааст",
+	"error.shell_no_quote":            "ҳисоб барои бастан нест, new ё quote-ро иҷро кунед",
+	"error.shell_no_history":          "дар ин ҷаласа ҳанӯз ҳисоб набуд",
+	"error.shell_history_number":      "ҳисоби %s дар таърих нест, рақамҳо аз 1 то %d",
+
+	"usage": `Истифода: %[1]s [ФАРМОН] [ПАРАМЕТРҲО]
+
+Фармонҳо:
+`,
+	"usage.footer": `
+Бе фармон %[2]s иҷро мешавад, бинобар ин даъвати пешина мисли пештара кор мекунад:
+  %[1]s -p Смартфон -c 1000 -n +992931234567 -m 6
+
+Маълумот оид ба фармон: %[1]s help ФАРМОН ё %[1]s ФАРМОН -h
+Параметри умумии --lang ЗАБОН забони интерфейсро муайян мекунад (ru, tg, en), бо пешфарз аз LANG.
+Параметри умумии --plain натиҷаҳоро бо сатрҳои содда бе чорчӯба ва ранг нишон медиҳад.
+Рамзҳои баромад: 0 - бомуваффақият, 1 - хатои иҷро, 2 - даъвати нодуруст, 130 - қатъ шуд.
+`,
+	"calc.usage": `Истифода: %[1]s [calc] [ПАРАМЕТРҲО]
+       %[1]s quote [ПАРАМЕТРҲО]
+
+calc насияро ҳисоб карда шартнома мебандад, quote танҳо ҳисобро нишон медиҳад.
+
+Параметрҳо:
+  -h, --help             Намоиши ин маълумот
+  -i, --interactive      Реҷаи интерактивӣ
+  -p, --product МОЛ      Навъи мол (Смартфон, Компютер, Телевизор)
+  -c, --cost НАРХ        Нархи мол бо сомонӣ, масалан 1500 ё "1 500,50"
+  -n, --number РАҚАМ     Рақами телефони мизоҷ
+  -m, --months МОҲҲО     Мӯҳлати насия бо моҳ
+  --answers ФАЙЛ         Ҷавобҳо аз файли YAML (-i-ро дар бар мегирад)
+  --tui                  Шакли пурраэкран ба ҷои саволҳо (-i-ро дар бар мегирад)
+  --sms-lang ЗАБОН       Забони SMS барои мизоҷ (ru, tg, en), танҳо calc
+  --consent              Тасдиқи розигии мизоҷ бо рамз аз SMS, танҳо calc
+
+Мисолҳо:
+  %[1]s -p Смартфон -c 1000 -n +992931234567 -m 6
+  %[1]s calc --product=Компютер --cost=2000 --number=+992931234567 --months=12
+  %[1]s quote -p Телевизор -c 3000 -n +992931234567 -m 12
+  %[1]s -i
+
+Дар реҷаи интерактивӣ як қисми параметрҳоро метавон дар сатри фармон
+нишон дод, боқимондаашро дар муколама ворид кард:
+  %[1]s -p Телевизор -i
+
+Ҷавобҳоро пешакӣ дар файл навиштан мумкин аст, он гоҳ муколама бе иштироки
+кассир ва ҳамеша якхела мегузарад:
+  %[1]s --answers javobho.yaml
+
+`,
+}
@@ -0,0 +1,201 @@
+package i18n
+
+import (
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+)
+
+// productAliases are the names a product is known by besides the catalog
+// names, in Russian, Tajik and English. They are compared after
+// normalizeProductName, so case, ё and the Tajik letters do not matter.
+var productAliases = map[domain.ProductType][]string{
+	domain.Smartphone: {
+		"смартфоны", "телефон", "телефоны", "мобильный", "мобильник", "сотовый",
+		"телефони мобилӣ", "смартфонҳо", "телефонҳо",
+		"smartphones", "phone", "phones", "mobile", "cellphone",
+	},
+	domain.Computer: {
+		"компьютеры", "ноутбук", "ноутбуки", "ноут", "лэптоп", "пк",
+		"компютерҳо", "ноутбукҳо",
+		"computers", "laptop", "laptops", "notebook", "pc",
+	},
+	domain.TV: {
+		"телевизоры", "тв", "телик",
+		"телевизорҳо",
+		"television", "telly",
+	},
+}
+
+var productTypes = []domain.ProductType{domain.Smartphone, domain.Computer, domain.TV}
+
+// ParseProduct resolves a product name written in any supported language,
+// by an alias such as "ноутбук" or "phone", with Latin lookalike letters or
+// with one typo in a name of five letters or more.
+func ParseProduct(name string) (domain.ProductType, bool) {
+	productType, distance, ok := closestProduct(name)
+	if !ok {
+		return "", false
+	}
+
+	length := len([]rune(normalizeProductName(name)))
+	if distance == 0 || distance == 1 && length >= 5 {
+		return productType, true
+	}
+	return "", false
+}
+
+// SuggestProduct returns the product a name that ParseProduct does not
+// accept most likely means, for a "did you mean" hint.
+func SuggestProduct(name string) (domain.ProductType, bool) {
+	productType, distance, ok := closestProduct(name)
+	if !ok {
+		return "", false
+	}
+
+	length := len([]rune(normalizeProductName(name)))
+	if distance <= max(1, min(3, length/3)) {
+		return productType, true
+	}
+	return "", false
+}
+
+// closestProduct returns the product with the name nearest to name. It fails
+// when name is empty or two products are equally near.
+func closestProduct(name string) (domain.ProductType, int, bool) {
+	input := normalizeProductName(name)
+	if input == "" {
+		return "", 0, false
+	}
+
+	var best domain.ProductType
+	bestDistance, tie := -1, false
+	for _, productType := range productTypes {
+		distance := -1
+		for _, candidate := range productNames(productType) {
+			d := editDistance(input, normalizeProductName(candidate))
+			if distance < 0 || d < distance {
+				distance = d
+			}
+		}
+
+		switch {
+		case bestDistance < 0 || distance < bestDistance:
+			best, bestDistance, tie = productType, distance, false
+		case distance == bestDistance:
+			tie = true
+		}
+	}
+
+	if tie {
+		return "", 0, false
+	}
+	return best, bestDistance, true
+}
+
+func productNames(productType domain.ProductType) []string {
+	names := make([]string, 0, len(Languages)+len(productAliases[productType]))
+	for _, lang := range Languages {
+		names = append(names, New(lang).Product(productType))
+	}
+	return append(names, productAliases[productType]...)
+}
+
+// tajikLetters fold the Tajik letters without a decomposition to the
+// Russian letters staff type instead of them.
+var tajikLetters = map[rune]rune{'ҳ': 'х', 'қ': 'к', 'ғ': 'г', 'ҷ': 'ч'}
+
+// homoglyphs map Latin letters to the Cyrillic letters they look like.
+var homoglyphs = map[rune]rune{
+	'a': 'а', 'b': 'в', 'c': 'с', 'e': 'е', 'h': 'н', 'k': 'к', 'm': 'м',
+	'o': 'о', 'p': 'р', 't': 'т', 'x': 'х', 'y': 'у',
+}
+
+// normalizeProductName folds name for comparison: compatibility forms and
+// diacritics are removed (ё is е, ӣ is и), letters are lowercased, spaces and
+// punctuation are dropped, and in a word mixing Latin and Cyrillic letters
+// the lookalikes are turned into the script of the majority.
+func normalizeProductName(name string) string {
+	var letters []rune
+	var latin, cyrillic int
+	for _, r := range norm.NFKD.String(name) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			continue
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+		default:
+			continue
+		}
+
+		r = unicode.ToLower(r)
+		if folded, ok := tajikLetters[r]; ok {
+			r = folded
+		}
+		switch {
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+		}
+		letters = append(letters, r)
+	}
+
+	if latin > 0 && cyrillic > 0 {
+		for i, r := range letters {
+			letters[i] = foldHomoglyph(r, latin < cyrillic)
+		}
+	}
+	return string(letters)
+}
+
+func foldHomoglyph(r rune, toCyrillic bool) rune {
+	for l, c := range homoglyphs {
+		switch {
+		case toCyrillic && r == l:
+			return c
+		case !toCyrillic && r == c:
+			return l
+		}
+	}
+	return r
+}
+
+// editDistance is the number of insertions, deletions, substitutions and
+// swaps of adjacent letters that turn a into b.
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	rows := make([][]int, len(s)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(t)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+	return rows[len(s)][len(t)]
+}
+
+// ProductSuggestion returns the "did you mean" hint for a product name that
+// is not recognized, or an empty string.
+func (c *Catalog) ProductSuggestion(name string) string {
+	productType, ok := SuggestProduct(name)
+	if !ok {
+		return ""
+	}
+	return c.T("error.product_suggestion", c.Product(productType))
+}
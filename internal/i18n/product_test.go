@@ -0,0 +1,75 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/i18n"
+)
+
+func TestParseProductAliases(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected domain.ProductType
+		ok       bool
+	}{
+		{name: "plural", input: "смартфоны", expected: domain.Smartphone, ok: true},
+		{name: "russian alias", input: "телефон", expected: domain.Smartphone, ok: true},
+		{name: "english alias", input: "phone", expected: domain.Smartphone, ok: true},
+		{name: "upper case", input: "TV", expected: domain.TV, ok: true},
+		{name: "laptop", input: "Ноутбук", expected: domain.Computer, ok: true},
+		{name: "tajik alias", input: "телефони мобилӣ", expected: domain.Smartphone, ok: true},
+		{name: "tajik letters typed as russian", input: "телевизорхо", expected: domain.TV, ok: true},
+		{name: "yo", input: "телёвизор", expected: domain.TV, ok: true},
+		{name: "latin lookalike", input: "Cмартфон", expected: domain.Smartphone, ok: true},
+		{name: "cyrillic lookalike in english", input: "рhone", expected: domain.Smartphone, ok: true},
+		{name: "decomposed letters", input: "мобильны\u0438\u0306", expected: domain.Smartphone, ok: true},
+		{name: "fullwidth letters", input: "ＴＶ", expected: domain.TV, ok: true},
+		{name: "punctuation", input: "т.в.", expected: domain.TV, ok: true},
+		{name: "one typo", input: "Компутер", expected: domain.Computer, ok: true},
+		{name: "swapped letters", input: "Телевиозр", expected: domain.TV, ok: true},
+		{name: "one typo in a short name", input: "пс", ok: false},
+		{name: "two typos", input: "Компьтр", ok: false},
+		{name: "unknown product", input: "холодильник", ok: false},
+		{name: "empty", input: "  ", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			productType, ok := i18n.ParseProduct(tt.input)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, productType)
+		})
+	}
+}
+
+func TestSuggestProduct(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected domain.ProductType
+		ok       bool
+	}{
+		{input: "Компьтр", expected: domain.Computer, ok: true},
+		{input: "Televisn", expected: domain.TV, ok: true},
+		{input: "пс", expected: domain.Computer, ok: true},
+		{input: "холодильник", ok: false},
+		{input: "принтер", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			productType, ok := i18n.SuggestProduct(tt.input)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, productType)
+		})
+	}
+}
+
+func TestCatalogProductSuggestion(t *testing.T) {
+	assert.Equal(t, "Возможно, вы имели в виду «Компьютер»?", i18n.New(i18n.Russian).ProductSuggestion("Компьтр"))
+	assert.Equal(t, `Did you mean "Computer"?`, i18n.New(i18n.English).ProductSuggestion("Компьтр"))
+	assert.Empty(t, i18n.New(i18n.Russian).ProductSuggestion("холодильник"))
+}
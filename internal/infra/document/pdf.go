@@ -0,0 +1,166 @@
+package document
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+
+	"github.com/jung-kurt/gofpdf"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/i18n"
+)
+
+// DejaVu Serif covers the Tajik letters, which the standard PDF fonts do
+// not. Only the glyphs used are embedded in the document.
+var (
+	//go:embed fonts/DejaVuSerif.ttf
+	regularFont []byte
+	//go:embed fonts/DejaVuSerif-Bold.ttf
+	boldFont []byte
+)
+
+const (
+	fontFamily = "DejaVuSerif"
+	margin     = 20.0
+	lineHeight = 6.0
+	labelWidth = 65.0
+)
+
+// pdfDocument lays out the agreement on A4 pages with the same sections as
+// the HTML template.
+type pdfDocument struct {
+	pdf     *gofpdf.Fpdf
+	catalog *i18n.Catalog
+	width   float64
+}
+
+func renderPDF(catalog *i18n.Catalog, data domain.AgreementData) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(margin, margin, margin)
+	pdf.SetAutoPageBreak(true, margin)
+	pdf.AddUTF8FontFromBytes(fontFamily, "", regularFont)
+	pdf.AddUTF8FontFromBytes(fontFamily, "B", boldFont)
+	pdf.SetTitle(catalog.T("document.title", data.ContractID), true)
+	// The same contract always gives the same file.
+	pdf.SetCreationDate(data.Date)
+	pdf.SetModificationDate(data.Date)
+	pdf.SetCatalogSort(true)
+	pdf.AddPage()
+
+	pageWidth, _ := pdf.GetPageSize()
+	d := &pdfDocument{pdf: pdf, catalog: catalog, width: pageWidth - 2*margin}
+	d.write(data)
+
+	var out bytes.Buffer
+	if err := pdf.Output(&out); err != nil {
+		return nil, fmt.Errorf("не удалось создать PDF: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+func (d *pdfDocument) write(data domain.AgreementData) {
+	t := d.catalog.T
+	currency := " " + t("currency")
+
+	d.pdf.SetFont(fontFamily, "B", 15)
+	d.pdf.MultiCell(d.width, 8, t("document.title", data.ContractID), "", "C", false)
+	d.pdf.SetFont(fontFamily, "", 11)
+	d.pdf.CellFormat(d.width, lineHeight, t("document.date")+": "+data.Date.Format("02.01.2006"), "", 1, "R", false, 0, "")
+
+	d.heading(t("document.parties"))
+	d.field(t("document.seller"), orBlank(data.Store.Name), true)
+	d.field(t("document.address"), orBlank(data.Store.Address), false)
+	d.field(t("document.phone"), orBlank(data.Store.Phone), false)
+	d.field(t("document.tax_id"), orBlank(data.Store.TaxID), false)
+	d.field(t("document.buyer"), t("document.phone")+": "+data.PhoneNumber, true)
+
+	d.heading(t("document.subject"))
+	d.field(t("document.product"), d.catalog.Product(data.Product), false)
+	d.field(t("document.price"), d.catalog.Money(data.Price)+currency, false)
+	d.field(t("document.period"), t("result.months", data.PeriodMonths), false)
+
+	d.heading(t("document.cost"))
+	d.field(t("document.overpayment"), fmt.Sprintf("%s%s (%s %s)", d.catalog.Money(data.Overpayment), currency,
+		formatPercent(d.catalog, data.OverpaymentPercent), t("document.overpayment_percent")), false)
+	d.field(t("document.total"), d.catalog.Money(data.TotalPayment)+currency, true)
+	d.field(t("document.words"), d.catalog.MoneyWords(data.TotalPayment), false)
+
+	d.heading(t("document.schedule"))
+	d.schedule(data)
+	d.pdf.Ln(2)
+	d.pdf.SetFont(fontFamily, "", 11)
+	d.pdf.MultiCell(d.width, lineHeight, t("document.terms"), "", "L", false)
+
+	d.heading(t("document.signatures"))
+	d.signatures()
+}
+
+func (d *pdfDocument) heading(text string) {
+	d.pdf.Ln(4)
+	d.pdf.SetFont(fontFamily, "B", 12)
+	d.pdf.MultiCell(d.width, 7, text, "", "L", false)
+	d.pdf.SetFont(fontFamily, "", 11)
+}
+
+// field prints a label and a value that wraps within its column.
+func (d *pdfDocument) field(label, value string, bold bool) {
+	style := ""
+	if bold {
+		style = "B"
+	}
+	d.pdf.SetFont(fontFamily, style, 11)
+	d.pdf.CellFormat(labelWidth, lineHeight, label, "", 0, "L", false, 0, "")
+	d.pdf.MultiCell(d.width-labelWidth, lineHeight, value, "", "L", false)
+	d.pdf.SetFont(fontFamily, "", 11)
+}
+
+func (d *pdfDocument) schedule(data domain.AgreementData) {
+	t := d.catalog.T
+	numberWidth, amountWidth := 15.0, 50.0
+	dateWidth := d.width - numberWidth - amountWidth
+
+	d.pdf.SetFont(fontFamily, "B", 11)
+	d.pdf.SetFillColor(230, 230, 230)
+	d.pdf.CellFormat(numberWidth, 7, t("document.number"), "1", 0, "C", true, 0, "")
+	d.pdf.CellFormat(dateWidth, 7, t("document.due_date"), "1", 0, "L", true, 0, "")
+	d.pdf.CellFormat(amountWidth, 7, t("document.amount")+", "+t("currency"), "1", 1, "R", true, 0, "")
+
+	d.pdf.SetFont(fontFamily, "", 11)
+	for _, installment := range data.Schedule {
+		d.pdf.CellFormat(numberWidth, 7, fmt.Sprint(installment.Number), "1", 0, "C", false, 0, "")
+		d.pdf.CellFormat(dateWidth, 7, installment.DueDate.Format("02.01.2006"), "1", 0, "L", false, 0, "")
+		d.pdf.CellFormat(amountWidth, 7, d.catalog.Money(installment.Amount), "1", 1, "R", false, 0, "")
+	}
+
+	d.pdf.SetFont(fontFamily, "B", 11)
+	d.pdf.CellFormat(numberWidth+dateWidth, 7, t("document.schedule_total"), "1", 0, "L", false, 0, "")
+	d.pdf.CellFormat(amountWidth, 7, d.catalog.Money(data.TotalPayment), "1", 1, "R", false, 0, "")
+	d.pdf.SetFont(fontFamily, "", 11)
+}
+
+// signatures draws a signature line for each party side by side, moving to a
+// new page rather than splitting them.
+func (d *pdfDocument) signatures() {
+	const height = 30.0
+	_, pageHeight := d.pdf.GetPageSize()
+	if d.pdf.GetY()+height > pageHeight-margin {
+		d.pdf.AddPage()
+	}
+
+	columnWidth := d.width / 2
+	top := d.pdf.GetY() + 2
+	for i, party := range []string{d.catalog.T("document.seller"), d.catalog.T("document.buyer")} {
+		x := margin + float64(i)*columnWidth
+		lineY := top + 18
+
+		d.pdf.SetXY(x, top)
+		d.pdf.SetFont(fontFamily, "", 11)
+		d.pdf.CellFormat(columnWidth, lineHeight, party, "", 0, "L", false, 0, "")
+		d.pdf.Line(x, lineY, x+columnWidth-10, lineY)
+		d.pdf.SetXY(x, lineY+1)
+		d.pdf.SetFont(fontFamily, "", 8)
+		d.pdf.CellFormat(columnWidth-10, 4, d.catalog.T("document.signature"), "", 0, "C", false, 0, "")
+	}
+	d.pdf.SetXY(margin, top+height)
+}
@@ -0,0 +1,177 @@
+// Package document renders printable installment agreements as HTML,
+// Markdown and PDF.
+package document
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/i18n"
+)
+
+//go:embed templates
+var defaultTemplates embed.FS
+
+// blank is printed for store details that are not configured, so that they
+// can be filled in by hand.
+const blank = "________________________"
+
+type executor interface {
+	Execute(w io.Writer, data any) error
+	Name() string
+}
+
+type templateKey struct {
+	lang   i18n.Language
+	format domain.DocumentFormat
+}
+
+// Renderer renders agreements from one template per format, with the labels
+// taken from the catalog of the requested language. The HTML and Markdown
+// templates can be overridden by documents/contract.html.tmpl and
+// documents/contract.md.tmpl in the templates directory; the PDF is laid out
+// in code with an embedded font, so it needs nothing installed.
+type Renderer struct {
+	templates map[templateKey]executor
+}
+
+// NewRenderer loads the templates and renders each one with sample data, so
+// a broken template is reported at startup rather than at sale time.
+func NewRenderer(dir string) (*Renderer, error) {
+	r := &Renderer{templates: make(map[templateKey]executor)}
+
+	for _, lang := range i18n.Languages {
+		funcs := templateFuncs(i18n.New(lang))
+
+		for _, format := range []domain.DocumentFormat{domain.FormatHTML, domain.FormatMarkdown} {
+			name := path.Join("documents", "contract."+string(format)+".tmpl")
+
+			text, err := loadTemplate(dir, name)
+			if err != nil {
+				return nil, err
+			}
+
+			var tmpl executor
+			if format == domain.FormatHTML {
+				tmpl, err = htmltemplate.New(name).Funcs(funcs).Parse(text)
+			} else {
+				tmpl, err = texttemplate.New(name).Funcs(funcs).Parse(text)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("ошибка в шаблоне %s: %w", name, err)
+			}
+			r.templates[templateKey{lang: lang, format: format}] = tmpl
+
+			if _, err := r.Render(string(lang), format, SampleAgreementData()); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return r, nil
+}
+
+func (r *Renderer) Render(language string, format domain.DocumentFormat, data domain.AgreementData) ([]byte, error) {
+	lang, ok := i18n.ParseLanguage(language)
+	if !ok {
+		lang = i18n.DefaultLanguage
+	}
+
+	if format == domain.FormatPDF {
+		return renderPDF(i18n.New(lang), data)
+	}
+
+	tmpl, ok := r.templates[templateKey{lang: lang, format: format}]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidDocumentFormat, format)
+	}
+
+	var document bytes.Buffer
+	if err := tmpl.Execute(&document, data); err != nil {
+		return nil, fmt.Errorf("ошибка в шаблоне %s: %w", tmpl.Name(), err)
+	}
+	return document.Bytes(), nil
+}
+
+func templateFuncs(catalog *i18n.Catalog) map[string]any {
+	return map[string]any{
+		"t":     catalog.T,
+		"lang":  func() string { return string(catalog.Language()) },
+		"money": catalog.Money,
+		"words": catalog.MoneyWords,
+		"date": func(t time.Time) string {
+			return t.Format("02.01.2006")
+		},
+		"product": func(productType domain.ProductType) string {
+			return catalog.Product(productType)
+		},
+		"percent": func(value float64) string {
+			return formatPercent(catalog, value)
+		},
+		"orBlank": orBlank,
+	}
+}
+
+// formatPercent writes a percentage with at most one decimal, e.g. "5,7%".
+func formatPercent(catalog *i18n.Catalog, value float64) string {
+	text := strconv.FormatFloat(math.Round(value*10)/10, 'f', -1, 64)
+	return strings.Replace(text, ".", catalog.T("money.decimal"), 1) + "%"
+}
+
+func orBlank(value string) string {
+	if strings.TrimSpace(value) == "" {
+		return blank
+	}
+	return value
+}
+
+// SampleAgreementData is used to check templates at startup.
+func SampleAgreementData() domain.AgreementData {
+	product := domain.Product{
+		Type:         domain.Smartphone,
+		Price:        1000,
+		PhoneNumber:  "+992900123456",
+		PeriodMonths: 9,
+	}
+	contract := domain.NewContract("20250101-120000", product, 1060,
+		time.Date(2025, time.January, 1, 12, 0, 0, 0, time.Local))
+	return domain.NewAgreementData(contract, domain.Store{
+		Name:    "ООО «Пример»",
+		Address: "г. Душанбе, пр. Рудаки, 1",
+		Phone:   "+992372000000",
+		TaxID:   "010000000",
+	})
+}
+
+func loadTemplate(dir, name string) (string, error) {
+	if dir != "" {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err == nil {
+			return string(data), nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("не удалось прочитать шаблон %s: %w", name, err)
+		}
+	}
+
+	data, err := defaultTemplates.ReadFile(path.Join("templates", path.Base(name)))
+	if err != nil {
+		return "", fmt.Errorf("нет встроенного шаблона %s", name)
+	}
+	return string(data), nil
+}
+
+var _ domain.AgreementRenderer = (*Renderer)(nil)
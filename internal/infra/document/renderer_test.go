@@ -0,0 +1,175 @@
+package document_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/infra/document"
+)
+
+func TestRenderer_Formats(t *testing.T) {
+	renderer, err := document.NewRenderer("")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		language string
+		format   domain.DocumentFormat
+		expected []string
+	}{
+		{
+			name:     "HTML in Russian",
+			language: "ru",
+			format:   domain.FormatHTML,
+			expected: []string{
+				"<title>Договор рассрочки № 20250101-120000</title>",
+				"ООО «Пример»",
+				"Телефон: &#43;992900123456",
+				"<td>Смартфон</td>",
+				"<td>9 мес.</td>",
+				"60,00 сомони (6% от цены товара)",
+				"одна тысяча шестьдесят сомони 00 дирам",
+				`<tr><td>9</td><td>01.10.2025</td><td class="amount">117,84</td></tr>`,
+				"Подписи сторон",
+			},
+		},
+		{
+			name:     "Markdown in Tajik",
+			language: "tg",
+			format:   domain.FormatMarkdown,
+			expected: []string{
+				"# Шартномаи насия № 20250101-120000",
+				"- Мол: Смартфон",
+				"- Бо ҳарф: як ҳазору шаст сомонӣ 00 дирам",
+				"| 1 | 01.02.2025 | 117,77 |",
+				"| | **Ҳамагӣ** | **1 060,00** |",
+				"Харидор: ______________________ (имзо)",
+			},
+		},
+		{
+			name:     "Unknown language falls back to Russian",
+			language: "fr",
+			format:   domain.FormatMarkdown,
+			expected: []string{"# Договор рассрочки № 20250101-120000"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered, err := renderer.Render(tt.language, tt.format, document.SampleAgreementData())
+			require.NoError(t, err)
+
+			for _, expected := range tt.expected {
+				assert.Contains(t, string(rendered), expected)
+			}
+		})
+	}
+}
+
+func TestRenderer_EscapesHTML(t *testing.T) {
+	renderer, err := document.NewRenderer("")
+	require.NoError(t, err)
+
+	data := document.SampleAgreementData()
+	data.Store.Name = `<script>alert("x")</script>`
+
+	html, err := renderer.Render("ru", domain.FormatHTML, data)
+	require.NoError(t, err)
+	assert.NotContains(t, string(html), "<script>")
+	assert.Contains(t, string(html), "&lt;script&gt;")
+}
+
+func TestRenderer_BlankStoreDetails(t *testing.T) {
+	renderer, err := document.NewRenderer("")
+	require.NoError(t, err)
+
+	data := document.SampleAgreementData()
+	data.Store = domain.Store{}
+
+	markdown, err := renderer.Render("en", domain.FormatMarkdown, data)
+	require.NoError(t, err)
+	assert.Contains(t, string(markdown), "- **Seller:** ________________________")
+	assert.Contains(t, string(markdown), "- Tax ID: ________________________")
+}
+
+func TestRenderer_PDF(t *testing.T) {
+	renderer, err := document.NewRenderer("")
+	require.NoError(t, err)
+
+	for _, language := range []string{"ru", "tg", "en"} {
+		t.Run(language, func(t *testing.T) {
+			pdf, err := renderer.Render(language, domain.FormatPDF, document.SampleAgreementData())
+			require.NoError(t, err)
+
+			assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF-")))
+			assert.True(t, bytes.HasSuffix(bytes.TrimSpace(pdf), []byte("%%EOF")))
+			// The font is subset to the glyphs used, not embedded whole.
+			assert.Less(t, len(pdf), 100_000)
+
+			again, err := renderer.Render(language, domain.FormatPDF, document.SampleAgreementData())
+			require.NoError(t, err)
+			assert.Equal(t, pdf, again)
+		})
+	}
+}
+
+func TestRenderer_Override(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, "documents/contract.md.tmpl",
+		"{{t \"document.title\" .ContractID}}: {{money .TotalPayment}} ({{words .TotalPayment}})\n")
+
+	renderer, err := document.NewRenderer(dir)
+	require.NoError(t, err)
+
+	markdown, err := renderer.Render("ru", domain.FormatMarkdown, document.SampleAgreementData())
+	require.NoError(t, err)
+	assert.Equal(t, "Договор рассрочки № 20250101-120000: 1 060,00 (одна тысяча шестьдесят сомони 00 дирам)\n",
+		string(markdown))
+
+	html, err := renderer.Render("ru", domain.FormatHTML, document.SampleAgreementData())
+	require.NoError(t, err)
+	assert.Contains(t, string(html), "<!DOCTYPE html>")
+}
+
+func TestRenderer_RejectsBrokenTemplates(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		errorMsg string
+	}{
+		{
+			name:     "Syntax error",
+			template: "{{.Price",
+			errorMsg: "ошибка в шаблоне documents/contract.html.tmpl",
+		},
+		{
+			name:     "Unknown field",
+			template: "{{.Customer}}",
+			errorMsg: "can't evaluate field Customer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeTemplate(t, dir, "documents/contract.html.tmpl", tt.template)
+
+			_, err := document.NewRenderer(dir)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorMsg)
+		})
+	}
+}
+
+func writeTemplate(t *testing.T, dir, name, text string) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(text), 0o644))
+}
@@ -0,0 +1,55 @@
+package email
+
+import (
+	_ "embed"
+	"html/template"
+	"strings"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/i18n"
+)
+
+// Email is a customer message rendered as a plain-text and an HTML part.
+type Email struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+//go:embed layout.html
+var layoutSource string
+
+var layout = template.Must(template.New("layout").Parse(layoutSource))
+
+// Render turns the text rendered from the event template into an email. The
+// HTML part keeps the paragraphs and line breaks of the text.
+func Render(message domain.Message) (Email, error) {
+	language, ok := i18n.ParseLanguage(message.Language)
+	if !ok {
+		language = i18n.DefaultLanguage
+	}
+	subject := i18n.New(language).T("email.subject." + string(message.Event))
+
+	var paragraphs [][]string
+	for _, block := range strings.Split(strings.ReplaceAll(message.Text, "\r\n", "\n"), "\n\n") {
+		if block = strings.TrimSpace(block); block != "" {
+			paragraphs = append(paragraphs, strings.Split(block, "\n"))
+		}
+	}
+
+	var html strings.Builder
+	err := layout.Execute(&html, struct {
+		Language   i18n.Language
+		Subject    string
+		Paragraphs [][]string
+	}{language, subject, paragraphs})
+	if err != nil {
+		return Email{}, err
+	}
+
+	return Email{
+		Subject: subject,
+		Text:    message.Text,
+		HTML:    html.String(),
+	}, nil
+}
@@ -0,0 +1,181 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+)
+
+type SMTPConfig struct {
+	Addr     string
+	From     string
+	Username string
+	Password string
+	// Timeout bounds the whole SMTP conversation, from dialing to QUIT.
+	Timeout time.Duration
+}
+
+// SMTPNotifier sends notifications as multipart emails. STARTTLS is used
+// whenever the server offers it.
+type SMTPNotifier struct {
+	config SMTPConfig
+	dialer net.Dialer
+}
+
+func NewSMTPNotifier(config SMTPConfig) *SMTPNotifier {
+	return &SMTPNotifier{config: config}
+}
+
+func (n *SMTPNotifier) Channel() domain.Channel {
+	return domain.ChannelEmail
+}
+
+func (n *SMTPNotifier) Notify(ctx context.Context, customer domain.Customer, message domain.Message) (domain.Receipt, error) {
+	rendered, err := Render(message)
+	if err != nil {
+		return domain.Receipt{}, err
+	}
+
+	messageID := newMessageID()
+	body, err := n.compose(customer.Email, messageID, rendered)
+	if err != nil {
+		return domain.Receipt{}, err
+	}
+
+	if err := n.send(ctx, customer.Email, body); err != nil {
+		return domain.Receipt{}, fmt.Errorf("не удалось отправить письмо: %w", err)
+	}
+
+	return domain.Receipt{
+		Channel:    domain.ChannelEmail,
+		MessageID:  messageID,
+		AcceptedAt: time.Now(),
+	}, nil
+}
+
+func (n *SMTPNotifier) send(ctx context.Context, to string, body []byte) error {
+	if n.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, n.config.Timeout)
+		defer cancel()
+	}
+
+	conn, err := n.dialer.DialContext(ctx, "tcp", n.config.Addr)
+	if err != nil {
+		return err
+	}
+	// Unblock the SMTP conversation when the caller gives up.
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	host, _, err := net.SplitHostPort(n.config.Addr)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+
+	if n.config.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", n.config.Username, n.config.Password, host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(n.config.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(body); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+func (n *SMTPNotifier) compose(to, messageID string, email Email) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+
+	header := func(key, value string) {
+		fmt.Fprintf(&body, "%s: %s\r\n", key, value)
+	}
+	header("From", n.config.From)
+	header("To", to)
+	header("Subject", mime.BEncoding.Encode("UTF-8", email.Subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("Message-ID", "<"+messageID+">")
+	header("MIME-Version", "1.0")
+	header("Content-Type", `multipart/alternative; boundary="`+parts.Boundary()+`"`)
+	body.WriteString("\r\n")
+
+	for _, part := range []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=UTF-8", email.Text},
+		{"text/html; charset=UTF-8", email.HTML},
+	} {
+		writer, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		encoder := quotedprintable.NewWriter(writer)
+		if _, err := encoder.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+	return body.Bytes(), nil
+}
+
+func newMessageID() string {
+	var id [12]byte
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:]) + "@installment-cli"
+}
+
+var _ domain.Notifier = (*SMTPNotifier)(nil)
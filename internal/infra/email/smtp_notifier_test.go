@@ -0,0 +1,215 @@
+package email_test
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/infra/email"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// smtpStandIn accepts a single message the way a local SMTP server would.
+type smtpStandIn struct {
+	listener net.Listener
+	from     string
+	to       string
+	data     chan string
+}
+
+func newSMTPStandIn(t *testing.T) *smtpStandIn {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	server := &smtpStandIn{listener: listener, data: make(chan string, 1)}
+	go server.serve()
+	return server
+}
+
+func (s *smtpStandIn) serve() {
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.TrimSpace(line))
+
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			s.from = strings.TrimSpace(line[len("MAIL FROM:"):])
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			s.to = strings.TrimSpace(line[len("RCPT TO:"):])
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(line)
+			}
+			s.data <- data.String()
+			reply("250 OK queued")
+		case command == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func TestSMTPNotifier_Notify(t *testing.T) {
+	server := newSMTPStandIn(t)
+	notifier := email.NewSMTPNotifier(email.SMTPConfig{
+		Addr: server.listener.Addr().String(),
+		From: "shop@example.tj",
+	})
+
+	receipt, err := notifier.Notify(context.Background(),
+		domain.Customer{PhoneNumber: "+992001002005", Email: "client@example.tj"},
+		domain.Message{
+			Event:    domain.EventPurchase,
+			Language: "ru",
+			Text:     "Уважаемый клиент!\nДетали вашей покупки:\nТовар: <Смартфон>",
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ChannelEmail, receipt.Channel)
+	assert.NotEmpty(t, receipt.MessageID)
+
+	assert.Equal(t, "<shop@example.tj>", server.from)
+	assert.Equal(t, "<client@example.tj>", server.to)
+
+	message, err := mail.ReadMessage(strings.NewReader(<-server.data))
+	require.NoError(t, err)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Детали покупки в рассрочку", subject)
+	assert.Equal(t, "<"+receipt.MessageID+">", message.Header.Get("Message-ID"))
+
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/alternative", mediaType)
+
+	parts := multipart.NewReader(message.Body, params["boundary"])
+	contents := make(map[string]string)
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+		mediaType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		contents[mediaType] = strings.ReplaceAll(string(body), "\r\n", "\n")
+	}
+
+	assert.Equal(t, "Уважаемый клиент!\nДетали вашей покупки:\nТовар: <Смартфон>", contents["text/plain"])
+	assert.Contains(t, contents["text/html"], "<p>Уважаемый клиент!<br>\nДетали вашей покупки:<br>\nТовар: &lt;Смартфон&gt;</p>")
+}
+
+func TestSMTPNotifier_ServerUnavailable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	listener.Close()
+
+	notifier := email.NewSMTPNotifier(email.SMTPConfig{Addr: addr, From: "shop@example.tj"})
+	_, err = notifier.Notify(context.Background(),
+		domain.Customer{Email: "client@example.tj"},
+		domain.Message{Event: domain.EventPurchase, Text: "text"},
+	)
+	assert.Error(t, err)
+}
+
+func TestSMTPNotifier_Timeout(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	// The server accepts the connection but never greets the client.
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			defer conn.Close()
+			time.Sleep(time.Second)
+		}
+	}()
+
+	notifier := email.NewSMTPNotifier(email.SMTPConfig{
+		Addr:    listener.Addr().String(),
+		From:    "shop@example.tj",
+		Timeout: 50 * time.Millisecond,
+	})
+
+	started := time.Now()
+	_, err = notifier.Notify(context.Background(),
+		domain.Customer{Email: "client@example.tj"},
+		domain.Message{Event: domain.EventPurchase, Text: "text"},
+	)
+	assert.Error(t, err)
+	assert.Less(t, time.Since(started), 500*time.Millisecond)
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name            string
+		message         domain.Message
+		expectedSubject string
+	}{
+		{
+			name:            "Tajik",
+			message:         domain.Message{Event: domain.EventReminder, Language: "tg", Text: "Матн"},
+			expectedSubject: "Хотиррасонӣ дар бораи пардохт",
+		},
+		{
+			name:            "Unknown language falls back to Russian",
+			message:         domain.Message{Event: domain.EventCancellation, Language: "", Text: "Текст"},
+			expectedSubject: "Договор рассрочки отменен",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered, err := email.Render(tt.message)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedSubject, rendered.Subject)
+			assert.Equal(t, tt.message.Text, rendered.Text)
+			assert.Contains(t, rendered.HTML, "<title>"+tt.expectedSubject+"</title>")
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package messenger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+)
+
+type webhookRequest struct {
+	Recipient string `json:"recipient"`
+	Event     string `json:"event"`
+	Language  string `json:"language"`
+	Text      string `json:"text"`
+}
+
+type webhookResponse struct {
+	MessageID string `json:"message_id"`
+}
+
+// WebhookNotifier hands notifications to a messenger bot through a webhook,
+// which delivers them to the customer's chat.
+type WebhookNotifier struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url, token string, timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		token:  token,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (n *WebhookNotifier) Channel() domain.Channel {
+	return domain.ChannelMessenger
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, customer domain.Customer, message domain.Message) (domain.Receipt, error) {
+	body, err := json.Marshal(webhookRequest{
+		Recipient: customer.MessengerID,
+		Event:     string(message.Event),
+		Language:  message.Language,
+		Text:      message.Text,
+	})
+	if err != nil {
+		return domain.Receipt{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return domain.Receipt{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.token != "" {
+		req.Header.Set("Authorization", "Bearer "+n.token)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return domain.Receipt{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return domain.Receipt{}, fmt.Errorf("мессенджер ответил %s", resp.Status)
+	}
+
+	var response webhookResponse
+	_ = json.NewDecoder(resp.Body).Decode(&response)
+
+	return domain.Receipt{
+		Channel:    domain.ChannelMessenger,
+		MessageID:  response.MessageID,
+		AcceptedAt: time.Now(),
+	}, nil
+}
+
+var _ domain.Notifier = (*WebhookNotifier)(nil)
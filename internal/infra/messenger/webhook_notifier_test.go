@@ -0,0 +1,60 @@
+package messenger_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/infra/messenger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookNotifier_Notify(t *testing.T) {
+	var request map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		_, _ = w.Write([]byte(`{"message_id": "tg-42"}`))
+	}))
+	defer server.Close()
+
+	receipt, err := messenger.NewWebhookNotifier(server.URL, "secret", time.Second).Notify(context.Background(),
+		domain.Customer{MessengerID: "@client"},
+		domain.Message{Event: domain.EventPurchase, Language: "tg", Text: "Матн"},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.Receipt{Channel: domain.ChannelMessenger, MessageID: "tg-42", AcceptedAt: receipt.AcceptedAt}, receipt)
+	assert.Equal(t, map[string]string{"recipient": "@client", "event": "purchase", "language": "tg", "text": "Матн"}, request)
+}
+
+func TestWebhookNotifier_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	_, err := messenger.NewWebhookNotifier(server.URL, "", 20*time.Millisecond).Notify(context.Background(),
+		domain.Customer{MessengerID: "@client"},
+		domain.Message{Event: domain.EventPurchase, Text: "text"},
+	)
+	assert.Error(t, err)
+}
+
+func TestWebhookNotifier_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	_, err := messenger.NewWebhookNotifier(server.URL, "", time.Second).Notify(context.Background(),
+		domain.Customer{MessengerID: "@client"},
+		domain.Message{Event: domain.EventPurchase, Text: "text"},
+	)
+	assert.ErrorContains(t, err, "502")
+}
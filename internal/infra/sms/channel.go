@@ -0,0 +1,32 @@
+package sms
+
+import (
+	"context"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+)
+
+// Channel delivers customer notifications as SMS through a sender chain.
+type Channel struct {
+	sender domain.SMSSender
+}
+
+func NewChannel(sender domain.SMSSender) *Channel {
+	return &Channel{sender: sender}
+}
+
+func (c *Channel) Channel() domain.Channel {
+	return domain.ChannelSMS
+}
+
+func (c *Channel) Notify(ctx context.Context, customer domain.Customer, message domain.Message) (domain.Receipt, error) {
+	receipt, err := c.sender.SendSMS(ctx, customer.PhoneNumber, message.Text)
+	if err != nil {
+		return domain.Receipt{}, err
+	}
+
+	receipt.Channel = domain.ChannelSMS
+	return receipt, nil
+}
+
+var _ domain.Notifier = (*Channel)(nil)
@@ -0,0 +1,61 @@
+package sms_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/infra/sms"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker_OpensAfterThreshold(t *testing.T) {
+	breaker := sms.NewCircuitBreaker(2, time.Hour)
+
+	assert.True(t, breaker.Allow())
+	breaker.Failure()
+	assert.Equal(t, sms.BreakerClosed, breaker.State())
+
+	assert.True(t, breaker.Allow())
+	breaker.Failure()
+	assert.Equal(t, sms.BreakerOpen, breaker.State())
+	assert.False(t, breaker.Allow())
+}
+
+func TestCircuitBreaker_SuccessResetsFailures(t *testing.T) {
+	breaker := sms.NewCircuitBreaker(2, time.Hour)
+
+	breaker.Failure()
+	breaker.Success()
+	breaker.Failure()
+
+	assert.Equal(t, sms.BreakerClosed, breaker.State())
+}
+
+func TestCircuitBreaker_HalfOpenProbe(t *testing.T) {
+	tests := []struct {
+		name     string
+		probeOK  bool
+		expected sms.BreakerState
+	}{
+		{name: "Successful probe closes the circuit", probeOK: true, expected: sms.BreakerClosed},
+		{name: "Failed probe opens it again", probeOK: false, expected: sms.BreakerOpen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breaker := sms.NewCircuitBreaker(1, 0)
+			breaker.Failure()
+
+			assert.True(t, breaker.Allow(), "probe must be let through after the open timeout")
+			assert.Equal(t, sms.BreakerHalfOpen, breaker.State())
+			assert.False(t, breaker.Allow(), "only one probe at a time")
+
+			if tt.probeOK {
+				breaker.Success()
+			} else {
+				breaker.Failure()
+			}
+			assert.Equal(t, tt.expected, breaker.State())
+		})
+	}
+}
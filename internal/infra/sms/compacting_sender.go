@@ -0,0 +1,39 @@
+package sms
+
+import (
+	"context"
+	"log"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+)
+
+// CompactingSender logs the encoding and segment count of every message and
+// applies the compaction policy before handing it to the next sender.
+type CompactingSender struct {
+	next   domain.SMSSender
+	policy CompactionPolicy
+	logger *log.Logger
+}
+
+func NewCompactingSender(next domain.SMSSender, policy CompactionPolicy, logger *log.Logger) *CompactingSender {
+	return &CompactingSender{
+		next:   next,
+		policy: policy,
+		logger: logger,
+	}
+}
+
+func (s *CompactingSender) SendSMS(ctx context.Context, phoneNumber string, message string) (domain.Receipt, error) {
+	compacted, analysis := s.policy.Compact(message)
+
+	s.logger.Printf("смс на %s: %s, сегментов: %d, осталось символов: %d",
+		domain.MaskPhoneNumber(phoneNumber), analysis.Encoding, analysis.Segments, analysis.Remaining)
+
+	if s.policy.Enabled() && analysis.Segments > s.policy.MaxSegments {
+		s.logger.Printf("смс на %s не удалось сократить до %d сегментов", domain.MaskPhoneNumber(phoneNumber), s.policy.MaxSegments)
+	}
+
+	return s.next.SendSMS(ctx, phoneNumber, compacted)
+}
+
+var _ domain.SMSSender = (*CompactingSender)(nil)
@@ -0,0 +1,26 @@
+package sms_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/icoder-new/installment-cli/internal/infra/sms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactingSender_MasksTheRecipientInLogs(t *testing.T) {
+	var logs bytes.Buffer
+	next := &stubSender{id: "next"}
+	sender := sms.NewCompactingSender(next, sms.CompactionPolicy{MaxSegments: 1}, log.New(&logs, "", 0))
+
+	_, err := sender.SendSMS(context.Background(), "+992931234567", strings.Repeat("длинный текст ", 20))
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, next.calls)
+	assert.Contains(t, logs.String(), "+992*****4567")
+	assert.NotContains(t, logs.String(), "+992931234567")
+}
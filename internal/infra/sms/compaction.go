@@ -0,0 +1,92 @@
+package sms
+
+import (
+	"strings"
+	"unicode"
+)
+
+// CompactionPolicy shortens messages that do not fit into MaxSegments. The
+// steps are applied in order of how much they change the text and stop as
+// soon as the message fits: whitespace cleanup, dropping the greeting line and
+// abbreviating the currency, and finally Latin transliteration, which allows
+// the cheaper GSM-7 encoding.
+type CompactionPolicy struct {
+	MaxSegments   int
+	Transliterate bool
+}
+
+func (p CompactionPolicy) Enabled() bool {
+	return p.MaxSegments > 0
+}
+
+func (p CompactionPolicy) Compact(message string) (string, Analysis) {
+	analysis := Analyze(message)
+	if !p.Enabled() || analysis.Segments <= p.MaxSegments {
+		return message, analysis
+	}
+
+	steps := []func(string) string{squashWhitespace, shorten}
+	if p.Transliterate {
+		steps = append(steps, transliterate)
+	}
+
+	for _, step := range steps {
+		message = step(message)
+		analysis = Analyze(message)
+		if analysis.Segments <= p.MaxSegments {
+			break
+		}
+	}
+
+	return message, analysis
+}
+
+func squashWhitespace(message string) string {
+	var lines []string
+	for _, line := range strings.Split(message, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+var abbreviations = strings.NewReplacer(
+	"сомонӣ", "TJS",
+	"сомони", "TJS",
+	"somoni", "TJS",
+)
+
+func shorten(message string) string {
+	lines := strings.Split(message, "\n")
+	if len(lines) > 1 && strings.HasSuffix(lines[0], "!") {
+		lines = lines[1:]
+	}
+	return abbreviations.Replace(strings.Join(lines, "\n"))
+}
+
+var transliterations = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "sch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	'ғ': "gh", 'ӣ': "i", 'қ': "q", 'ӯ': "u", 'ҳ': "h", 'ҷ': "j",
+	'№': "N",
+}
+
+func transliterate(message string) string {
+	var b strings.Builder
+	for _, r := range message {
+		latin, ok := transliterations[unicode.ToLower(r)]
+		switch {
+		case !ok:
+			b.WriteRune(r)
+		case unicode.IsUpper(r) && latin != "":
+			b.WriteString(strings.ToUpper(latin[:1]) + latin[1:])
+		default:
+			b.WriteString(latin)
+		}
+	}
+	return b.String()
+}
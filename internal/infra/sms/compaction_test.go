@@ -0,0 +1,86 @@
+package sms_test
+
+import (
+	"testing"
+
+	"github.com/icoder-new/installment-cli/internal/infra/sms"
+	"github.com/stretchr/testify/assert"
+)
+
+const purchaseMessage = "Уважаемый клиент!\n" +
+	"Детали вашей покупки:\n" +
+	"Товар: Смартфон\n" +
+	"Сумма: 1000.00 сомони\n" +
+	"Срок рассрочки: 9 мес.\n" +
+	"Переплата: 60.00 сомони\n" +
+	"Итого к оплате: 1060.00 сомони"
+
+func TestCompactionPolicy_Compact(t *testing.T) {
+	tests := []struct {
+		name             string
+		policy           sms.CompactionPolicy
+		expectedText     string
+		expectedSegments int
+		expectedEncoding sms.Encoding
+	}{
+		{
+			name:             "Disabled policy keeps the text",
+			policy:           sms.CompactionPolicy{},
+			expectedText:     purchaseMessage,
+			expectedSegments: 3,
+			expectedEncoding: sms.UCS2,
+		},
+		{
+			name:   "Shortening is enough for two segments",
+			policy: sms.CompactionPolicy{MaxSegments: 2},
+			expectedText: "Детали вашей покупки:\n" +
+				"Товар: Смартфон\n" +
+				"Сумма: 1000.00 TJS\n" +
+				"Срок рассрочки: 9 мес.\n" +
+				"Переплата: 60.00 TJS\n" +
+				"Итого к оплате: 1060.00 TJS",
+			expectedSegments: 2,
+			expectedEncoding: sms.UCS2,
+		},
+		{
+			name:   "Transliteration fits one GSM-7 segment",
+			policy: sms.CompactionPolicy{MaxSegments: 1, Transliterate: true},
+			expectedText: "Detali vashey pokupki:\n" +
+				"Tovar: Smartfon\n" +
+				"Summa: 1000.00 TJS\n" +
+				"Srok rassrochki: 9 mes.\n" +
+				"Pereplata: 60.00 TJS\n" +
+				"Itogo k oplate: 1060.00 TJS",
+			expectedSegments: 1,
+			expectedEncoding: sms.GSM7,
+		},
+		{
+			name:             "Without transliteration one segment is out of reach",
+			policy:           sms.CompactionPolicy{MaxSegments: 1},
+			expectedSegments: 2,
+			expectedEncoding: sms.UCS2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, analysis := tt.policy.Compact(purchaseMessage)
+			if tt.expectedText != "" {
+				assert.Equal(t, tt.expectedText, text)
+			}
+			assert.Equal(t, tt.expectedSegments, analysis.Segments)
+			assert.Equal(t, tt.expectedEncoding, analysis.Encoding)
+		})
+	}
+}
+
+func TestCompactionPolicy_TransliteratesTajik(t *testing.T) {
+	policy := sms.CompactionPolicy{MaxSegments: 1, Transliterate: true}
+
+	text, analysis := policy.Compact("Муҳтарам мизоҷ! Пардохти №2 аз рӯи шартнома 20250101-120000. " +
+		"Ҳамагӣ барои пардохт: 1060.00 сомонӣ. Ташаккур барои харид! Қарз ғафс")
+
+	assert.Equal(t, sms.GSM7, analysis.Encoding)
+	assert.Equal(t, "Muhtaram mizoj! Pardokhti N2 az rui shartnoma 20250101-120000. "+
+		"Hamagi baroi pardokht: 1060.00 TJS. Tashakkur baroi kharid! Qarz ghafs", text)
+}
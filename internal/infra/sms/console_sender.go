@@ -1,9 +1,14 @@
 package sms
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"time"
 
 	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/phone"
 )
 
 type ConsoleSender struct{}
@@ -12,9 +17,33 @@ func NewConsoleSender() *ConsoleSender {
 	return &ConsoleSender{}
 }
 
-func (s *ConsoleSender) SendSMS(phoneNumber string, message string) error {
-	fmt.Printf("Уведомление отправлено на номер %s:\n%s\n", phoneNumber, message)
-	return nil
+func (s *ConsoleSender) SendSMS(ctx context.Context, phoneNumber string, message string) (domain.Receipt, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.Receipt{}, err
+	}
+
+	number, err := phone.ParseAny(phoneNumber)
+	if err != nil {
+		return domain.Receipt{}, fmt.Errorf("номер получателя %s: %w", phoneNumber, err)
+	}
+
+	fmt.Printf("Уведомление отправлено на номер %s:\n%s\n", number, message)
+
+	return domain.Receipt{
+		MessageID:  newMessageID(),
+		Segments:   Analyze(message).Segments,
+		AcceptedAt: time.Now(),
+	}, nil
+}
+
+func newMessageID() string {
+	return "console-" + randomHex()
+}
+
+func randomHex() string {
+	var id [8]byte
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:])
 }
 
 var _ domain.SMSSender = (*ConsoleSender)(nil)
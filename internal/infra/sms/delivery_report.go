@@ -0,0 +1,80 @@
+package sms
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+)
+
+var ErrInvalidDeliveryReceipt = errors.New("неверный формат отчета о доставке")
+
+// DeliveryReceipt is a delivery report as sent by a gateway.
+type DeliveryReceipt struct {
+	MessageID string
+	Status    domain.DeliveryStatus
+	Done      time.Time
+	Error     string
+}
+
+// DeliveryStatusFromCode maps SMPP stat values and the plain words used by
+// HTTP gateways to a delivery status. Intermediate states stay pending.
+func DeliveryStatusFromCode(code string) domain.DeliveryStatus {
+	switch strings.ToUpper(strings.TrimSpace(code)) {
+	case "DELIVRD", "DELIVERED":
+		return domain.DeliveryDelivered
+	case "UNDELIV", "UNDELIVERED", "REJECTD", "REJECTED", "DELETED", "FAILED":
+		return domain.DeliveryFailed
+	case "EXPIRED":
+		return domain.DeliveryExpired
+	default:
+		return domain.DeliveryPending
+	}
+}
+
+// ParseSMPPReceipt parses the short_message of an SMPP deliver_sm carrying a
+// delivery receipt, e.g.
+//
+//	id:0123456789 sub:001 dlvrd:001 submit date:2501011200 done date:2501011201 stat:DELIVRD err:000 text:...
+func ParseSMPPReceipt(text string) (DeliveryReceipt, error) {
+	normalized := smppDateKeys.ReplaceAllString(text[:textStart(text)], "${1}_date:")
+
+	fields := make(map[string]string)
+	for _, field := range strings.Fields(normalized) {
+		if key, value, ok := strings.Cut(field, ":"); ok {
+			fields[strings.ToLower(key)] = value
+		}
+	}
+
+	if fields["id"] == "" || fields["stat"] == "" {
+		return DeliveryReceipt{}, ErrInvalidDeliveryReceipt
+	}
+
+	receipt := DeliveryReceipt{
+		MessageID: fields["id"],
+		Status:    DeliveryStatusFromCode(fields["stat"]),
+	}
+
+	if done, err := time.ParseInLocation("0601021504", fields["done_date"], time.Local); err == nil {
+		receipt.Done = done
+	}
+
+	if code := fields["err"]; strings.Trim(code, "0") != "" {
+		receipt.Error = "err:" + code
+	}
+
+	return receipt, nil
+}
+
+var smppDateKeys = regexp.MustCompile(`(?i)(submit|done) date:`)
+
+// textStart cuts off the free-form text field, which may contain spaces and
+// colons of its own.
+func textStart(receipt string) int {
+	if i := strings.Index(strings.ToLower(receipt), "text:"); i >= 0 {
+		return i
+	}
+	return len(receipt)
+}
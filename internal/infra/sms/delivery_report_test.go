@@ -0,0 +1,86 @@
+package sms_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/infra/sms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSMPPReceipt(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		expected    sms.DeliveryReceipt
+		expectError bool
+	}{
+		{
+			name: "Delivered",
+			text: "id:AbC123 sub:001 dlvrd:001 submit date:2501011200 done date:2501011201 stat:DELIVRD err:000 text:Hello world",
+			expected: sms.DeliveryReceipt{
+				MessageID: "AbC123",
+				Status:    domain.DeliveryDelivered,
+				Done:      time.Date(2025, 1, 1, 12, 1, 0, 0, time.Local),
+			},
+		},
+		{
+			name: "Undelivered with error code",
+			text: "id:42 sub:001 dlvrd:000 submit date:2501011200 done date:2501011300 stat:UNDELIV err:011 text:stat:DELIVRD",
+			expected: sms.DeliveryReceipt{
+				MessageID: "42",
+				Status:    domain.DeliveryFailed,
+				Done:      time.Date(2025, 1, 1, 13, 0, 0, 0, time.Local),
+				Error:     "err:011",
+			},
+		},
+		{
+			name: "Expired without dates",
+			text: "id:7 stat:EXPIRED",
+			expected: sms.DeliveryReceipt{
+				MessageID: "7",
+				Status:    domain.DeliveryExpired,
+			},
+		},
+		{
+			name:        "Missing status",
+			text:        "id:7 sub:001",
+			expectError: true,
+		},
+		{
+			name:        "Free text only",
+			text:        "hello",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receipt, err := sms.ParseSMPPReceipt(tt.text)
+			if tt.expectError {
+				assert.ErrorIs(t, err, sms.ErrInvalidDeliveryReceipt)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, receipt)
+		})
+	}
+}
+
+func TestDeliveryStatusFromCode(t *testing.T) {
+	tests := map[string]domain.DeliveryStatus{
+		"DELIVRD":   domain.DeliveryDelivered,
+		"delivered": domain.DeliveryDelivered,
+		"UNDELIV":   domain.DeliveryFailed,
+		"REJECTD":   domain.DeliveryFailed,
+		"EXPIRED":   domain.DeliveryExpired,
+		"ENROUTE":   domain.DeliveryPending,
+		"":          domain.DeliveryPending,
+	}
+
+	for code, expected := range tests {
+		assert.Equal(t, expected, sms.DeliveryStatusFromCode(code), code)
+	}
+}
@@ -0,0 +1,81 @@
+package sms
+
+import (
+	"strings"
+	"unicode/utf16"
+)
+
+type Encoding string
+
+const (
+	GSM7 Encoding = "GSM-7"
+	UCS2 Encoding = "UCS-2"
+)
+
+const (
+	gsm7SingleLength = 160
+	gsm7PartLength   = 153
+	ucs2SingleLength = 70
+	ucs2PartLength   = 67
+)
+
+// gsm7Basic is the GSM 03.38 default alphabet; gsm7Extension characters are
+// sent with an escape prefix and take two septets each.
+const (
+	gsm7Basic = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+		"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+	gsm7Extension = "^{}\\[~]|€\f"
+)
+
+// Analysis describes how a message will be split into SMS segments.
+type Analysis struct {
+	Encoding Encoding
+	// Units is the message length in septets for GSM-7 and in UTF-16 code
+	// units for UCS-2.
+	Units    int
+	Segments int
+	// Remaining is how many more units fit into the last segment.
+	Remaining int
+}
+
+func Analyze(message string) Analysis {
+	if units, ok := gsm7Length(message); ok {
+		return split(GSM7, units, gsm7SingleLength, gsm7PartLength)
+	}
+
+	units := len(utf16.Encode([]rune(message)))
+	return split(UCS2, units, ucs2SingleLength, ucs2PartLength)
+}
+
+func split(encoding Encoding, units, single, part int) Analysis {
+	analysis := Analysis{Encoding: encoding, Units: units}
+
+	switch {
+	case units == 0:
+		analysis.Segments = 1
+		analysis.Remaining = single
+	case units <= single:
+		analysis.Segments = 1
+		analysis.Remaining = single - units
+	default:
+		analysis.Segments = (units + part - 1) / part
+		analysis.Remaining = analysis.Segments*part - units
+	}
+
+	return analysis
+}
+
+func gsm7Length(message string) (int, bool) {
+	units := 0
+	for _, r := range message {
+		switch {
+		case strings.ContainsRune(gsm7Basic, r):
+			units++
+		case strings.ContainsRune(gsm7Extension, r):
+			units += 2
+		default:
+			return 0, false
+		}
+	}
+	return units, true
+}
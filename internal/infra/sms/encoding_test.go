@@ -0,0 +1,64 @@
+package sms_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/icoder-new/installment-cli/internal/infra/sms"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		expected sms.Analysis
+	}{
+		{
+			name:     "Empty",
+			message:  "",
+			expected: sms.Analysis{Encoding: sms.GSM7, Units: 0, Segments: 1, Remaining: 160},
+		},
+		{
+			name:     "Latin single segment",
+			message:  "Payment due",
+			expected: sms.Analysis{Encoding: sms.GSM7, Units: 11, Segments: 1, Remaining: 149},
+		},
+		{
+			name:     "GSM-7 full segment",
+			message:  strings.Repeat("a", 160),
+			expected: sms.Analysis{Encoding: sms.GSM7, Units: 160, Segments: 1, Remaining: 0},
+		},
+		{
+			name:     "GSM-7 multipart",
+			message:  strings.Repeat("a", 161),
+			expected: sms.Analysis{Encoding: sms.GSM7, Units: 161, Segments: 2, Remaining: 145},
+		},
+		{
+			name:     "Extension characters take two septets",
+			message:  "€[]",
+			expected: sms.Analysis{Encoding: sms.GSM7, Units: 6, Segments: 1, Remaining: 154},
+		},
+		{
+			name:     "Cyrillic switches to UCS-2",
+			message:  strings.Repeat("я", 70),
+			expected: sms.Analysis{Encoding: sms.UCS2, Units: 70, Segments: 1, Remaining: 0},
+		},
+		{
+			name:     "UCS-2 multipart",
+			message:  strings.Repeat("я", 71),
+			expected: sms.Analysis{Encoding: sms.UCS2, Units: 71, Segments: 2, Remaining: 63},
+		},
+		{
+			name:     "Emoji counts as a surrogate pair",
+			message:  "ok 👍",
+			expected: sms.Analysis{Encoding: sms.UCS2, Units: 5, Segments: 1, Remaining: 65},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, sms.Analyze(tt.message))
+		})
+	}
+}
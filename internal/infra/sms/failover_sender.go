@@ -0,0 +1,146 @@
+package sms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+)
+
+var ErrNoProviderAvailable = errors.New("нет доступных смс-провайдеров")
+
+// ErrRejected marks sends that no provider and no retry can fix, such as an
+// invalid recipient or a request the gateway refused as malformed. They say
+// nothing about the provider's health, so they do not trip its breaker.
+var ErrRejected = errors.New("смс отклонено")
+
+// Provider is one SMS gateway in a failover chain.
+type Provider struct {
+	Name    string
+	Sender  domain.SMSSender
+	Breaker *CircuitBreaker
+}
+
+// ProviderMetrics counts what happened to the sends routed to a provider.
+type ProviderMetrics struct {
+	Name      string
+	State     BreakerState
+	Attempts  int
+	Successes int
+	Failures  int
+	Rejected  int
+	Skipped   int
+	Latency   time.Duration
+	LastError string
+}
+
+// FailoverSender tries providers in order and moves on to the next one when a
+// provider fails or its circuit is open. A rejected message is returned at
+// once: another provider would refuse it too.
+type FailoverSender struct {
+	providers []Provider
+	logger    *log.Logger
+
+	mu      sync.Mutex
+	metrics []ProviderMetrics
+}
+
+func NewFailoverSender(providers []Provider, logger *log.Logger) *FailoverSender {
+	metrics := make([]ProviderMetrics, len(providers))
+	for i, provider := range providers {
+		metrics[i].Name = provider.Name
+	}
+
+	return &FailoverSender{
+		providers: providers,
+		logger:    logger,
+		metrics:   metrics,
+	}
+}
+
+func (s *FailoverSender) SendSMS(ctx context.Context, phoneNumber string, message string) (domain.Receipt, error) {
+	var errs []error
+
+	for i, provider := range s.providers {
+		if err := ctx.Err(); err != nil {
+			return domain.Receipt{}, err
+		}
+
+		if !provider.Breaker.Allow() {
+			s.record(i, func(m *ProviderMetrics) { m.Skipped++ })
+			continue
+		}
+
+		started := time.Now()
+		receipt, err := provider.Sender.SendSMS(ctx, phoneNumber, message)
+		elapsed := time.Since(started)
+
+		if err == nil {
+			provider.Breaker.Success()
+			s.record(i, func(m *ProviderMetrics) {
+				m.Attempts++
+				m.Successes++
+				m.Latency += elapsed
+			})
+			return receipt, nil
+		}
+
+		// The caller gave up: that says nothing about the provider.
+		if ctx.Err() != nil {
+			provider.Breaker.Release()
+			return domain.Receipt{}, ctx.Err()
+		}
+
+		if errors.Is(err, ErrRejected) {
+			provider.Breaker.Release()
+			s.record(i, func(m *ProviderMetrics) {
+				m.Attempts++
+				m.Rejected++
+				m.Latency += elapsed
+				m.LastError = err.Error()
+			})
+			return domain.Receipt{}, fmt.Errorf("%s: %w", provider.Name, err)
+		}
+
+		provider.Breaker.Failure()
+		s.record(i, func(m *ProviderMetrics) {
+			m.Attempts++
+			m.Failures++
+			m.Latency += elapsed
+			m.LastError = err.Error()
+		})
+		s.logger.Printf("смс-провайдер %s: %v", provider.Name, err)
+		errs = append(errs, fmt.Errorf("%s: %w", provider.Name, err))
+	}
+
+	if len(errs) == 0 {
+		return domain.Receipt{}, ErrNoProviderAvailable
+	}
+	return domain.Receipt{}, fmt.Errorf("%w: %w", ErrNoProviderAvailable, errors.Join(errs...))
+}
+
+// Metrics returns a snapshot of per-provider counters in priority order.
+func (s *FailoverSender) Metrics() []ProviderMetrics {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot := make([]ProviderMetrics, len(s.metrics))
+	for i, metrics := range s.metrics {
+		metrics.State = s.providers[i].Breaker.State()
+		snapshot[i] = metrics
+	}
+	return snapshot
+}
+
+func (s *FailoverSender) record(i int, update func(*ProviderMetrics)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	update(&s.metrics[i])
+}
+
+var _ domain.SMSSender = (*FailoverSender)(nil)
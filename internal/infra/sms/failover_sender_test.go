@@ -0,0 +1,170 @@
+package sms_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/infra/sms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubSender struct {
+	id    string
+	err   error
+	calls int
+}
+
+func (s *stubSender) SendSMS(ctx context.Context, phoneNumber string, message string) (domain.Receipt, error) {
+	s.calls++
+	if s.err != nil {
+		return domain.Receipt{}, s.err
+	}
+	return domain.Receipt{MessageID: s.id}, nil
+}
+
+func newTestFailover(senders ...*stubSender) *sms.FailoverSender {
+	providers := make([]sms.Provider, len(senders))
+	for i, sender := range senders {
+		providers[i] = sms.Provider{
+			Name:    sender.id,
+			Sender:  sender,
+			Breaker: sms.NewCircuitBreaker(2, time.Hour),
+		}
+	}
+	return sms.NewFailoverSender(providers, log.New(&bytes.Buffer{}, "", 0))
+}
+
+func TestFailoverSender_FallsBackToNextProvider(t *testing.T) {
+	primary := &stubSender{id: "primary", err: errors.New("503")}
+	backup := &stubSender{id: "backup"}
+	sender := newTestFailover(primary, backup)
+
+	for range 3 {
+		receipt, err := sender.SendSMS(context.Background(), "+992001002005", "text")
+		require.NoError(t, err)
+		assert.Equal(t, "backup", receipt.MessageID)
+	}
+
+	assert.Equal(t, 2, primary.calls, "open circuit must skip the primary provider")
+	assert.Equal(t, 3, backup.calls)
+
+	metrics := sender.Metrics()
+	assert.Equal(t, sms.ProviderMetrics{
+		Name: "primary", State: sms.BreakerOpen, Attempts: 2, Failures: 2, Skipped: 1,
+		Latency: metrics[0].Latency, LastError: "503",
+	}, metrics[0])
+	assert.Equal(t, 3, metrics[1].Successes)
+}
+
+func TestFailoverSender_RejectedMessagesKeepCircuitClosed(t *testing.T) {
+	primary := &stubSender{id: "primary", err: fmt.Errorf("%w: шлюз ответил 400 Bad Request", sms.ErrRejected)}
+	backup := &stubSender{id: "backup"}
+	sender := newTestFailover(primary, backup)
+
+	for range 3 {
+		_, err := sender.SendSMS(context.Background(), "+992001002005", "text")
+		assert.ErrorIs(t, err, sms.ErrRejected)
+		assert.NotErrorIs(t, err, sms.ErrNoProviderAvailable)
+	}
+
+	assert.Equal(t, 3, primary.calls, "rejections must not open the circuit")
+	assert.Zero(t, backup.calls, "another provider would reject the message too")
+
+	metrics := sender.Metrics()
+	assert.Equal(t, sms.BreakerClosed, metrics[0].State)
+	assert.Equal(t, 3, metrics[0].Rejected)
+	assert.Zero(t, metrics[0].Failures)
+}
+
+func TestFailoverSender_AllProvidersFail(t *testing.T) {
+	sender := newTestFailover(
+		&stubSender{id: "primary", err: errors.New("503")},
+		&stubSender{id: "backup", err: errors.New("timeout")},
+	)
+
+	_, err := sender.SendSMS(context.Background(), "+992001002005", "text")
+	assert.ErrorIs(t, err, sms.ErrNoProviderAvailable)
+	assert.Contains(t, err.Error(), "primary: 503")
+	assert.Contains(t, err.Error(), "backup: timeout")
+}
+
+func TestFailoverSender_CancelledContext(t *testing.T) {
+	primary := &stubSender{id: "primary"}
+	sender := newTestFailover(primary)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := sender.SendSMS(ctx, "+992001002005", "text")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, primary.calls)
+}
+
+func TestNewProviderChain(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       sms.ProvidersConfig
+		expectError  bool
+		expectedName []string
+	}{
+		{
+			name: "Sorted by priority",
+			config: sms.ProvidersConfig{Providers: []sms.ProviderConfig{
+				{Name: "backup", Type: "console", Priority: 2},
+				{Name: "primary", Type: "http", URL: "http://gateway.local/send", Priority: 1},
+			}},
+			expectedName: []string{"primary", "backup"},
+		},
+		{
+			name:        "Empty",
+			config:      sms.ProvidersConfig{},
+			expectError: true,
+		},
+		{
+			name: "Duplicate name",
+			config: sms.ProvidersConfig{Providers: []sms.ProviderConfig{
+				{Name: "a", Type: "console"},
+				{Name: "a", Type: "console"},
+			}},
+			expectError: true,
+		},
+		{
+			name: "HTTP without url",
+			config: sms.ProvidersConfig{Providers: []sms.ProviderConfig{
+				{Name: "a", Type: "http"},
+			}},
+			expectError: true,
+		},
+		{
+			name: "Unknown type",
+			config: sms.ProvidersConfig{Providers: []sms.ProviderConfig{
+				{Name: "a", Type: "smpp"},
+			}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sender, err := sms.NewProviderChain(tt.config, time.Second, log.New(&bytes.Buffer{}, "", 0))
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var names []string
+			for _, metrics := range sender.Metrics() {
+				names = append(names, metrics.Name)
+			}
+			assert.Equal(t, tt.expectedName, names)
+		})
+	}
+}
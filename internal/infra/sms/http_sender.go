@@ -0,0 +1,101 @@
+package sms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/phone"
+)
+
+type httpSendRequest struct {
+	To   string `json:"to"`
+	Text string `json:"text"`
+}
+
+type httpSendResponse struct {
+	MessageID string `json:"message_id"`
+}
+
+// HTTPSender posts messages as JSON to an HTTP SMS gateway and expects the
+// gateway's message ID back, which later delivery reports refer to. A 2xx
+// response without one still means the message was accepted: the receipt
+// then has no ID and the message gets no delivery reports.
+type HTTPSender struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func NewHTTPSender(url, token string) *HTTPSender {
+	return &HTTPSender{
+		url:    url,
+		token:  token,
+		client: &http.Client{},
+	}
+}
+
+func (s *HTTPSender) SendSMS(ctx context.Context, phoneNumber string, message string) (domain.Receipt, error) {
+	to, err := phone.Normalize(phoneNumber)
+	if err != nil {
+		return domain.Receipt{}, fmt.Errorf("%w: номер получателя %s: %w", ErrRejected, phoneNumber, err)
+	}
+
+	body, err := json.Marshal(httpSendRequest{To: to, Text: message})
+	if err != nil {
+		return domain.Receipt{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return domain.Receipt{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return domain.Receipt{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		if isRejection(resp.StatusCode) {
+			return domain.Receipt{}, fmt.Errorf("%w: шлюз ответил %s", ErrRejected, resp.Status)
+		}
+		return domain.Receipt{}, fmt.Errorf("шлюз ответил %s", resp.Status)
+	}
+
+	// Sending again through another provider would text the customer
+	// twice, so a missing ID is not an error.
+	var response httpSendResponse
+	_ = json.NewDecoder(resp.Body).Decode(&response)
+
+	return domain.Receipt{
+		MessageID:  response.MessageID,
+		Segments:   Analyze(message).Segments,
+		AcceptedAt: time.Now(),
+	}, nil
+}
+
+// isRejection reports whether the gateway refused the message itself. Timeouts,
+// rate limiting and rejected credentials are problems of this provider that
+// another one may not have.
+func isRejection(status int) bool {
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return false
+	default:
+		return status >= 400 && status < 500
+	}
+}
+
+var _ domain.SMSSender = (*HTTPSender)(nil)
@@ -0,0 +1,49 @@
+package sms_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/icoder-new/installment-cli/internal/infra/sms"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPSender_SendSMS(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		expectedID   string
+		expectErr    bool
+		expectReject bool
+	}{
+		{name: "Accepted", status: http.StatusOK, body: `{"message_id": "gw-1"}`, expectedID: "gw-1"},
+		{name: "Accepted without ID", status: http.StatusAccepted, body: `{}`},
+		{name: "Accepted with an unreadable body", status: http.StatusOK, body: `OK`},
+		{name: "Rejected", status: http.StatusBadRequest, expectErr: true, expectReject: true},
+		{name: "Provider failure", status: http.StatusBadGateway, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			receipt, err := sms.NewHTTPSender(server.URL, "").SendSMS(context.Background(), "+992931234567", "text")
+			if !tt.expectErr {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedID, receipt.MessageID)
+				return
+			}
+
+			assert.Error(t, err)
+			assert.Equal(t, tt.expectReject, errors.Is(err, sms.ErrRejected))
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package sms
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+)
+
+// PolicySender enforces the send policy in front of another sender. Optional
+// messages to opted-out customers are dropped, and messages that would break
+// the per-phone rate limit or, unless urgent, arrive in quiet hours are queued
+// in the outbox instead; perishable messages are refused rather than queued.
+// Consent codes are counted in a quota of their own, so that a code and the
+// purchase SMS after it do not use up each other's limit. Messages without
+// an event are treated as mandatory and urgent.
+type PolicySender struct {
+	next      domain.SMSSender
+	policy    SendPolicy
+	customers domain.CustomerRepository
+	outbox    domain.SMSOutbox
+	sendLog   domain.SMSSendLog
+	logger    *log.Logger
+	now       func() time.Time
+}
+
+func NewPolicySender(
+	next domain.SMSSender,
+	policy SendPolicy,
+	customers domain.CustomerRepository,
+	outbox domain.SMSOutbox,
+	sendLog domain.SMSSendLog,
+	logger *log.Logger,
+) *PolicySender {
+	return &PolicySender{
+		next:      next,
+		policy:    policy,
+		customers: customers,
+		outbox:    outbox,
+		sendLog:   sendLog,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+func (s *PolicySender) SendSMS(ctx context.Context, phoneNumber string, message string) (domain.Receipt, error) {
+	event, known := domain.MessageEventFromContext(ctx)
+	mandatory := !known || event.IsMandatory()
+	urgent := !known || event.IsUrgent()
+	now := s.now()
+
+	if !mandatory {
+		customer, err := s.customers.FindByPhone(phoneNumber)
+		if err != nil && !errors.Is(err, domain.ErrCustomerNotFound) {
+			return domain.Receipt{}, err
+		}
+		if customer.OptedOut {
+			s.logger.Printf("смс %s на %s не отправлено: клиент отказался от рассылки", event, domain.MaskPhoneNumber(phoneNumber))
+			return domain.Receipt{}, domain.ErrMessageSuppressed
+		}
+	}
+
+	bucket := rateBucket(phoneNumber, event)
+	notBefore, err := s.sendTime(bucket, now, urgent)
+	if err != nil {
+		return domain.Receipt{}, err
+	}
+
+	if notBefore.After(now) {
+		if known && event.IsPerishable() {
+			s.logger.Printf("смс %s на %s не отправлено: превышено число смс на номер", event, domain.MaskPhoneNumber(phoneNumber))
+			return domain.Receipt{}, domain.ErrSendLimited
+		}
+		return s.enqueue(phoneNumber, message, event, notBefore, now)
+	}
+
+	receipt, err := s.next.SendSMS(ctx, phoneNumber, message)
+	if err != nil {
+		return receipt, err
+	}
+
+	if err := s.sendLog.Record(bucket, now); err != nil {
+		s.logger.Printf("смс на %s: %v", domain.MaskPhoneNumber(phoneNumber), err)
+	}
+	return receipt, nil
+}
+
+// rateBucket returns the send log key the message is counted under.
+func rateBucket(phoneNumber string, event domain.MessageEvent) string {
+	if event == domain.EventConsent {
+		return phoneNumber + "/" + string(event)
+	}
+	return phoneNumber
+}
+
+// sendTime returns the earliest time a message counted under bucket may go
+// out.
+func (s *PolicySender) sendTime(bucket string, now time.Time, urgent bool) (time.Time, error) {
+	notBefore := now
+	if !urgent {
+		if until, quiet := s.policy.QuietUntil(notBefore); quiet {
+			notBefore = until
+		}
+	}
+
+	if s.policy.RateLimit <= 0 {
+		return notBefore, nil
+	}
+
+	sent, err := s.sendLog.SentSince(bucket, notBefore.Add(-s.policy.RateWindow))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if len(sent) >= s.policy.RateLimit {
+		notBefore = sent[len(sent)-s.policy.RateLimit].Add(s.policy.RateWindow)
+		if !urgent {
+			if until, quiet := s.policy.QuietUntil(notBefore); quiet {
+				notBefore = until
+			}
+		}
+	}
+	return notBefore, nil
+}
+
+func (s *PolicySender) enqueue(
+	phoneNumber, message string,
+	event domain.MessageEvent,
+	notBefore, now time.Time,
+) (domain.Receipt, error) {
+	queued := domain.QueuedSMS{
+		ID:          "queued-" + randomHex(),
+		PhoneNumber: phoneNumber,
+		Message:     message,
+		Event:       event,
+		NotBefore:   notBefore,
+		QueuedAt:    now,
+	}
+
+	if err := s.outbox.Enqueue(queued); err != nil {
+		return domain.Receipt{}, err
+	}
+
+	s.logger.Printf("смс на %s отложено до %s", domain.MaskPhoneNumber(phoneNumber), notBefore.In(s.policy.Location).Format("02.01.2006 15:04"))
+
+	return domain.Receipt{
+		MessageID:   queued.ID,
+		Segments:    Analyze(message).Segments,
+		AcceptedAt:  now,
+		ScheduledAt: notBefore,
+	}, nil
+}
+
+var _ domain.SMSSender = (*PolicySender)(nil)
@@ -0,0 +1,194 @@
+package sms_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/infra/sms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryOutbox map[string]domain.QueuedSMS
+
+func (o memoryOutbox) Enqueue(message domain.QueuedSMS) error {
+	o[message.ID] = message
+	return nil
+}
+
+func (o memoryOutbox) Due(now time.Time) ([]domain.QueuedSMS, error) {
+	var due []domain.QueuedSMS
+	for _, message := range o {
+		if !message.NotBefore.After(now) {
+			due = append(due, message)
+		}
+	}
+	return due, nil
+}
+
+func (o memoryOutbox) Remove(id string) error {
+	delete(o, id)
+	return nil
+}
+
+type memorySendLog map[string][]time.Time
+
+func (l memorySendLog) SentSince(phoneNumber string, since time.Time) ([]time.Time, error) {
+	var sent []time.Time
+	for _, sentAt := range l[phoneNumber] {
+		if sentAt.After(since) {
+			sent = append(sent, sentAt)
+		}
+	}
+	return sent, nil
+}
+
+func (l memorySendLog) Record(phoneNumber string, sentAt time.Time) error {
+	l[phoneNumber] = append(l[phoneNumber], sentAt)
+	return nil
+}
+
+type memoryCustomers map[string]domain.Customer
+
+func (r memoryCustomers) Save(customer domain.Customer) error {
+	r[customer.PhoneNumber] = customer
+	return nil
+}
+
+func (r memoryCustomers) FindByPhone(phoneNumber string) (domain.Customer, error) {
+	customer, ok := r[phoneNumber]
+	if !ok {
+		return domain.Customer{}, domain.ErrCustomerNotFound
+	}
+	return customer, nil
+}
+
+const testPhone = "+992001002005"
+
+func TestPolicySender(t *testing.T) {
+	alwaysQuiet := sms.SendPolicy{QuietEnd: 24 * time.Hour, Location: time.UTC}
+	never := sms.SendPolicy{Location: time.UTC}
+
+	tests := []struct {
+		name        string
+		policy      sms.SendPolicy
+		optedOut    bool
+		event       domain.MessageEvent
+		expectError error
+		expectSent  bool
+		expectQueue bool
+	}{
+		{name: "Plain send", policy: never, event: domain.EventReminder, expectSent: true},
+		{name: "Opt-out suppresses reminders", policy: never, optedOut: true, event: domain.EventReminder, expectError: domain.ErrMessageSuppressed},
+		{name: "Opt-out keeps required messages", policy: never, optedOut: true, event: domain.EventOverdue, expectSent: true},
+		{name: "Quiet hours delay reminders", policy: alwaysQuiet, event: domain.EventReminder, expectQueue: true},
+		{name: "Quiet hours delay debt notices", policy: alwaysQuiet, event: domain.EventOverdue, expectQueue: true},
+		{name: "Purchase is sent at night", policy: alwaysQuiet, event: domain.EventPurchase, expectSent: true},
+		{name: "Unknown event is treated as urgent", policy: alwaysQuiet, expectSent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &stubSender{id: "msg-1"}
+			outbox := memoryOutbox{}
+			customers := memoryCustomers{testPhone: {PhoneNumber: testPhone, OptedOut: tt.optedOut}}
+			sender := sms.NewPolicySender(next, tt.policy, customers, outbox, memorySendLog{}, log.New(&bytes.Buffer{}, "", 0))
+
+			ctx := context.Background()
+			if tt.event != "" {
+				ctx = domain.WithMessageEvent(ctx, tt.event)
+			}
+
+			receipt, err := sender.SendSMS(ctx, testPhone, "text")
+			if tt.expectError != nil {
+				assert.ErrorIs(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			if tt.expectSent {
+				assert.Equal(t, 1, next.calls)
+				assert.Equal(t, "msg-1", receipt.MessageID)
+			} else {
+				assert.Zero(t, next.calls)
+			}
+
+			if tt.expectQueue {
+				require.Contains(t, outbox, receipt.MessageID)
+				assert.False(t, receipt.ScheduledAt.IsZero())
+				assert.Equal(t, tt.event, outbox[receipt.MessageID].Event)
+			} else {
+				assert.Empty(t, outbox)
+			}
+		})
+	}
+}
+
+func TestPolicySender_RateLimit(t *testing.T) {
+	next := &stubSender{id: "msg-1"}
+	outbox := memoryOutbox{}
+	sendLog := memorySendLog{}
+	policy := sms.SendPolicy{RateLimit: 2, RateWindow: time.Hour, Location: time.UTC}
+	sender := sms.NewPolicySender(next, policy, memoryCustomers{}, outbox, sendLog, log.New(&bytes.Buffer{}, "", 0))
+
+	ctx := domain.WithMessageEvent(context.Background(), domain.EventPurchase)
+	for range 2 {
+		_, err := sender.SendSMS(ctx, testPhone, "text")
+		require.NoError(t, err)
+	}
+
+	receipt, err := sender.SendSMS(ctx, testPhone, "text")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, next.calls)
+	require.Contains(t, outbox, receipt.MessageID)
+	assert.Equal(t, sendLog[testPhone][0].Add(time.Hour), receipt.ScheduledAt)
+
+	_, err = sender.SendSMS(ctx, "+992001002006", "text")
+	require.NoError(t, err)
+	assert.Equal(t, 3, next.calls, "the limit is per phone number")
+}
+
+func TestPolicySender_RateLimitRefusesConsentCodes(t *testing.T) {
+	next := &stubSender{id: "msg-1"}
+	outbox := memoryOutbox{}
+	policy := sms.SendPolicy{RateLimit: 1, RateWindow: time.Hour, Location: time.UTC}
+	sender := sms.NewPolicySender(next, policy, memoryCustomers{}, outbox, memorySendLog{}, log.New(&bytes.Buffer{}, "", 0))
+
+	ctx := domain.WithMessageEvent(context.Background(), domain.EventConsent)
+	_, err := sender.SendSMS(ctx, testPhone, "code")
+	require.NoError(t, err)
+
+	_, err = sender.SendSMS(ctx, testPhone, "code")
+
+	assert.ErrorIs(t, err, domain.ErrSendLimited)
+	assert.Equal(t, 1, next.calls)
+	assert.Empty(t, outbox, "a code must not be sent after it expires")
+}
+
+func TestPolicySender_ConsentCodesHaveTheirOwnQuota(t *testing.T) {
+	next := &stubSender{id: "msg-1"}
+	outbox := memoryOutbox{}
+	sendLog := memorySendLog{}
+	policy := sms.SendPolicy{RateLimit: 3, RateWindow: time.Hour, Location: time.UTC}
+	sender := sms.NewPolicySender(next, policy, memoryCustomers{}, outbox, sendLog, log.New(&bytes.Buffer{}, "", 0))
+
+	consent := domain.WithMessageEvent(context.Background(), domain.EventConsent)
+	purchase := domain.WithMessageEvent(context.Background(), domain.EventPurchase)
+	for _, ctx := range []context.Context{consent, purchase, consent, purchase, purchase} {
+		receipt, err := sender.SendSMS(ctx, testPhone, "text")
+		require.NoError(t, err)
+		assert.True(t, receipt.ScheduledAt.IsZero())
+	}
+
+	assert.Equal(t, 5, next.calls, "two codes and three purchases within an hour all go out")
+	assert.Empty(t, outbox)
+
+	receipt, err := sender.SendSMS(purchase, testPhone, "text")
+	require.NoError(t, err)
+	assert.Contains(t, outbox, receipt.MessageID, "the purchase quota is still enforced")
+}
@@ -0,0 +1,111 @@
+package sms
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/jsonconf"
+)
+
+const (
+	defaultFailureThreshold = 3
+	defaultOpenTimeout      = time.Minute
+)
+
+type ProviderConfig struct {
+	Name             string            `json:"name"`
+	Type             string            `json:"type"`
+	Priority         int               `json:"priority"`
+	URL              string            `json:"url"`
+	TokenEnv         string            `json:"token_env"`
+	Timeout          jsonconf.Duration `json:"timeout"`
+	FailureThreshold int               `json:"failure_threshold"`
+	OpenTimeout      jsonconf.Duration `json:"open_timeout"`
+}
+
+type ProvidersConfig struct {
+	Providers []ProviderConfig `json:"providers"`
+}
+
+func LoadProvidersConfig(path string) (ProvidersConfig, error) {
+	var config ProvidersConfig
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, fmt.Errorf("не удалось прочитать настройки смс-провайдеров: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("не удалось разобрать настройки смс-провайдеров: %w", err)
+	}
+
+	return config, nil
+}
+
+// NewProviderChain builds a failover sender from the config. Providers are
+// tried by ascending priority; defaultTimeout applies to providers that do
+// not set their own.
+func NewProviderChain(config ProvidersConfig, defaultTimeout time.Duration, logger *log.Logger) (*FailoverSender, error) {
+	if len(config.Providers) == 0 {
+		return nil, fmt.Errorf("не задано ни одного смс-провайдера")
+	}
+
+	configs := append([]ProviderConfig(nil), config.Providers...)
+	sort.SliceStable(configs, func(i, j int) bool {
+		return configs[i].Priority < configs[j].Priority
+	})
+
+	seen := make(map[string]bool)
+	providers := make([]Provider, 0, len(configs))
+	for _, cfg := range configs {
+		if cfg.Name == "" || seen[cfg.Name] {
+			return nil, fmt.Errorf("имя смс-провайдера пустое или повторяется: %q", cfg.Name)
+		}
+		seen[cfg.Name] = true
+
+		sender, err := newProviderSender(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		timeout := time.Duration(cfg.Timeout)
+		if timeout <= 0 {
+			timeout = defaultTimeout
+		}
+		threshold := cfg.FailureThreshold
+		if threshold <= 0 {
+			threshold = defaultFailureThreshold
+		}
+		openTimeout := time.Duration(cfg.OpenTimeout)
+		if openTimeout <= 0 {
+			openTimeout = defaultOpenTimeout
+		}
+
+		providers = append(providers, Provider{
+			Name:    cfg.Name,
+			Sender:  NewTimeoutSender(sender, timeout),
+			Breaker: NewCircuitBreaker(threshold, openTimeout),
+		})
+	}
+
+	return NewFailoverSender(providers, logger), nil
+}
+
+func newProviderSender(cfg ProviderConfig) (domain.SMSSender, error) {
+	switch cfg.Type {
+	case "console":
+		return NewConsoleSender(), nil
+	case "http":
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("для смс-провайдера %s не указан url", cfg.Name)
+		}
+		return NewHTTPSender(cfg.URL, os.Getenv(cfg.TokenEnv)), nil
+	default:
+		return nil, fmt.Errorf("неизвестный тип смс-провайдера %s: %q", cfg.Name, cfg.Type)
+	}
+}
@@ -0,0 +1,97 @@
+package sms
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SendPolicy limits how often and when customers are texted. Quiet hours
+// may wrap around midnight; equal start and end disable them.
+type SendPolicy struct {
+	RateLimit  int
+	RateWindow time.Duration
+	QuietStart time.Duration
+	QuietEnd   time.Duration
+	Location   *time.Location
+}
+
+// DushanbeLocation returns Tajikistan time, falling back to its fixed UTC+5
+// offset when the system has no time zone database.
+func DushanbeLocation() *time.Location {
+	if location, err := time.LoadLocation("Asia/Dushanbe"); err == nil {
+		return location
+	}
+	return time.FixedZone("Asia/Dushanbe", 5*60*60)
+}
+
+// QuietUntil reports whether t falls in quiet hours and, if so, when they end.
+func (p SendPolicy) QuietUntil(t time.Time) (time.Time, bool) {
+	if p.QuietStart == p.QuietEnd {
+		return time.Time{}, false
+	}
+
+	local := t.In(p.Location)
+	year, month, day := local.Date()
+	midnight := time.Date(year, month, day, 0, 0, 0, 0, p.Location)
+	offset := local.Sub(midnight)
+
+	if p.QuietStart < p.QuietEnd {
+		if offset >= p.QuietStart && offset < p.QuietEnd {
+			return midnight.Add(p.QuietEnd), true
+		}
+		return time.Time{}, false
+	}
+
+	switch {
+	case offset >= p.QuietStart:
+		return midnight.AddDate(0, 0, 1).Add(p.QuietEnd), true
+	case offset < p.QuietEnd:
+		return midnight.Add(p.QuietEnd), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// ParseRateLimit parses limits such as "3/1h"; "off" disables the limit.
+func ParseRateLimit(value string) (int, time.Duration, error) {
+	if value == "off" {
+		return 0, 0, nil
+	}
+
+	count, window, ok := strings.Cut(value, "/")
+	limit, err := strconv.Atoi(count)
+	if !ok || err != nil || limit <= 0 {
+		return 0, 0, fmt.Errorf("неверный лимит смс: %s", value)
+	}
+
+	duration, err := time.ParseDuration(window)
+	if err != nil || duration <= 0 {
+		return 0, 0, fmt.Errorf("неверный лимит смс: %s", value)
+	}
+	return limit, duration, nil
+}
+
+// ParseQuietHours parses ranges such as "21:00-08:00"; "off" disables them.
+func ParseQuietHours(value string) (time.Duration, time.Duration, error) {
+	if value == "off" {
+		return 0, 0, nil
+	}
+
+	from, to, ok := strings.Cut(value, "-")
+	start, startErr := parseClock(from)
+	end, endErr := parseClock(to)
+	if !ok || startErr != nil || endErr != nil {
+		return 0, 0, fmt.Errorf("неверные тихие часы: %s", value)
+	}
+	return start, end, nil
+}
+
+func parseClock(value string) (time.Duration, error) {
+	clock, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute, nil
+}
@@ -0,0 +1,74 @@
+package sms_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/infra/sms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendPolicy_QuietUntil(t *testing.T) {
+	dushanbe := sms.DushanbeLocation()
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2025, 3, day, hour, minute, 0, 0, dushanbe)
+	}
+
+	tests := []struct {
+		name      string
+		start     time.Duration
+		end       time.Duration
+		time      time.Time
+		expected  time.Time
+		wantQuiet bool
+	}{
+		{name: "Evening before midnight", start: 21 * time.Hour, end: 8 * time.Hour, time: at(10, 22, 30), expected: at(11, 8, 0), wantQuiet: true},
+		{name: "Night after midnight", start: 21 * time.Hour, end: 8 * time.Hour, time: at(10, 3, 0), expected: at(10, 8, 0), wantQuiet: true},
+		{name: "Daytime", start: 21 * time.Hour, end: 8 * time.Hour, time: at(10, 12, 0)},
+		{name: "End is not quiet", start: 21 * time.Hour, end: 8 * time.Hour, time: at(10, 8, 0)},
+		{name: "Same-day range", start: 13 * time.Hour, end: 14 * time.Hour, time: at(10, 13, 15), expected: at(10, 14, 0), wantQuiet: true},
+		{name: "Disabled", time: at(10, 3, 0)},
+		{name: "Other time zone", start: 21 * time.Hour, end: 8 * time.Hour, time: time.Date(2025, 3, 10, 17, 0, 0, 0, time.UTC), expected: at(11, 8, 0), wantQuiet: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := sms.SendPolicy{QuietStart: tt.start, QuietEnd: tt.end, Location: dushanbe}
+
+			until, quiet := policy.QuietUntil(tt.time)
+			assert.Equal(t, tt.wantQuiet, quiet)
+			if tt.wantQuiet {
+				assert.True(t, tt.expected.Equal(until), "expected %s, got %s", tt.expected, until)
+			}
+		})
+	}
+}
+
+func TestParseRateLimit(t *testing.T) {
+	limit, window, err := sms.ParseRateLimit("3/1h")
+	require.NoError(t, err)
+	assert.Equal(t, 3, limit)
+	assert.Equal(t, time.Hour, window)
+
+	limit, _, err = sms.ParseRateLimit("off")
+	require.NoError(t, err)
+	assert.Zero(t, limit)
+
+	for _, value := range []string{"3", "0/1h", "x/1h", "3/soon", "3/-1h"} {
+		_, _, err := sms.ParseRateLimit(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestParseQuietHours(t *testing.T) {
+	start, end, err := sms.ParseQuietHours("21:30-08:00")
+	require.NoError(t, err)
+	assert.Equal(t, 21*time.Hour+30*time.Minute, start)
+	assert.Equal(t, 8*time.Hour, end)
+
+	for _, value := range []string{"21:00", "25:00-08:00", "evening-morning"} {
+		_, _, err := sms.ParseQuietHours(value)
+		assert.Error(t, err, value)
+	}
+}
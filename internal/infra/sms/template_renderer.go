@@ -0,0 +1,137 @@
+package sms
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/i18n"
+)
+
+//go:embed templates
+var defaultTemplates embed.FS
+
+func templateFuncs(catalog *i18n.Catalog) template.FuncMap {
+	return template.FuncMap{
+		"money": catalog.Money,
+		"words": catalog.MoneyWords,
+		"date": func(t time.Time) string {
+			return t.Format("02.01.2006")
+		},
+		"product": func(productType domain.ProductType) string {
+			return catalog.Product(productType)
+		},
+	}
+}
+
+type templateKey struct {
+	lang  i18n.Language
+	event domain.MessageEvent
+}
+
+// TemplateRenderer renders customer messages from one text/template per
+// language and event. Built-in templates can be overridden by
+// <lang>/<event>.tmpl files in a directory, so wording changes do not need a
+// release.
+type TemplateRenderer struct {
+	templates map[templateKey]*template.Template
+}
+
+// NewTemplateRenderer loads the templates and renders each one with sample
+// data, so a broken template is reported at startup rather than at sale time.
+func NewTemplateRenderer(dir string) (*TemplateRenderer, error) {
+	r := &TemplateRenderer{templates: make(map[templateKey]*template.Template)}
+
+	for _, lang := range i18n.Languages {
+		funcs := templateFuncs(i18n.New(lang))
+
+		for _, event := range domain.MessageEvents {
+			name := path.Join(string(lang), string(event)+".tmpl")
+
+			text, err := loadTemplate(dir, name)
+			if err != nil {
+				return nil, err
+			}
+
+			tmpl, err := template.New(name).Funcs(funcs).Parse(text)
+			if err != nil {
+				return nil, fmt.Errorf("ошибка в шаблоне %s: %w", name, err)
+			}
+			r.templates[templateKey{lang: lang, event: event}] = tmpl
+
+			if _, err := r.Render(string(lang), event, SampleMessageData()); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return r, nil
+}
+
+func (r *TemplateRenderer) Render(language string, event domain.MessageEvent, data domain.MessageData) (string, error) {
+	lang, ok := i18n.ParseLanguage(language)
+	if !ok {
+		lang = i18n.DefaultLanguage
+	}
+
+	tmpl, ok := r.templates[templateKey{lang: lang, event: event}]
+	if !ok {
+		return "", fmt.Errorf("нет шаблона для события %s", event)
+	}
+
+	var message bytes.Buffer
+	if err := tmpl.Execute(&message, data); err != nil {
+		return "", fmt.Errorf("ошибка в шаблоне %s: %w", tmpl.Name(), err)
+	}
+
+	return strings.TrimSpace(message.String()), nil
+}
+
+// SampleMessageData is used to check templates at startup and by the
+// sms preview command.
+func SampleMessageData() domain.MessageData {
+	return domain.MessageData{
+		ContractID:        "20250101-120000",
+		Product:           domain.Smartphone,
+		Price:             1000,
+		PeriodMonths:      9,
+		Overpayment:       60,
+		TotalPayment:      1060,
+		InstallmentNumber: 2,
+		DueDate:           time.Date(2025, time.March, 1, 0, 0, 0, 0, time.Local),
+		Amount:            117.77,
+		Days:              3,
+		Reason:            "возврат товара",
+		Refund:            235.54,
+		Code:              "482915",
+		ValidMinutes:      5,
+	}
+}
+
+func loadTemplate(dir, name string) (string, error) {
+	if dir != "" {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err == nil {
+			return string(data), nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("не удалось прочитать шаблон %s: %w", name, err)
+		}
+	}
+
+	data, err := defaultTemplates.ReadFile(path.Join("templates", name))
+	if err != nil {
+		return "", fmt.Errorf("нет встроенного шаблона %s", name)
+	}
+	return string(data), nil
+}
+
+var _ domain.MessageRenderer = (*TemplateRenderer)(nil)
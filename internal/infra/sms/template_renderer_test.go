@@ -0,0 +1,128 @@
+package sms_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/infra/sms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateRenderer_DefaultPurchaseMessage(t *testing.T) {
+	renderer, err := sms.NewTemplateRenderer("")
+	require.NoError(t, err)
+
+	message, err := renderer.Render("ru", domain.EventPurchase, domain.MessageData{
+		Product:      domain.Computer,
+		Price:        25000,
+		PeriodMonths: 12,
+		Overpayment:  3000,
+		TotalPayment: 28000,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Уважаемый клиент!\n"+
+		"Детали вашей покупки:\n"+
+		"Товар: Компьютер\n"+
+		"Сумма: 25 000,00 сомони\n"+
+		"Срок рассрочки: 12 мес.\n"+
+		"Переплата: 3 000,00 сомони\n"+
+		"Итого к оплате: 28 000,00 сомони", message)
+}
+
+func TestTemplateRenderer_Languages(t *testing.T) {
+	renderer, err := sms.NewTemplateRenderer("")
+	require.NoError(t, err)
+
+	tests := []struct {
+		language string
+		expected string
+	}{
+		{language: "tg", expected: "Мол: Компютер"},
+		{language: "en", expected: "Product: Computer"},
+		{language: "", expected: "Товар: Компьютер"},
+		{language: "fr", expected: "Товар: Компьютер"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.language, func(t *testing.T) {
+			data := sms.SampleMessageData()
+			data.Product = domain.Computer
+
+			message, err := renderer.Render(tt.language, domain.EventPurchase, data)
+			require.NoError(t, err)
+			assert.Contains(t, message, tt.expected)
+		})
+	}
+}
+
+func TestTemplateRenderer_Override(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, "ru/reminder.tmpl", "Платеж {{money .Amount}} до {{date .DueDate}}\n")
+
+	renderer, err := sms.NewTemplateRenderer(dir)
+	require.NoError(t, err)
+
+	message, err := renderer.Render("ru", domain.EventReminder, sms.SampleMessageData())
+	require.NoError(t, err)
+	assert.Equal(t, "Платеж 117,77 до 01.03.2025", message)
+}
+
+func TestTemplateRenderer_Words(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, "tg/payoff.tmpl", "{{words .TotalPayment}}\n")
+
+	renderer, err := sms.NewTemplateRenderer(dir)
+	require.NoError(t, err)
+
+	data := sms.SampleMessageData()
+	data.TotalPayment = 1060
+	message, err := renderer.Render("tg", domain.EventPayoff, data)
+	require.NoError(t, err)
+	assert.Equal(t, "як ҳазору шаст сомонӣ 00 дирам", message)
+}
+
+func TestTemplateRenderer_RejectsBrokenTemplates(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		errorMsg string
+	}{
+		{
+			name:     "Syntax error",
+			template: "{{.Amount",
+			errorMsg: "ошибка в шаблоне tg/payoff.tmpl",
+		},
+		{
+			name:     "Unknown field",
+			template: "{{.Customer}}",
+			errorMsg: "can't evaluate field Customer",
+		},
+		{
+			name:     "Unknown function",
+			template: "{{currency .Amount}}",
+			errorMsg: `function "currency" not defined`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeTemplate(t, dir, "tg/payoff.tmpl", tt.template)
+
+			_, err := sms.NewTemplateRenderer(dir)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorMsg)
+		})
+	}
+}
+
+func writeTemplate(t *testing.T, dir, name, text string) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(text), 0o644))
+}
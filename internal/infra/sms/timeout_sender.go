@@ -0,0 +1,31 @@
+package sms
+
+import (
+	"context"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+)
+
+// TimeoutSender bounds every send so that a slow gateway cannot hang the
+// caller; cancellation of the parent context is still honoured.
+type TimeoutSender struct {
+	next    domain.SMSSender
+	timeout time.Duration
+}
+
+func NewTimeoutSender(next domain.SMSSender, timeout time.Duration) *TimeoutSender {
+	return &TimeoutSender{
+		next:    next,
+		timeout: timeout,
+	}
+}
+
+func (s *TimeoutSender) SendSMS(ctx context.Context, phoneNumber string, message string) (domain.Receipt, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	return s.next.SendSMS(ctx, phoneNumber, message)
+}
+
+var _ domain.SMSSender = (*TimeoutSender)(nil)
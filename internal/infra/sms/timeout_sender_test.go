@@ -0,0 +1,48 @@
+package sms_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/infra/sms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type blockingSender struct{}
+
+func (blockingSender) SendSMS(ctx context.Context, phoneNumber string, message string) (domain.Receipt, error) {
+	<-ctx.Done()
+	return domain.Receipt{}, ctx.Err()
+}
+
+func TestTimeoutSender(t *testing.T) {
+	t.Run("Slow gateway times out", func(t *testing.T) {
+		sender := sms.NewTimeoutSender(blockingSender{}, 10*time.Millisecond)
+
+		_, err := sender.SendSMS(context.Background(), "992001002005", "test")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("Parent cancellation wins", func(t *testing.T) {
+		sender := sms.NewTimeoutSender(blockingSender{}, time.Hour)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := sender.SendSMS(ctx, "992001002005", "test")
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("Receipt is passed through", func(t *testing.T) {
+		sender := sms.NewTimeoutSender(sms.NewConsoleSender(), time.Second)
+
+		receipt, err := sender.SendSMS(context.Background(), "992001002005", "test")
+		require.NoError(t, err)
+		assert.NotEmpty(t, receipt.MessageID)
+		assert.Equal(t, 1, receipt.Segments)
+		assert.False(t, receipt.AcceptedAt.IsZero())
+	})
+}
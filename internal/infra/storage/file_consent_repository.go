@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+)
+
+// FileConsentRepository keeps pending consent requests. Expired requests are
+// dropped whenever another one is saved.
+type FileConsentRepository struct {
+	path string
+	mu   sync.Mutex
+	now  func() time.Time
+}
+
+func NewFileConsentRepository(path string) *FileConsentRepository {
+	return &FileConsentRepository{path: path, now: time.Now}
+}
+
+func (r *FileConsentRepository) Save(request domain.ConsentRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	requests, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	now := r.now()
+	for id, pending := range requests {
+		if now.After(pending.ExpiresAt) {
+			delete(requests, id)
+		}
+	}
+
+	requests[request.ID] = request
+	return r.save(requests)
+}
+
+func (r *FileConsentRepository) FindByID(id string) (domain.ConsentRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	requests, err := r.load()
+	if err != nil {
+		return domain.ConsentRequest{}, err
+	}
+
+	request, ok := requests[id]
+	if !ok {
+		return domain.ConsentRequest{}, fmt.Errorf("%w: %s", domain.ErrConsentNotFound, id)
+	}
+	return request, nil
+}
+
+func (r *FileConsentRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	requests, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	delete(requests, id)
+	return r.save(requests)
+}
+
+func (r *FileConsentRepository) Update(id string, update func(*domain.ConsentRequest) (bool, error)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	requests, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	request, ok := requests[id]
+	if !ok {
+		return fmt.Errorf("%w: %s", domain.ErrConsentNotFound, id)
+	}
+
+	keep, err := update(&request)
+	if keep {
+		requests[id] = request
+	} else {
+		delete(requests, id)
+	}
+
+	if saveErr := r.save(requests); saveErr != nil {
+		return saveErr
+	}
+	return err
+}
+
+func (r *FileConsentRepository) load() (map[string]domain.ConsentRequest, error) {
+	requests := make(map[string]domain.ConsentRequest)
+
+	data, err := os.ReadFile(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return requests, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать запросы подтверждения: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &requests); err != nil {
+		return nil, fmt.Errorf("поврежден файл запросов подтверждения %s: %w", r.path, err)
+	}
+
+	return requests, nil
+}
+
+func (r *FileConsentRepository) save(requests map[string]domain.ConsentRequest) error {
+	data, err := json.MarshalIndent(requests, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := writeFile(r.path, data, 0o600); err != nil {
+		return fmt.Errorf("не удалось сохранить запросы подтверждения: %w", err)
+	}
+	return nil
+}
+
+var _ domain.ConsentRepository = (*FileConsentRepository)(nil)
@@ -0,0 +1,49 @@
+package storage_test
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/infra/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileConsentRepository_UpdateCountsConcurrentAttempts(t *testing.T) {
+	repo := storage.NewFileConsentRepository(filepath.Join(t.TempDir(), "consents.json"))
+
+	product := domain.Product{Type: domain.Smartphone, Price: 1000, PhoneNumber: "+992001002005", PeriodMonths: 3}
+	request := domain.NewConsentRequest("consent-1", "123456", product, 1030, time.Now(), time.Minute, 3)
+	require.NoError(t, repo.Save(request))
+
+	var mismatches, confirmed atomic.Int32
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := repo.Update("consent-1", func(pending *domain.ConsentRequest) (bool, error) {
+				_, err := pending.Verify("000000", time.Now())
+				return errors.Is(err, domain.ErrConsentCodeMismatch), err
+			})
+			if errors.Is(err, domain.ErrConsentCodeMismatch) {
+				mismatches.Add(1)
+			}
+			if err == nil {
+				confirmed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), mismatches.Load(), "only the attempts within the limit are checked")
+	assert.Zero(t, confirmed.Load())
+
+	_, err := repo.FindByID("consent-1")
+	assert.ErrorIs(t, err, domain.ErrConsentNotFound, "an exhausted request is removed")
+}
@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+)
+
+// FileContractRepository keeps the contracts in one JSON file. Writes hold an
+// advisory lock on the file, so that serve and the other commands running
+// as separate processes do not overwrite each other's changes.
+type FileContractRepository struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileContractRepository(path string) *FileContractRepository {
+	return &FileContractRepository{path: path}
+}
+
+func (r *FileContractRepository) Save(contract domain.Contract) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	unlock, err := lockFile(r.path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	contracts, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	replaced := false
+	for i := range contracts {
+		if contracts[i].ID == contract.ID {
+			contracts[i] = contract
+			replaced = true
+			break
+		}
+	}
+
+	if !replaced {
+		contracts = append(contracts, contract)
+	}
+
+	return r.store(contracts)
+}
+
+func (r *FileContractRepository) Update(id string, update func(*domain.Contract) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	unlock, err := lockFile(r.path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	contracts, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	for i := range contracts {
+		if contracts[i].ID != id {
+			continue
+		}
+		if err := update(&contracts[i]); err != nil {
+			return err
+		}
+		return r.store(contracts)
+	}
+
+	return fmt.Errorf("%w: %s", domain.ErrContractNotFound, id)
+}
+
+func (r *FileContractRepository) FindByID(id string) (domain.Contract, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	contracts, err := r.load()
+	if err != nil {
+		return domain.Contract{}, err
+	}
+
+	for _, contract := range contracts {
+		if contract.ID == id {
+			return contract, nil
+		}
+	}
+
+	return domain.Contract{}, fmt.Errorf("%w: %s", domain.ErrContractNotFound, id)
+}
+
+func (r *FileContractRepository) FindAll() ([]domain.Contract, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.load()
+}
+
+func (r *FileContractRepository) load() ([]domain.Contract, error) {
+	data, err := os.ReadFile(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать файл договоров: %w", err)
+	}
+
+	var contracts []domain.Contract
+	if err := json.Unmarshal(data, &contracts); err != nil {
+		return nil, fmt.Errorf("поврежден файл договоров %s: %w", r.path, err)
+	}
+
+	return contracts, nil
+}
+
+func (r *FileContractRepository) store(contracts []domain.Contract) error {
+	data, err := json.MarshalIndent(contracts, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := writeFile(r.path, data, 0o644); err != nil {
+		return fmt.Errorf("не удалось сохранить договоры: %w", err)
+	}
+	return nil
+}
+
+var _ domain.ContractRepository = (*FileContractRepository)(nil)
@@ -0,0 +1,80 @@
+package storage_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/infra/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileContractRepository_UpdateIsAtomic(t *testing.T) {
+	repo := storage.NewFileContractRepository(filepath.Join(t.TempDir(), "contracts.json"))
+
+	product := domain.Product{Type: domain.Smartphone, Price: 1000, PhoneNumber: "+992001002005", PeriodMonths: 3}
+	contract := domain.NewContract("C-1", product, 1030, time.Now())
+	for i := range 20 {
+		contract.RecordNotification(domain.EventReminder, domain.Receipt{MessageID: fmt.Sprintf("msg-%d", i)})
+	}
+	require.NoError(t, repo.Save(contract))
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := repo.Update("C-1", func(contract *domain.Contract) error {
+				contract.UpdateDelivery(fmt.Sprintf("msg-%d", i), domain.DeliveryDelivered, time.Now(), "")
+				return nil
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	stored, err := repo.FindByID("C-1")
+	require.NoError(t, err)
+	for _, notification := range stored.Notifications {
+		assert.Equal(t, domain.DeliveryDelivered, notification.Status, notification.MessageID)
+	}
+
+	err = repo.Update("C-2", func(*domain.Contract) error { return nil })
+	assert.ErrorIs(t, err, domain.ErrContractNotFound)
+}
+
+func TestFileContractRepository_UpdatesFromSeparateInstancesAreKept(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "contracts.json")
+
+	product := domain.Product{Type: domain.Smartphone, Price: 1000, PhoneNumber: "+992001002005", PeriodMonths: 3}
+	contract := domain.NewContract("C-1", product, 1030, time.Now())
+	for i := range 10 {
+		contract.RecordNotification(domain.EventReminder, domain.Receipt{MessageID: fmt.Sprintf("msg-%d", i)})
+	}
+	require.NoError(t, storage.NewFileContractRepository(path).Save(contract))
+
+	// Each instance stands for a separate process: they share only the file.
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := storage.NewFileContractRepository(path).Update("C-1", func(contract *domain.Contract) error {
+				contract.UpdateDelivery(fmt.Sprintf("msg-%d", i), domain.DeliveryDelivered, time.Now(), "")
+				return nil
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	stored, err := storage.NewFileContractRepository(path).FindByID("C-1")
+	require.NoError(t, err)
+	for _, notification := range stored.Notifications {
+		assert.Equal(t, domain.DeliveryDelivered, notification.Status, notification.MessageID)
+	}
+}
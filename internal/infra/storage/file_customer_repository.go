@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/phone"
+)
+
+type FileCustomerRepository struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileCustomerRepository(path string) *FileCustomerRepository {
+	return &FileCustomerRepository{path: path}
+}
+
+func (r *FileCustomerRepository) Save(customer domain.Customer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	customers, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	customer.PhoneNumber = customerKey(customer.PhoneNumber)
+	customers[customer.PhoneNumber] = customer
+
+	data, err := json.MarshalIndent(customers, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := writeFile(r.path, data, 0o644); err != nil {
+		return fmt.Errorf("не удалось сохранить клиентов: %w", err)
+	}
+	return nil
+}
+
+func (r *FileCustomerRepository) FindByPhone(phoneNumber string) (domain.Customer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	customers, err := r.load()
+	if err != nil {
+		return domain.Customer{}, err
+	}
+
+	customer, ok := customers[customerKey(phoneNumber)]
+	if !ok {
+		return domain.Customer{}, fmt.Errorf("%w: %s", domain.ErrCustomerNotFound, phoneNumber)
+	}
+	return customer, nil
+}
+
+func (r *FileCustomerRepository) load() (map[string]domain.Customer, error) {
+	customers := make(map[string]domain.Customer)
+
+	data, err := os.ReadFile(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return customers, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать файл клиентов: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &customers); err != nil {
+		return nil, fmt.Errorf("поврежден файл клиентов %s: %w", r.path, err)
+	}
+
+	normalized := make(map[string]domain.Customer, len(customers))
+	for phoneNumber, customer := range customers {
+		customer.PhoneNumber = customerKey(phoneNumber)
+		normalized[customer.PhoneNumber] = customer
+	}
+	return normalized, nil
+}
+
+// customerKey keys customers by the E.164 number, so that records saved
+// before numbers were normalized (992XXXXXXXXX) are still found.
+func customerKey(phoneNumber string) string {
+	if normalized, err := phone.Normalize(phoneNumber); err == nil {
+		return normalized
+	}
+	return phoneNumber
+}
+
+var _ domain.CustomerRepository = (*FileCustomerRepository)(nil)
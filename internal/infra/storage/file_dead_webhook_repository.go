@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+)
+
+type FileDeadWebhookRepository struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileDeadWebhookRepository(path string) *FileDeadWebhookRepository {
+	return &FileDeadWebhookRepository{path: path}
+}
+
+func (r *FileDeadWebhookRepository) Add(webhook domain.DeadWebhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	webhooks, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	return r.save(append(webhooks, webhook))
+}
+
+func (r *FileDeadWebhookRepository) FindAll() ([]domain.DeadWebhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.load()
+}
+
+func (r *FileDeadWebhookRepository) load() ([]domain.DeadWebhook, error) {
+	var webhooks []domain.DeadWebhook
+
+	data, err := os.ReadFile(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return webhooks, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать недоставленные вебхуки: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &webhooks); err != nil {
+		return nil, fmt.Errorf("поврежден файл недоставленных вебхуков %s: %w", r.path, err)
+	}
+
+	return webhooks, nil
+}
+
+func (r *FileDeadWebhookRepository) save(webhooks []domain.DeadWebhook) error {
+	data, err := json.MarshalIndent(webhooks, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := writeFile(r.path, data, 0o644); err != nil {
+		return fmt.Errorf("не удалось сохранить недоставленные вебхуки: %w", err)
+	}
+	return nil
+}
+
+var _ domain.DeadWebhookRepository = (*FileDeadWebhookRepository)(nil)
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package storage
+
+// lockFile does not lock across processes on this platform; updates are only
+// serialized within the process.
+func lockFile(path string) (func(), error) {
+	return func() {}, nil
+}
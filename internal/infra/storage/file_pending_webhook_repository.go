@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+)
+
+type FilePendingWebhookRepository struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFilePendingWebhookRepository(path string) *FilePendingWebhookRepository {
+	return &FilePendingWebhookRepository{path: path}
+}
+
+func (r *FilePendingWebhookRepository) Add(webhook domain.PendingWebhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	webhooks, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	return r.save(append(webhooks, webhook))
+}
+
+func (r *FilePendingWebhookRepository) FindAll() ([]domain.PendingWebhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.load()
+}
+
+// Update replaces the stored delivery. A delivery removed in the meantime
+// stays removed.
+func (r *FilePendingWebhookRepository) Update(webhook domain.PendingWebhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	webhooks, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	for i := range webhooks {
+		if webhooks[i].ID == webhook.ID && webhooks[i].Subscription == webhook.Subscription {
+			webhooks[i] = webhook
+			return r.save(webhooks)
+		}
+	}
+	return nil
+}
+
+func (r *FilePendingWebhookRepository) Remove(id, subscription string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	webhooks, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	kept := slices.DeleteFunc(webhooks, func(webhook domain.PendingWebhook) bool {
+		return webhook.ID == id && webhook.Subscription == subscription
+	})
+	return r.save(kept)
+}
+
+func (r *FilePendingWebhookRepository) load() ([]domain.PendingWebhook, error) {
+	var webhooks []domain.PendingWebhook
+
+	data, err := os.ReadFile(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return webhooks, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать очередь вебхуков: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &webhooks); err != nil {
+		return nil, fmt.Errorf("поврежден файл очереди вебхуков %s: %w", r.path, err)
+	}
+
+	return webhooks, nil
+}
+
+func (r *FilePendingWebhookRepository) save(webhooks []domain.PendingWebhook) error {
+	data, err := json.MarshalIndent(webhooks, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := writeFile(r.path, data, 0o644); err != nil {
+		return fmt.Errorf("не удалось сохранить очередь вебхуков: %w", err)
+	}
+	return nil
+}
+
+var _ domain.PendingWebhookRepository = (*FilePendingWebhookRepository)(nil)
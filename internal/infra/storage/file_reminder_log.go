@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+)
+
+type FileReminderLog struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileReminderLog(path string) *FileReminderLog {
+	return &FileReminderLog{path: path}
+}
+
+// Claim holds an advisory lock on the log, so that overlapping runs of
+// remind in separate processes cannot both claim the same reminder.
+func (l *FileReminderLog) Claim(key string, at time.Time) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	unlock, err := lockFile(l.path)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	entries, err := l.load()
+	if err != nil {
+		return false, err
+	}
+
+	if _, claimed := entries[key]; claimed {
+		return false, nil
+	}
+
+	entries[key] = at
+	return true, l.save(entries)
+}
+
+func (l *FileReminderLog) Release(key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	unlock, err := lockFile(l.path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	entries, err := l.load()
+	if err != nil {
+		return err
+	}
+
+	delete(entries, key)
+	return l.save(entries)
+}
+
+func (l *FileReminderLog) load() (map[string]time.Time, error) {
+	entries := make(map[string]time.Time)
+
+	data, err := os.ReadFile(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать журнал напоминаний: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("поврежден журнал напоминаний %s: %w", l.path, err)
+	}
+
+	return entries, nil
+}
+
+func (l *FileReminderLog) save(entries map[string]time.Time) error {
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := writeFile(l.path, data, 0o644); err != nil {
+		return fmt.Errorf("не удалось сохранить журнал напоминаний: %w", err)
+	}
+	return nil
+}
+
+var _ domain.ReminderLog = (*FileReminderLog)(nil)
@@ -0,0 +1,40 @@
+package storage_test
+
+import (
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/infra/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileReminderLog_OneClaimWins(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reminders.json")
+
+	// Each instance stands for an overlapping run of remind.
+	var claimed atomic.Int32
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := storage.NewFileReminderLog(path).Claim("C-1/1/due", time.Now())
+			assert.NoError(t, err)
+			if ok {
+				claimed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), claimed.Load())
+
+	log := storage.NewFileReminderLog(path)
+	require.NoError(t, log.Release("C-1/1/due"))
+	ok, err := log.Claim("C-1/1/due", time.Now())
+	require.NoError(t, err)
+	assert.True(t, ok, "a released reminder can be claimed again")
+}
@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+)
+
+type FileSMSOutbox struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileSMSOutbox(path string) *FileSMSOutbox {
+	return &FileSMSOutbox{path: path}
+}
+
+func (o *FileSMSOutbox) Enqueue(message domain.QueuedSMS) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	messages, err := o.load()
+	if err != nil {
+		return err
+	}
+
+	messages[message.ID] = message
+	return o.save(messages)
+}
+
+// Due returns the queued messages whose time has come, oldest first.
+func (o *FileSMSOutbox) Due(now time.Time) ([]domain.QueuedSMS, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	messages, err := o.load()
+	if err != nil {
+		return nil, err
+	}
+
+	var due []domain.QueuedSMS
+	for _, message := range messages {
+		if !message.NotBefore.After(now) {
+			due = append(due, message)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].NotBefore.Before(due[j].NotBefore)
+	})
+	return due, nil
+}
+
+func (o *FileSMSOutbox) Remove(id string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	messages, err := o.load()
+	if err != nil {
+		return err
+	}
+
+	delete(messages, id)
+	return o.save(messages)
+}
+
+func (o *FileSMSOutbox) load() (map[string]domain.QueuedSMS, error) {
+	messages := make(map[string]domain.QueuedSMS)
+
+	data, err := os.ReadFile(o.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return messages, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать очередь смс: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return nil, fmt.Errorf("повреждена очередь смс %s: %w", o.path, err)
+	}
+
+	return messages, nil
+}
+
+func (o *FileSMSOutbox) save(messages map[string]domain.QueuedSMS) error {
+	data, err := json.MarshalIndent(messages, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := writeFile(o.path, data, 0o644); err != nil {
+		return fmt.Errorf("не удалось сохранить очередь смс: %w", err)
+	}
+	return nil
+}
+
+var _ domain.SMSOutbox = (*FileSMSOutbox)(nil)
@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+)
+
+// FileSMSSendLog keeps send times for the retention period only, which must
+// cover the longest rate limit window in use.
+type FileSMSSendLog struct {
+	path      string
+	retention time.Duration
+	mu        sync.Mutex
+}
+
+func NewFileSMSSendLog(path string, retention time.Duration) *FileSMSSendLog {
+	return &FileSMSSendLog{
+		path:      path,
+		retention: retention,
+	}
+}
+
+func (l *FileSMSSendLog) SentSince(phoneNumber string, since time.Time) ([]time.Time, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entries, err := l.load()
+	if err != nil {
+		return nil, err
+	}
+
+	var sent []time.Time
+	for _, sentAt := range entries[phoneNumber] {
+		if sentAt.After(since) {
+			sent = append(sent, sentAt)
+		}
+	}
+	return sent, nil
+}
+
+func (l *FileSMSSendLog) Record(phoneNumber string, sentAt time.Time) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entries, err := l.load()
+	if err != nil {
+		return err
+	}
+
+	cutoff := sentAt.Add(-l.retention)
+	for phone, times := range entries {
+		var kept []time.Time
+		for _, t := range times {
+			if t.After(cutoff) {
+				kept = append(kept, t)
+			}
+		}
+		if len(kept) == 0 {
+			delete(entries, phone)
+			continue
+		}
+		entries[phone] = kept
+	}
+	entries[phoneNumber] = append(entries[phoneNumber], sentAt)
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := writeFile(l.path, data, 0o644); err != nil {
+		return fmt.Errorf("не удалось сохранить журнал смс: %w", err)
+	}
+	return nil
+}
+
+func (l *FileSMSSendLog) load() (map[string][]time.Time, error) {
+	entries := make(map[string][]time.Time)
+
+	data, err := os.ReadFile(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать журнал смс: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("поврежден журнал смс %s: %w", l.path, err)
+	}
+
+	return entries, nil
+}
+
+var _ domain.SMSSendLog = (*FileSMSSendLog)(nil)
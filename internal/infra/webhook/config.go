@@ -0,0 +1,96 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/jsonconf"
+)
+
+const (
+	defaultMaxAttempts    = 5
+	defaultInitialBackoff = time.Second
+	defaultMaxBackoff     = time.Minute
+	defaultTimeout        = 10 * time.Second
+)
+
+// Subscription is an endpoint interested in some of the events. An empty
+// event list subscribes to all of them.
+type Subscription struct {
+	Name      string   `json:"name"`
+	URL       string   `json:"url"`
+	Secret    string   `json:"secret"`
+	SecretEnv string   `json:"secret_env"`
+	Events    []string `json:"events"`
+}
+
+func (s Subscription) Wants(event string) bool {
+	return len(s.Events) == 0 || slices.Contains(s.Events, event) || slices.Contains(s.Events, "*")
+}
+
+type Config struct {
+	Subscriptions  []Subscription    `json:"subscriptions"`
+	MaxAttempts    int               `json:"max_attempts"`
+	InitialBackoff jsonconf.Duration `json:"initial_backoff"`
+	MaxBackoff     jsonconf.Duration `json:"max_backoff"`
+	Timeout        jsonconf.Duration `json:"timeout"`
+}
+
+// LoadConfig reads the subscriptions from a JSON file and resolves secrets
+// given through environment variables.
+func LoadConfig(path string) (Config, error) {
+	var config Config
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, fmt.Errorf("не удалось прочитать настройки вебхуков: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("не удалось разобрать настройки вебхуков: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	for i, subscription := range config.Subscriptions {
+		if subscription.Name == "" {
+			return config, fmt.Errorf("у вебхука %d не указано имя", i+1)
+		}
+		if seen[subscription.Name] {
+			return config, fmt.Errorf("вебхук %s указан дважды", subscription.Name)
+		}
+		seen[subscription.Name] = true
+
+		if u, err := url.Parse(subscription.URL); err != nil || u.Scheme == "" || u.Host == "" {
+			return config, fmt.Errorf("у вебхука %s неверный адрес: %s", subscription.Name, subscription.URL)
+		}
+
+		if subscription.SecretEnv != "" {
+			config.Subscriptions[i].Secret = os.Getenv(subscription.SecretEnv)
+		}
+		if config.Subscriptions[i].Secret == "" {
+			return config, fmt.Errorf("у вебхука %s не задан секрет для подписи", subscription.Name)
+		}
+	}
+
+	return config, nil
+}
+
+func (c Config) withDefaults() Config {
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = defaultMaxAttempts
+	}
+	if c.InitialBackoff <= 0 {
+		c.InitialBackoff = jsonconf.Duration(defaultInitialBackoff)
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = jsonconf.Duration(defaultMaxBackoff)
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = jsonconf.Duration(defaultTimeout)
+	}
+	return c
+}
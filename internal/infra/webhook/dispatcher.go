@@ -0,0 +1,313 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+)
+
+// permanentError marks responses that retrying cannot fix, such as a
+// rejected signature.
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Dispatcher posts signed event payloads to the subscribed endpoints. An
+// event gets one attempt when it happens; failed deliveries are queued and
+// retried with exponential backoff by Retry, and end up in the dead-letter
+// list once the attempts run out.
+type Dispatcher struct {
+	config      Config
+	client      *http.Client
+	pending     domain.PendingWebhookRepository
+	deadLetters domain.DeadWebhookRepository
+	logger      *log.Logger
+	now         func() time.Time
+}
+
+func NewDispatcher(
+	config Config,
+	pending domain.PendingWebhookRepository,
+	deadLetters domain.DeadWebhookRepository,
+	logger *log.Logger,
+) *Dispatcher {
+	return &Dispatcher{
+		config:      config.withDefaults(),
+		client:      &http.Client{},
+		pending:     pending,
+		deadLetters: deadLetters,
+		logger:      logger,
+		now:         time.Now,
+	}
+}
+
+func (d *Dispatcher) Subscriptions() []Subscription {
+	return d.config.Subscriptions
+}
+
+// Events lists the events sent to webhook subscribers.
+var Events = []string{
+	domain.EventNameInstallmentConfirmed,
+	domain.EventNamePaymentReceived,
+	domain.EventNameInstallmentCancelled,
+	domain.EventNameNotificationFailed,
+}
+
+// Handle makes the first delivery attempt for each subscriber. It is meant
+// to be subscribed asynchronously and never fails: deliveries that can be
+// retried are queued for Retry, the rest go to the dead-letter list.
+func (d *Dispatcher) Handle(ctx context.Context, event domain.Event) error {
+	payload := NewPayload(event)
+	body, err := json.Marshal(payload)
+	if err != nil {
+		d.logger.Printf("не удалось подготовить вебхук %s: %v", payload.ID, err)
+		return nil
+	}
+
+	for _, subscription := range d.config.Subscriptions {
+		if !subscription.Wants(payload.Type) {
+			continue
+		}
+
+		delivery := domain.PendingWebhook{
+			ID:           payload.ID,
+			Subscription: subscription.Name,
+			URL:          subscription.URL,
+			Event:        payload.Type,
+			Payload:      string(body),
+		}
+		if err := d.attempt(ctx, subscription, &delivery); err != nil {
+			d.fail(delivery, err, false)
+		}
+	}
+	return nil
+}
+
+// RetryResult counts the outcome of one pass over the queued deliveries.
+type RetryResult struct {
+	Delivered int
+	Requeued  int
+	Dead      int
+	Waiting   int
+}
+
+// Retry makes the next attempt for every queued delivery that is due.
+func (d *Dispatcher) Retry(ctx context.Context) (RetryResult, error) {
+	var result RetryResult
+
+	deliveries, err := d.pending.FindAll()
+	if err != nil {
+		return result, err
+	}
+
+	now := d.now()
+	for _, delivery := range deliveries {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if delivery.NextAttemptAt.After(now) {
+			result.Waiting++
+			continue
+		}
+
+		subscription, ok := d.subscription(delivery.Subscription)
+		if !ok {
+			delivery.Attempts++
+			d.fail(delivery, permanentError{fmt.Errorf("%w: %s", domain.ErrWebhookNotFound, delivery.Subscription)}, true)
+			result.Dead++
+			continue
+		}
+
+		err := d.attempt(ctx, subscription, &delivery)
+		switch {
+		case err == nil:
+			if err := d.pending.Remove(delivery.ID, delivery.Subscription); err != nil {
+				return result, err
+			}
+			result.Delivered++
+		case d.fail(delivery, err, true):
+			result.Requeued++
+		default:
+			result.Dead++
+		}
+	}
+	return result, nil
+}
+
+// Run retries the queued deliveries every interval until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if _, err := d.Retry(ctx); err != nil && ctx.Err() == nil {
+			d.logger.Printf("не удалось повторить отправку вебхуков: %v", err)
+		}
+	}
+}
+
+// TestResult is the outcome of a test delivery to one subscription.
+type TestResult struct {
+	Subscription string
+	Err          error
+}
+
+// Test sends a single webhook.test event, without retries, to the named
+// subscription or to all of them when name is empty.
+func (d *Dispatcher) Test(ctx context.Context, name string) ([]TestResult, error) {
+	var results []TestResult
+
+	for _, subscription := range d.config.Subscriptions {
+		if name != "" && subscription.Name != name {
+			continue
+		}
+
+		payload := newTestPayload(subscription.Name, d.now())
+		body, err := json.Marshal(payload)
+		if err == nil {
+			err = d.deliver(ctx, subscription, payload.ID, payload.Type, body)
+		}
+		results = append(results, TestResult{Subscription: subscription.Name, Err: err})
+	}
+
+	if name != "" && len(results) == 0 {
+		return nil, fmt.Errorf("%w: %s", domain.ErrWebhookNotFound, name)
+	}
+	return results, nil
+}
+
+func (d *Dispatcher) Pending() ([]domain.PendingWebhook, error) {
+	return d.pending.FindAll()
+}
+
+func (d *Dispatcher) DeadLetters() ([]domain.DeadWebhook, error) {
+	return d.deadLetters.FindAll()
+}
+
+func (d *Dispatcher) subscription(name string) (Subscription, bool) {
+	for _, subscription := range d.config.Subscriptions {
+		if subscription.Name == name {
+			return subscription, true
+		}
+	}
+	return Subscription{}, false
+}
+
+// attempt makes one delivery attempt and, on failure, schedules the next one.
+func (d *Dispatcher) attempt(ctx context.Context, subscription Subscription, delivery *domain.PendingWebhook) error {
+	delivery.Attempts++
+	err := d.deliver(ctx, subscription, delivery.ID, delivery.Event, []byte(delivery.Payload))
+	if err != nil {
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = d.now().Add(d.backoff(delivery.Attempts))
+	}
+	return err
+}
+
+// backoff is the pause after the given number of failed attempts.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	backoff := time.Duration(d.config.InitialBackoff)
+	for range attempts - 1 {
+		backoff = min(backoff*2, time.Duration(d.config.MaxBackoff))
+	}
+	return backoff
+}
+
+// fail queues the delivery for another attempt, or buries it when retrying
+// cannot help. It reports whether the delivery stays queued.
+func (d *Dispatcher) fail(delivery domain.PendingWebhook, cause error, queued bool) bool {
+	var permanent permanentError
+	if delivery.Attempts < d.config.MaxAttempts && !errors.As(cause, &permanent) {
+		d.logger.Printf("вебхук %s (%s): попытка %d не удалась: %v, повтор через %s",
+			delivery.Subscription, delivery.Event, delivery.Attempts, cause, d.backoff(delivery.Attempts))
+
+		var err error
+		if queued {
+			err = d.pending.Update(delivery)
+		} else {
+			err = d.pending.Add(delivery)
+		}
+		if err == nil {
+			return true
+		}
+		d.logger.Printf("не удалось поставить вебхук %s в очередь: %v", delivery.ID, err)
+	}
+
+	d.bury(delivery, cause)
+	if queued {
+		if err := d.pending.Remove(delivery.ID, delivery.Subscription); err != nil {
+			d.logger.Printf("не удалось убрать вебхук %s из очереди: %v", delivery.ID, err)
+		}
+	}
+	return false
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, subscription Subscription, id, event string, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(d.config.Timeout))
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return permanentError{err}
+	}
+
+	timestamp := d.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderID, id)
+	req.Header.Set(HeaderEvent, event)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(subscription.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode <= 299:
+		return nil
+	case resp.StatusCode >= 500, resp.StatusCode == http.StatusRequestTimeout, resp.StatusCode == http.StatusTooManyRequests:
+		return fmt.Errorf("получатель ответил %s", resp.Status)
+	default:
+		return permanentError{fmt.Errorf("получатель ответил %s", resp.Status)}
+	}
+}
+
+func (d *Dispatcher) bury(delivery domain.PendingWebhook, cause error) {
+	d.logger.Printf("вебхук %s (%s) не доставлен после %d попыток: %v",
+		delivery.Subscription, delivery.Event, delivery.Attempts, cause)
+
+	err := d.deadLetters.Add(domain.DeadWebhook{
+		ID:           delivery.ID,
+		Subscription: delivery.Subscription,
+		URL:          delivery.URL,
+		Event:        delivery.Event,
+		Payload:      delivery.Payload,
+		Attempts:     delivery.Attempts,
+		LastError:    cause.Error(),
+		FailedAt:     d.now(),
+	})
+	if err != nil {
+		d.logger.Printf("не удалось сохранить недоставленный вебхук %s: %v", delivery.ID, err)
+	}
+}
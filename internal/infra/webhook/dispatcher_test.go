@@ -0,0 +1,248 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/infra/webhook"
+	"github.com/icoder-new/installment-cli/internal/jsonconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "s3cret"
+
+type memoryDeadLetters struct {
+	webhooks []domain.DeadWebhook
+}
+
+func (m *memoryDeadLetters) Add(webhook domain.DeadWebhook) error {
+	m.webhooks = append(m.webhooks, webhook)
+	return nil
+}
+
+func (m *memoryDeadLetters) FindAll() ([]domain.DeadWebhook, error) {
+	return m.webhooks, nil
+}
+
+type memoryPending struct {
+	webhooks []domain.PendingWebhook
+}
+
+func (m *memoryPending) Add(webhook domain.PendingWebhook) error {
+	m.webhooks = append(m.webhooks, webhook)
+	return nil
+}
+
+func (m *memoryPending) FindAll() ([]domain.PendingWebhook, error) {
+	return slices.Clone(m.webhooks), nil
+}
+
+func (m *memoryPending) Update(webhook domain.PendingWebhook) error {
+	for i := range m.webhooks {
+		if m.webhooks[i].ID == webhook.ID && m.webhooks[i].Subscription == webhook.Subscription {
+			m.webhooks[i] = webhook
+		}
+	}
+	return nil
+}
+
+func (m *memoryPending) Remove(id, subscription string) error {
+	m.webhooks = slices.DeleteFunc(m.webhooks, func(webhook domain.PendingWebhook) bool {
+		return webhook.ID == id && webhook.Subscription == subscription
+	})
+	return nil
+}
+
+func newTestContract() domain.Contract {
+	product := domain.Product{
+		Type:         domain.Smartphone,
+		Price:        1000,
+		PhoneNumber:  "+992001002005",
+		PeriodMonths: 3,
+	}
+	return domain.NewContract("C-1", product, 1030, time.Now())
+}
+
+func newTestDispatcher(url string, events []string, pending domain.PendingWebhookRepository, deadLetters domain.DeadWebhookRepository) *webhook.Dispatcher {
+	config := webhook.Config{
+		Subscriptions: []webhook.Subscription{
+			{Name: "crm", URL: url, Secret: testSecret, Events: events},
+		},
+		MaxAttempts:    3,
+		InitialBackoff: jsonconf.Duration(time.Millisecond),
+	}
+	return webhook.NewDispatcher(config, pending, deadLetters, log.New(io.Discard, "", 0))
+}
+
+func TestDispatcher_HandleSignsPayload(t *testing.T) {
+	var received webhook.Payload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		err := webhook.Verify(testSecret, r.Header.Get(webhook.HeaderTimestamp),
+			r.Header.Get(webhook.HeaderSignature), body, time.Minute, time.Now())
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		assert.Equal(t, domain.EventNameInstallmentConfirmed, r.Header.Get(webhook.HeaderEvent))
+		_ = json.Unmarshal(body, &received)
+	}))
+	defer server.Close()
+
+	deadLetters := new(memoryDeadLetters)
+	dispatcher := newTestDispatcher(server.URL, nil, new(memoryPending), deadLetters)
+
+	_ = dispatcher.Handle(context.Background(), domain.InstallmentConfirmed{Contract: newTestContract()})
+
+	assert.Empty(t, deadLetters.webhooks)
+	assert.Equal(t, domain.EventNameInstallmentConfirmed, received.Type)
+	assert.Regexp(t, `^evt_[0-9a-f]{16}$`, received.ID)
+
+	data, ok := received.Data.(map[string]any)
+	require.True(t, ok)
+	contract := data["contract"].(map[string]any)
+	assert.Equal(t, "C-1", contract["id"])
+	assert.Equal(t, 1030.0, contract["balance"])
+}
+
+func TestDispatcher_HandleRetries(t *testing.T) {
+	tests := []struct {
+		name             string
+		statuses         []int
+		expectedRequests int32
+		expectDead       bool
+	}{
+		{
+			name:             "Succeeds after server errors",
+			statuses:         []int{http.StatusBadGateway, http.StatusTooManyRequests, http.StatusOK},
+			expectedRequests: 3,
+		},
+		{
+			name:             "Dead letter after final failure",
+			statuses:         []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError},
+			expectedRequests: 3,
+			expectDead:       true,
+		},
+		{
+			name:             "Client error is not retried",
+			statuses:         []int{http.StatusUnauthorized},
+			expectedRequests: 1,
+			expectDead:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := requests.Add(1)
+				w.WriteHeader(tt.statuses[min(int(n), len(tt.statuses))-1])
+			}))
+			defer server.Close()
+
+			pending := new(memoryPending)
+			deadLetters := new(memoryDeadLetters)
+			dispatcher := newTestDispatcher(server.URL, nil, pending, deadLetters)
+
+			_ = dispatcher.Handle(context.Background(), domain.InstallmentCancelled{Contract: newTestContract()})
+			assert.Equal(t, int32(1), requests.Load(), "only one attempt is made when the event happens")
+
+			for len(pending.webhooks) > 0 {
+				time.Sleep(10 * time.Millisecond)
+				_, err := dispatcher.Retry(context.Background())
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.expectedRequests, requests.Load())
+			if !tt.expectDead {
+				assert.Empty(t, deadLetters.webhooks)
+				return
+			}
+
+			require.Len(t, deadLetters.webhooks, 1)
+			dead := deadLetters.webhooks[0]
+			assert.Equal(t, "crm", dead.Subscription)
+			assert.Equal(t, domain.EventNameInstallmentCancelled, dead.Event)
+			assert.Equal(t, int(tt.expectedRequests), dead.Attempts)
+			assert.Contains(t, dead.Payload, `"installment.cancelled"`)
+		})
+	}
+}
+
+func TestDispatcher_RetryWaitsForBackoff(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	pending := new(memoryPending)
+	config := webhook.Config{
+		Subscriptions:  []webhook.Subscription{{Name: "crm", URL: server.URL, Secret: testSecret}},
+		InitialBackoff: jsonconf.Duration(time.Hour),
+	}
+	dispatcher := webhook.NewDispatcher(config, pending, new(memoryDeadLetters), log.New(io.Discard, "", 0))
+
+	_ = dispatcher.Handle(context.Background(), domain.InstallmentConfirmed{Contract: newTestContract()})
+	require.Len(t, pending.webhooks, 1)
+	assert.Equal(t, 1, pending.webhooks[0].Attempts)
+	assert.Contains(t, pending.webhooks[0].LastError, "503")
+
+	result, err := dispatcher.Retry(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, webhook.RetryResult{Waiting: 1}, result)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestDispatcher_HandleSkipsUnsubscribedEvents(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer server.Close()
+
+	dispatcher := newTestDispatcher(server.URL, []string{domain.EventNamePaymentReceived}, new(memoryPending), new(memoryDeadLetters))
+
+	_ = dispatcher.Handle(context.Background(), domain.InstallmentConfirmed{Contract: newTestContract()})
+	assert.Zero(t, requests.Load())
+
+	contract := newTestContract()
+	payment, err := contract.AddPayment(100, time.Now())
+	require.NoError(t, err)
+	_ = dispatcher.Handle(context.Background(), domain.PaymentReceived{Contract: contract, Payment: payment})
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestDispatcher_Test(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "webhook.test", r.Header.Get(webhook.HeaderEvent))
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	deadLetters := new(memoryDeadLetters)
+	dispatcher := newTestDispatcher(server.URL, []string{domain.EventNamePaymentReceived}, new(memoryPending), deadLetters)
+
+	results, err := dispatcher.Test(context.Background(), "")
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "crm", results[0].Subscription)
+	assert.ErrorContains(t, results[0].Err, "503")
+	assert.Empty(t, deadLetters.webhooks)
+
+	_, err = dispatcher.Test(context.Background(), "billing")
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+}
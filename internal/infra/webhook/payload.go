@@ -0,0 +1,128 @@
+package webhook
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"math"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+)
+
+const eventNameTest = "webhook.test"
+
+type Payload struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       any       `json:"data"`
+}
+
+type contractData struct {
+	ID           string     `json:"id"`
+	Status       string     `json:"status"`
+	Product      string     `json:"product"`
+	Price        float64    `json:"price"`
+	PeriodMonths int        `json:"period_months"`
+	PhoneNumber  string     `json:"phone_number"`
+	TotalPayment float64    `json:"total_payment"`
+	TotalPaid    float64    `json:"total_paid"`
+	Balance      float64    `json:"balance"`
+	CreatedAt    time.Time  `json:"created_at"`
+	CancelledAt  *time.Time `json:"cancelled_at,omitempty"`
+	CancelReason string     `json:"cancel_reason,omitempty"`
+	Refund       float64    `json:"refund,omitempty"`
+}
+
+type paymentData struct {
+	Amount float64   `json:"amount"`
+	PaidAt time.Time `json:"paid_at"`
+}
+
+type eventData struct {
+	Contract contractData `json:"contract"`
+	Payment  *paymentData `json:"payment,omitempty"`
+	PaidOff  bool         `json:"paid_off,omitempty"`
+}
+
+type notificationFailedData struct {
+	ContractID   string `json:"contract_id"`
+	PhoneNumber  string `json:"phone_number"`
+	MessageEvent string `json:"message_event"`
+	Error        string `json:"error"`
+}
+
+type testData struct {
+	Subscription string `json:"subscription"`
+}
+
+func NewPayload(event domain.Event) Payload {
+	payload := Payload{
+		ID:         "evt_" + randomHex(),
+		Type:       event.EventName(),
+		OccurredAt: event.OccurredAt(),
+	}
+
+	switch event := event.(type) {
+	case domain.InstallmentConfirmed:
+		payload.Data = eventData{Contract: newContractData(event.Contract)}
+	case domain.InstallmentCancelled:
+		payload.Data = eventData{Contract: newContractData(event.Contract)}
+	case domain.PaymentReceived:
+		payload.Data = eventData{
+			Contract: newContractData(event.Contract),
+			Payment:  &paymentData{Amount: money(event.Payment.Amount), PaidAt: event.Payment.PaidAt},
+			PaidOff:  event.Contract.IsPaidOff(),
+		}
+	case domain.NotificationFailed:
+		payload.Data = notificationFailedData{
+			ContractID:   event.ContractID,
+			PhoneNumber:  event.PhoneNumber,
+			MessageEvent: string(event.Event),
+			Error:        event.Error,
+		}
+	}
+
+	return payload
+}
+
+func newTestPayload(subscription string, now time.Time) Payload {
+	return Payload{
+		ID:         "evt_" + randomHex(),
+		Type:       eventNameTest,
+		OccurredAt: now,
+		Data:       testData{Subscription: subscription},
+	}
+}
+
+func newContractData(contract domain.Contract) contractData {
+	data := contractData{
+		ID:           contract.ID,
+		Status:       string(contract.Status),
+		Product:      string(contract.Product.Type),
+		Price:        money(contract.Product.Price),
+		PeriodMonths: contract.Product.PeriodMonths,
+		PhoneNumber:  contract.Product.PhoneNumber,
+		TotalPayment: money(contract.TotalPayment),
+		TotalPaid:    money(contract.TotalPaid()),
+		Balance:      money(contract.Balance()),
+		CreatedAt:    contract.CreatedAt,
+		CancelReason: contract.CancelReason,
+		Refund:       money(contract.Refund),
+	}
+	if !contract.CancelledAt.IsZero() {
+		cancelledAt := contract.CancelledAt
+		data.CancelledAt = &cancelledAt
+	}
+	return data
+}
+
+func money(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+func randomHex() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
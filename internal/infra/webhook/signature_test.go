@@ -0,0 +1,51 @@
+package webhook_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/infra/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	// Reference value: printf '1700000000.{}' | openssl dgst -sha256 -hmac s3cret
+	assert.Equal(t,
+		"sha256=97926816e98fbb41ccb1673225ff29a2f35369099990e1b1561651e7bd097ebf",
+		webhook.Sign("s3cret", 1700000000, []byte("{}")))
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"type":"installment.created"}`)
+	signature := webhook.Sign(testSecret, now.Unix(), body)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		signature string
+		body      []byte
+		now       time.Time
+		expected  error
+	}{
+		{name: "Valid", secret: testSecret, timestamp: timestamp, signature: signature, body: body, now: now},
+		{name: "Wrong secret", secret: "other", timestamp: timestamp, signature: signature, body: body, now: now, expected: webhook.ErrInvalidSignature},
+		{name: "Tampered body", secret: testSecret, timestamp: timestamp, signature: signature, body: []byte(`{}`), now: now, expected: webhook.ErrInvalidSignature},
+		{name: "Stale timestamp", secret: testSecret, timestamp: timestamp, signature: signature, body: body, now: now.Add(10 * time.Minute), expected: webhook.ErrStaleTimestamp},
+		{name: "Bad timestamp", secret: testSecret, timestamp: "yesterday", signature: signature, body: body, now: now, expected: webhook.ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := webhook.Verify(tt.secret, tt.timestamp, tt.signature, tt.body, 5*time.Minute, tt.now)
+			if tt.expected == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+// Package jsonconf holds the value types shared by the JSON settings files.
+package jsonconf
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Duration reads durations such as "30s" from JSON.
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	*d = Duration(duration)
+	return nil
+}
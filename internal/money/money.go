@@ -0,0 +1,193 @@
+// Package money parses amounts typed by staff and formats amounts for
+// people. Amounts are in somoni with at most two decimal places.
+package money
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrInvalidFormat = errors.New("неверный формат суммы, введите, например, 1500 или 1 500,50")
+	ErrTooPrecise    = errors.New("в сумме не может быть больше двух знаков после запятой")
+)
+
+// Locale is how amounts are written in a language: the separator between
+// groups of thousands and the one before the decimals.
+type Locale struct {
+	Group   string
+	Decimal string
+}
+
+// Format writes amount rounded to two decimal places with grouped thousands,
+// e.g. "1 030,00".
+func (l Locale) Format(amount float64) string {
+	cents := int64(math.Round(math.Abs(amount) * 100))
+	whole := strconv.FormatInt(cents/100, 10)
+
+	var b strings.Builder
+	if amount < 0 && cents != 0 {
+		b.WriteByte('-')
+	}
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(l.Group)
+		}
+		b.WriteRune(digit)
+	}
+	b.WriteString(l.Decimal)
+	b.WriteString(strconv.FormatInt(cents%100+100, 10)[1:])
+	return b.String()
+}
+
+// Parse reads an amount in any of the ways it is commonly written: "1500",
+// "1 500,50", "1.500", "1,500.50" or "1500 сомони" when "сомони" is one of
+// the currencies. A single dot followed by exactly three digits separates
+// thousands, since an amount never has three decimals; otherwise the last dot
+// or comma comes before the decimals. A lone comma always does: "12,345" may
+// well mean 12.345, so it is rejected as too precise rather than read as
+// 12345. Commas group thousands only when repeated or before a dot.
+func Parse(input string, currencies ...string) (float64, error) {
+	text := stripCurrency(strings.TrimSpace(input), currencies)
+
+	negative := false
+	if rest, ok := strings.CutPrefix(text, "-"); ok {
+		negative, text = true, rest
+	} else {
+		text = strings.TrimPrefix(text, "+")
+	}
+
+	runs, separators, err := tokenize(text)
+	if err != nil {
+		return 0, err
+	}
+
+	decimals := ""
+	if last := len(separators) - 1; last >= 0 && isDecimalSeparator(runs, separators) {
+		decimals, runs, separators = runs[last+1], runs[:last+1], separators[:last]
+		if len(decimals) > 2 {
+			return 0, ErrTooPrecise
+		}
+	}
+
+	if err := checkGroups(runs, separators); err != nil {
+		return 0, err
+	}
+
+	whole, err := strconv.ParseInt(strings.Join(runs, ""), 10, 64)
+	if err != nil {
+		return 0, ErrInvalidFormat
+	}
+	cents := whole * 100
+	if decimals != "" {
+		fraction, _ := strconv.ParseInt((decimals + "0")[:2], 10, 64)
+		cents += fraction
+	}
+	if cents/100 != whole {
+		return 0, ErrInvalidFormat
+	}
+
+	amount := float64(cents) / 100
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+// stripCurrency removes a currency name before or after the amount.
+func stripCurrency(text string, currencies []string) string {
+	for _, currency := range currencies {
+		if currency == "" || len(currency) > len(text) {
+			continue
+		}
+		if strings.EqualFold(text[len(text)-len(currency):], currency) {
+			return strings.TrimSpace(text[:len(text)-len(currency)])
+		}
+		if strings.EqualFold(text[:len(currency)], currency) {
+			return strings.TrimSpace(text[len(currency):])
+		}
+	}
+	return text
+}
+
+// tokenize splits text into runs of digits and the separators between them.
+// Spaces and apostrophes are all returned as a space.
+func tokenize(text string) ([]string, []rune, error) {
+	var (
+		runs       []string
+		separators []rune
+		current    strings.Builder
+	)
+	for _, r := range text {
+		switch {
+		case r >= '0' && r <= '9':
+			current.WriteRune(r)
+			continue
+		case r == '.' || r == ',':
+		case unicode.IsSpace(r) || r == '\'' || r == '’':
+			r = ' '
+		default:
+			return nil, nil, ErrInvalidFormat
+		}
+
+		if current.Len() == 0 {
+			return nil, nil, ErrInvalidFormat
+		}
+		runs = append(runs, current.String())
+		separators = append(separators, r)
+		current.Reset()
+	}
+
+	if current.Len() == 0 {
+		return nil, nil, ErrInvalidFormat
+	}
+	return append(runs, current.String()), separators, nil
+}
+
+// isDecimalSeparator reports whether the last separator comes before the
+// decimals rather than between thousands.
+func isDecimalSeparator(runs []string, separators []rune) bool {
+	last := separators[len(separators)-1]
+	if last == ' ' {
+		return false
+	}
+	if len(runs[len(runs)-1]) != 3 || len(runs[0]) > 3 {
+		return true
+	}
+
+	// "1.500", "1.500.000" and "1,500,000" group thousands; "1 500,000",
+	// "12,345" and "0.500" do not.
+	repeated, mixed := false, false
+	for _, separator := range separators[:len(separators)-1] {
+		if separator == last {
+			repeated = true
+		} else {
+			mixed = true
+		}
+	}
+	if repeated || mixed {
+		return !repeated
+	}
+	return last == ',' || runs[0] == "0"
+}
+
+// checkGroups makes sure thousands separators, if any, are used one way and
+// split the number into groups of three digits.
+func checkGroups(runs []string, separators []rune) error {
+	if len(separators) == 0 {
+		return nil
+	}
+
+	if len(runs[0]) > 3 || runs[0][0] == '0' {
+		return ErrInvalidFormat
+	}
+	for i, run := range runs[1:] {
+		if len(run) != 3 || separators[i] != separators[0] {
+			return ErrInvalidFormat
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package money_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/icoder-new/installment-cli/internal/money"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		amount float64
+		err    error
+	}{
+		{name: "plain", input: "1500", amount: 1500},
+		{name: "dot decimals", input: "1500.5", amount: 1500.5},
+		{name: "comma decimals", input: "1500,50", amount: 1500.5},
+		{name: "space groups", input: "1 500,50", amount: 1500.5},
+		{name: "no-break space groups", input: "1 500 000", amount: 1500000},
+		{name: "apostrophe groups", input: "1'500", amount: 1500},
+		{name: "dot groups", input: "1.500", amount: 1500},
+		{name: "repeated comma groups", input: "1,500,000", amount: 1500000},
+		{name: "english", input: "1,500.50", amount: 1500.5},
+		{name: "european", input: "1.500.000,25", amount: 1500000.25},
+		{name: "currency suffix", input: "1500 сомони", amount: 1500},
+		{name: "currency in capitals", input: "1 500 СОМОНӢ", amount: 1500},
+		{name: "currency prefix", input: "TJS 99.9", amount: 99.9},
+		{name: "surrounding spaces", input: "  250  ", amount: 250},
+		{name: "negative", input: "-10", amount: -10},
+		{name: "three decimals", input: "1500,555", err: money.ErrTooPrecise},
+		{name: "three decimals after zero", input: "0.500", err: money.ErrTooPrecise},
+		{name: "lone comma before three digits", input: "12,345", err: money.ErrTooPrecise},
+		{name: "lone comma after zero", input: "0,500", err: money.ErrTooPrecise},
+		{name: "three decimals after groups", input: "1 500,000", err: money.ErrTooPrecise},
+		{name: "four decimals", input: "1.5000", err: money.ErrTooPrecise},
+		{name: "uneven groups", input: "12.34.56", err: money.ErrInvalidFormat},
+		{name: "mixed group separators", input: "1 500.000.000", err: money.ErrInvalidFormat},
+		{name: "long first group", input: "1500 000", err: money.ErrInvalidFormat},
+		{name: "trailing separator", input: "1500.", err: money.ErrInvalidFormat},
+		{name: "exponent", input: "1e3", err: money.ErrInvalidFormat},
+		{name: "unknown currency", input: "1500 руб", err: money.ErrInvalidFormat},
+		{name: "empty", input: "", err: money.ErrInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, err := money.Parse(tt.input, "сомони", "сомонӣ", "TJS")
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.amount, amount)
+		})
+	}
+}
+
+func TestLocaleFormat(t *testing.T) {
+	russian := money.Locale{Group: " ", Decimal: ","}
+	english := money.Locale{Group: ",", Decimal: "."}
+
+	tests := []struct {
+		amount  float64
+		russian string
+		english string
+	}{
+		{amount: 0, russian: "0,00", english: "0.00"},
+		{amount: 30, russian: "30,00", english: "30.00"},
+		{amount: 999.999, russian: "1 000,00", english: "1,000.00"},
+		{amount: 1030.5, russian: "1 030,50", english: "1,030.50"},
+		{amount: 1234567.89, russian: "1 234 567,89", english: "1,234,567.89"},
+		{amount: -45.1, russian: "-45,10", english: "-45.10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.english, func(t *testing.T) {
+			assert.Equal(t, tt.russian, russian.Format(tt.amount))
+			assert.Equal(t, tt.english, english.Format(tt.amount))
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package money
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// RussianWords writes amount the way agreements state it: somoni in words
+// and dirams in digits, e.g. "одна тысяча шестьдесят сомони 00 дирам".
+// Somoni is masculine and does not decline; the genitive plural of diram is
+// "дирам", as in the banks' documents.
+func RussianWords(amount float64) string {
+	somoni, dirams := split(amount)
+	return russianNumber(somoni) + " сомони " + dirams + " " +
+		russianPlural(dirams, "дирам", "дирама", "дирам")
+}
+
+// TajikWords writes amount in Tajik, e.g. "як ҳазору шаст сомонӣ 00 дирам".
+// Nouns after numerals stay singular.
+func TajikWords(amount float64) string {
+	somoni, dirams := split(amount)
+	return tajikNumber(somoni) + " сомонӣ " + dirams + " дирам"
+}
+
+// EnglishWords writes amount in English, e.g. "one thousand sixty somoni 00
+// dirams".
+func EnglishWords(amount float64) string {
+	somoni, dirams := split(amount)
+	unit := "dirams"
+	if dirams == "01" {
+		unit = "diram"
+	}
+	return englishNumber(somoni) + " somoni " + dirams + " " + unit
+}
+
+// split returns the whole somoni and the dirams as two digits.
+func split(amount float64) (int64, string) {
+	cents := int64(math.Round(math.Abs(amount) * 100))
+	return cents / 100, strconv.FormatInt(cents%100+100, 10)[1:]
+}
+
+type scale struct {
+	value int64
+	forms [3]string
+	// feminine scales take "одна" and "две".
+	feminine bool
+}
+
+var russianScales = []scale{
+	{value: 1_000_000_000, forms: [3]string{"миллиард", "миллиарда", "миллиардов"}},
+	{value: 1_000_000, forms: [3]string{"миллион", "миллиона", "миллионов"}},
+	{value: 1_000, forms: [3]string{"тысяча", "тысячи", "тысяч"}, feminine: true},
+}
+
+var (
+	russianUnits    = []string{"", "один", "два", "три", "четыре", "пять", "шесть", "семь", "восемь", "девять"}
+	russianFeminine = []string{"", "одна", "две"}
+	russianTeens    = []string{"десять", "одиннадцать", "двенадцать", "тринадцать", "четырнадцать",
+		"пятнадцать", "шестнадцать", "семнадцать", "восемнадцать", "девятнадцать"}
+	russianTens = []string{"", "", "двадцать", "тридцать", "сорок", "пятьдесят",
+		"шестьдесят", "семьдесят", "восемьдесят", "девяносто"}
+	russianHundreds = []string{"", "сто", "двести", "триста", "четыреста", "пятьсот",
+		"шестьсот", "семьсот", "восемьсот", "девятьсот"}
+)
+
+func russianNumber(n int64) string {
+	if n == 0 {
+		return "ноль"
+	}
+
+	var words []string
+	for _, s := range russianScales {
+		if group := n / s.value; group > 0 {
+			words = append(words, russianTriplet(group, s.feminine)...)
+			words = append(words, russianPlural(strconv.FormatInt(group, 10), s.forms[0], s.forms[1], s.forms[2]))
+			n %= s.value
+		}
+	}
+	return strings.Join(append(words, russianTriplet(n, false)...), " ")
+}
+
+// russianTriplet writes a number below a thousand, or for the billions the
+// whole count of them.
+func russianTriplet(n int64, feminine bool) []string {
+	var words []string
+	if n >= 1000 {
+		words = append(words, russianNumber(n/1000*1000))
+		n %= 1000
+	}
+
+	words = append(words, russianHundreds[n/100])
+	switch tens := n % 100; {
+	case tens >= 10 && tens < 20:
+		words = append(words, russianTeens[tens-10])
+	default:
+		words = append(words, russianTens[tens/10])
+		if feminine && tens%10 <= 2 {
+			words = append(words, russianFeminine[tens%10])
+		} else {
+			words = append(words, russianUnits[tens%10])
+		}
+	}
+	return nonEmpty(words)
+}
+
+// russianPlural picks the form of a noun after the number written in digits:
+// 1 дирам, 2 дирама, 5 дирам.
+func russianPlural(digits, one, few, many string) string {
+	n, _ := strconv.Atoi(digits)
+	switch {
+	case n%100 >= 11 && n%100 <= 14:
+		return many
+	case n%10 == 1:
+		return one
+	case n%10 >= 2 && n%10 <= 4:
+		return few
+	default:
+		return many
+	}
+}
+
+var (
+	tajikUnits = []string{"", "як", "ду", "се", "чор", "панҷ", "шаш", "ҳафт", "ҳашт", "нӯҳ"}
+	tajikTeens = []string{"даҳ", "ёздаҳ", "дувоздаҳ", "сенздаҳ", "чордаҳ",
+		"понздаҳ", "шонздаҳ", "ҳабдаҳ", "ҳаждаҳ", "нуздаҳ"}
+	tajikTens     = []string{"", "", "бист", "сӣ", "чил", "панҷоҳ", "шаст", "ҳафтод", "ҳаштод", "навад"}
+	tajikHundreds = []string{"", "сад", "дусад", "сесад", "чорсад", "панҷсад",
+		"шашсад", "ҳафтсад", "ҳаштсад", "нӯҳсад"}
+	tajikScales = []struct {
+		value int64
+		word  string
+	}{
+		{value: 1_000_000_000, word: "миллиард"},
+		{value: 1_000_000, word: "миллион"},
+		{value: 1_000, word: "ҳазор"},
+	}
+)
+
+// tajikNumber joins the parts of a number with the conjunction -у, or -ю
+// after a vowel: 1060 is "як ҳазору шаст", 31 is "сию як".
+func tajikNumber(n int64) string {
+	if n == 0 {
+		return "сифр"
+	}
+
+	var parts []string
+	for _, s := range tajikScales {
+		if group := n / s.value; group > 0 {
+			groupParts := tajikParts(group)
+			groupParts[len(groupParts)-1] += " " + s.word
+			parts = append(parts, groupParts...)
+			n %= s.value
+		}
+	}
+	parts = append(parts, tajikParts(n)...)
+
+	for i := range parts[:len(parts)-1] {
+		parts[i] = withConjunction(parts[i])
+	}
+	return strings.Join(parts, " ")
+}
+
+// withConjunction adds -у to a word, or -ю after a vowel, where a final ӣ
+// becomes и: "ҳазору", "сию".
+func withConjunction(word string) string {
+	if stem, ok := strings.CutSuffix(word, "ӣ"); ok {
+		return stem + "ию"
+	}
+	if last, _ := utf8.DecodeLastRuneInString(word); strings.ContainsRune("аеиоуӯяюёэ", last) {
+		return word + "ю"
+	}
+	return word + "у"
+}
+
+// tajikParts returns the parts of a number below a thousand, or for the
+// billions the whole count of them.
+func tajikParts(n int64) []string {
+	if n >= 1000 {
+		return []string{tajikNumber(n)}
+	}
+
+	parts := []string{tajikHundreds[n/100]}
+	switch tens := n % 100; {
+	case tens >= 10 && tens < 20:
+		parts = append(parts, tajikTeens[tens-10])
+	default:
+		parts = append(parts, tajikTens[tens/10], tajikUnits[tens%10])
+	}
+	return nonEmpty(parts)
+}
+
+var (
+	englishUnits = []string{"", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen",
+		"eighteen", "nineteen"}
+	englishTens   = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+	englishScales = []struct {
+		value int64
+		word  string
+	}{
+		{value: 1_000_000_000, word: "billion"},
+		{value: 1_000_000, word: "million"},
+		{value: 1_000, word: "thousand"},
+	}
+)
+
+func englishNumber(n int64) string {
+	if n == 0 {
+		return "zero"
+	}
+
+	var words []string
+	for _, s := range englishScales {
+		if group := n / s.value; group > 0 {
+			words = append(words, englishTriplet(group), s.word)
+			n %= s.value
+		}
+	}
+	return strings.Join(nonEmpty(append(words, englishTriplet(n))), " ")
+}
+
+func englishTriplet(n int64) string {
+	if n >= 1000 {
+		return englishNumber(n)
+	}
+
+	var words []string
+	if n >= 100 {
+		words = append(words, englishUnits[n/100], "hundred")
+	}
+	switch tens := n % 100; {
+	case tens < 20:
+		words = append(words, englishUnits[tens])
+	case tens%10 == 0:
+		words = append(words, englishTens[tens/10])
+	default:
+		words = append(words, englishTens[tens/10]+"-"+englishUnits[tens%10])
+	}
+	return strings.Join(nonEmpty(words), " ")
+}
+
+func nonEmpty(words []string) []string {
+	result := words[:0]
+	for _, word := range words {
+		if word != "" {
+			result = append(result, word)
+		}
+	}
+	return result
+}
@@ -0,0 +1,89 @@
+package money_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icoder-new/installment-cli/internal/money"
+)
+
+func TestRussianWords(t *testing.T) {
+	tests := []struct {
+		amount float64
+		want   string
+	}{
+		{amount: 0, want: "ноль сомони 00 дирам"},
+		{amount: 1, want: "один сомони 00 дирам"},
+		{amount: 2, want: "два сомони 00 дирам"},
+		{amount: 11, want: "одиннадцать сомони 00 дирам"},
+		{amount: 21, want: "двадцать один сомони 00 дирам"},
+		{amount: 112, want: "сто двенадцать сомони 00 дирам"},
+		{amount: 1000, want: "одна тысяча сомони 00 дирам"},
+		{amount: 1060, want: "одна тысяча шестьдесят сомони 00 дирам"},
+		{amount: 2000, want: "две тысячи сомони 00 дирам"},
+		{amount: 5000, want: "пять тысяч сомони 00 дирам"},
+		{amount: 11000, want: "одиннадцать тысяч сомони 00 дирам"},
+		{amount: 21000, want: "двадцать одна тысяча сомони 00 дирам"},
+		{amount: 1_000_000, want: "один миллион сомони 00 дирам"},
+		{amount: 2_342_001, want: "два миллиона триста сорок две тысячи один сомони 00 дирам"},
+		{amount: 5_000_000_000, want: "пять миллиардов сомони 00 дирам"},
+		{amount: 0.01, want: "ноль сомони 01 дирам"},
+		{amount: 0.02, want: "ноль сомони 02 дирама"},
+		{amount: 0.11, want: "ноль сомони 11 дирам"},
+		{amount: 0.21, want: "ноль сомони 21 дирам"},
+		{amount: 1499.995, want: "одна тысяча пятьсот сомони 00 дирам"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, money.RussianWords(tt.amount))
+		})
+	}
+}
+
+func TestTajikWords(t *testing.T) {
+	tests := []struct {
+		amount float64
+		want   string
+	}{
+		{amount: 0, want: "сифр сомонӣ 00 дирам"},
+		{amount: 1, want: "як сомонӣ 00 дирам"},
+		{amount: 11, want: "ёздаҳ сомонӣ 00 дирам"},
+		{amount: 21, want: "бисту як сомонӣ 00 дирам"},
+		{amount: 31, want: "сию як сомонӣ 00 дирам"},
+		{amount: 120, want: "саду бист сомонӣ 00 дирам"},
+		{amount: 1060, want: "як ҳазору шаст сомонӣ 00 дирам"},
+		{amount: 2345, want: "ду ҳазору сесаду чилу панҷ сомонӣ 00 дирам"},
+		{amount: 120_000, want: "саду бист ҳазор сомонӣ 00 дирам"},
+		{amount: 1_000_000, want: "як миллион сомонӣ 00 дирам"},
+		{amount: 0.5, want: "сифр сомонӣ 50 дирам"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, money.TajikWords(tt.amount))
+		})
+	}
+}
+
+func TestEnglishWords(t *testing.T) {
+	tests := []struct {
+		amount float64
+		want   string
+	}{
+		{amount: 0, want: "zero somoni 00 dirams"},
+		{amount: 1, want: "one somoni 00 dirams"},
+		{amount: 11, want: "eleven somoni 00 dirams"},
+		{amount: 21, want: "twenty-one somoni 00 dirams"},
+		{amount: 1060, want: "one thousand sixty somoni 00 dirams"},
+		{amount: 1_000_000, want: "one million somoni 00 dirams"},
+		{amount: 305.01, want: "three hundred five somoni 01 diram"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, money.EnglishWords(tt.amount))
+		})
+	}
+}
@@ -0,0 +1,274 @@
+// Package phone parses customer phone numbers into E.164. Tajik numbers are
+// checked against the mobile operators' ranges; numbers of other countries are
+// only accepted by a parser that allows them.
+package phone
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const HomeCountry = "TJ"
+
+var (
+	ErrInvalidFormat      = errors.New("неверный формат номера телефона")
+	ErrUnknownOperator    = errors.New("номер не принадлежит мобильному оператору")
+	ErrCountryNotAllowed  = errors.New("номера этой страны не принимаются")
+	ErrUnsupportedCountry = errors.New("неизвестный код страны")
+)
+
+// Country describes the numbering plan of a country: its calling code, the
+// length of the national number and the ranges mobile numbers start with.
+type Country struct {
+	Code        string
+	CallingCode string
+	Length      int
+	Operators   []Operator
+}
+
+// Operator is a mobile operator with the national prefixes assigned to it.
+type Operator struct {
+	Name     string
+	Prefixes []string
+}
+
+// operator returns the operator the national number belongs to, preferring
+// the longest matching prefix.
+func (c Country) operator(national string) (Operator, bool) {
+	var (
+		found  Operator
+		length int
+	)
+	for _, operator := range c.Operators {
+		for _, prefix := range operator.Prefixes {
+			if len(prefix) > length && strings.HasPrefix(national, prefix) {
+				found, length = operator, len(prefix)
+			}
+		}
+	}
+	return found, length > 0
+}
+
+var countries = []Country{
+	{
+		Code:        "TJ",
+		CallingCode: "992",
+		Length:      9,
+		Operators: []Operator{
+			{Name: "Tcell", Prefixes: []string{"50", "77", "92", "93"}},
+			{Name: "MegaFon", Prefixes: []string{"10", "55", "88", "90"}},
+			{Name: "ZET-Mobile", Prefixes: []string{"40", "91"}},
+			{Name: "Babilon-M", Prefixes: []string{"00", "11", "98", "918"}},
+		},
+	},
+	{
+		Code:        "RU",
+		CallingCode: "7",
+		Length:      10,
+		Operators:   []Operator{{Prefixes: []string{"9"}}},
+	},
+	{
+		Code:        "KZ",
+		CallingCode: "7",
+		Length:      10,
+		Operators:   []Operator{{Prefixes: []string{"70", "74", "75", "76", "77"}}},
+	},
+	{
+		Code:        "UZ",
+		CallingCode: "998",
+		Length:      9,
+		Operators:   []Operator{{Prefixes: []string{"20", "33", "50", "55", "77", "88", "9"}}},
+	},
+	{
+		Code:        "KG",
+		CallingCode: "996",
+		Length:      9,
+		Operators:   []Operator{{Prefixes: []string{"2", "5", "7", "88", "99"}}},
+	},
+	{
+		Code:        "TR",
+		CallingCode: "90",
+		Length:      10,
+		Operators:   []Operator{{Prefixes: []string{"5"}}},
+	},
+}
+
+// Countries lists the codes of the countries whose numbers can be allowed.
+func Countries() []string {
+	codes := make([]string, 0, len(countries))
+	for _, country := range countries {
+		codes = append(codes, country.Code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+func lookupCountry(code string) (Country, bool) {
+	for _, country := range countries {
+		if country.Code == code {
+			return country, true
+		}
+	}
+	return Country{}, false
+}
+
+// Number is a parsed mobile number.
+type Number struct {
+	Country  Country
+	National string
+	Operator string
+}
+
+// E164 returns the number as stored and sent to gateways, e.g. +992931234567.
+func (n Number) E164() string {
+	return "+" + n.Country.CallingCode + n.National
+}
+
+// String formats the number for people, e.g. +992 93 123 45 67.
+func (n Number) String() string {
+	national := n.National
+	groups := []string{"+" + n.Country.CallingCode}
+	switch n.Country.Length {
+	case 9:
+		groups = append(groups, national[:2], national[2:5], national[5:7], national[7:])
+	case 10:
+		groups = append(groups, national[:3], national[3:6], national[6:8], national[8:])
+	default:
+		groups = append(groups, national)
+	}
+	return strings.Join(groups, " ")
+}
+
+// Parser parses numbers of the home country and of the countries it allows.
+type Parser struct {
+	allowed map[string]bool
+}
+
+// NewParser returns a parser that accepts Tajik numbers and those of the given
+// countries (ISO 3166-1 alpha-2 codes).
+func NewParser(countryCodes ...string) (*Parser, error) {
+	allowed := map[string]bool{HomeCountry: true}
+	for _, code := range countryCodes {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if code == "" {
+			continue
+		}
+		if _, ok := lookupCountry(code); !ok {
+			return nil, fmt.Errorf("%w: %s (доступны: %s)", ErrUnsupportedCountry, code, strings.Join(Countries(), ", "))
+		}
+		allowed[code] = true
+	}
+	return &Parser{allowed: allowed}, nil
+}
+
+var (
+	defaultParser, _ = NewParser()
+	anyParser, _     = NewParser(Countries()...)
+)
+
+// Parse parses a number with the default parser, which accepts Tajik numbers
+// only.
+func Parse(input string) (Number, error) {
+	return defaultParser.Parse(input)
+}
+
+// ParseAny parses a number of any known country. It is meant for numbers that
+// were validated on input, e.g. before handing them to a gateway.
+func ParseAny(input string) (Number, error) {
+	return anyParser.Parse(input)
+}
+
+// Normalize returns the E.164 form of a number of any known country.
+func Normalize(input string) (string, error) {
+	number, err := ParseAny(input)
+	if err != nil {
+		return "", err
+	}
+	return number.E164(), nil
+}
+
+// Parse accepts the international form with "+" or "00", and for Tajik
+// numbers also 992XXXXXXXXX and the nine-digit national number. Spaces,
+// dashes, dots and brackets are ignored.
+func (p *Parser) Parse(input string) (Number, error) {
+	digits, international, ok := clean(input)
+	if !ok {
+		return Number{}, ErrInvalidFormat
+	}
+
+	home, _ := lookupCountry(HomeCountry)
+	switch {
+	case international:
+		return p.parseInternational(digits)
+	case len(digits) == home.Length:
+		return p.number(home, digits)
+	case len(digits) == len(home.CallingCode)+home.Length && strings.HasPrefix(digits, home.CallingCode):
+		return p.number(home, digits[len(home.CallingCode):])
+	default:
+		return Number{}, ErrInvalidFormat
+	}
+}
+
+func (p *Parser) parseInternational(digits string) (Number, error) {
+	var candidate *Country
+	for i, country := range countries {
+		national, ok := strings.CutPrefix(digits, country.CallingCode)
+		if !ok || len(national) != country.Length {
+			continue
+		}
+		// Countries may share a calling code (+7), so the ranges decide.
+		if _, ok := country.operator(national); ok {
+			return p.number(country, national)
+		}
+		if candidate == nil {
+			candidate = &countries[i]
+		}
+	}
+
+	if candidate == nil {
+		return Number{}, ErrInvalidFormat
+	}
+	return p.number(*candidate, digits[len(candidate.CallingCode):])
+}
+
+func (p *Parser) number(country Country, national string) (Number, error) {
+	if !p.allowed[country.Code] {
+		return Number{}, fmt.Errorf("%w: %s", ErrCountryNotAllowed, country.Code)
+	}
+	if len(national) != country.Length {
+		return Number{}, ErrInvalidFormat
+	}
+
+	operator, ok := country.operator(national)
+	if !ok {
+		return Number{}, fmt.Errorf("%w: +%s %s", ErrUnknownOperator, country.CallingCode, national)
+	}
+
+	return Number{Country: country, National: national, Operator: operator.Name}, nil
+}
+
+func clean(input string) (digits string, international bool, ok bool) {
+	input = strings.TrimSpace(input)
+	if strings.HasPrefix(input, "+") {
+		international, input = true, input[1:]
+	}
+
+	var b strings.Builder
+	for _, r := range input {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", false, false
+		}
+	}
+
+	digits = b.String()
+	if !international && strings.HasPrefix(digits, "00") && len(digits) > 11 {
+		international, digits = true, digits[2:]
+	}
+	return digits, international, digits != ""
+}
@@ -0,0 +1,100 @@
+package phone_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/icoder-new/installment-cli/internal/phone"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		e164      string
+		formatted string
+		operator  string
+		err       error
+	}{
+		{name: "national", input: "931234567", e164: "+992931234567", formatted: "+992 93 123 45 67", operator: "Tcell"},
+		{name: "with country code", input: "992901234567", e164: "+992901234567", formatted: "+992 90 123 45 67", operator: "MegaFon"},
+		{name: "international", input: "+992 (91) 123-45-67", e164: "+992911234567", formatted: "+992 91 123 45 67", operator: "ZET-Mobile"},
+		{name: "international with 00", input: "00992981234567", e164: "+992981234567", formatted: "+992 98 123 45 67", operator: "Babilon-M"},
+		{name: "longest prefix wins", input: "+992918123456", e164: "+992918123456", formatted: "+992 91 812 34 56", operator: "Babilon-M"},
+		{name: "landline", input: "+992372212345", err: phone.ErrUnknownOperator},
+		{name: "unassigned range", input: "991234567", err: phone.ErrUnknownOperator},
+		{name: "foreign number is not rewritten", input: "+79123456789", err: phone.ErrCountryNotAllowed},
+		{name: "too short", input: "+99293123456", err: phone.ErrInvalidFormat},
+		{name: "too long", input: "9929312345678", err: phone.ErrInvalidFormat},
+		{name: "letters", input: "93123456a", err: phone.ErrInvalidFormat},
+		{name: "empty", input: "", err: phone.ErrInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			number, err := phone.Parse(tt.input)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.e164, number.E164())
+			assert.Equal(t, tt.formatted, number.String())
+			assert.Equal(t, tt.operator, number.Operator)
+		})
+	}
+}
+
+func TestParserAllowsForeignCountries(t *testing.T) {
+	parser, err := phone.NewParser("ru", "KZ")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		input   string
+		country string
+		e164    string
+		err     error
+	}{
+		{name: "russian mobile", input: "+7 912 345-67-89", country: "RU", e164: "+79123456789"},
+		{name: "kazakh mobile", input: "+77011234567", country: "KZ", e164: "+77011234567"},
+		{name: "russian landline", input: "+74951234567", err: phone.ErrUnknownOperator},
+		{name: "uzbek not allowed", input: "+998901234567", err: phone.ErrCountryNotAllowed},
+		{name: "home country", input: "931234567", country: "TJ", e164: "+992931234567"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			number, err := parser.Parse(tt.input)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.country, number.Country.Code)
+			assert.Equal(t, tt.e164, number.E164())
+		})
+	}
+}
+
+func TestNewParserRejectsUnknownCountry(t *testing.T) {
+	_, err := phone.NewParser("XX")
+	assert.ErrorIs(t, err, phone.ErrUnsupportedCountry)
+}
+
+func TestNormalize(t *testing.T) {
+	normalized, err := phone.Normalize("992 93 123 45 67")
+	require.NoError(t, err)
+	assert.Equal(t, "+992931234567", normalized)
+
+	normalized, err = phone.Normalize("+998 90 123 45 67")
+	require.NoError(t, err)
+	assert.Equal(t, "+998901234567", normalized)
+
+	_, err = phone.Normalize("12345")
+	assert.ErrorIs(t, err, phone.ErrInvalidFormat)
+}
@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"github.com/icoder-new/installment-cli/internal/domain"
+)
+
+// AgreementService produces the printed installment agreement for a contract.
+type AgreementService struct {
+	contracts domain.ContractRepository
+	renderer  domain.AgreementRenderer
+	store     domain.Store
+}
+
+func NewAgreementService(contracts domain.ContractRepository, renderer domain.AgreementRenderer, store domain.Store) *AgreementService {
+	return &AgreementService{
+		contracts: contracts,
+		renderer:  renderer,
+		store:     store,
+	}
+}
+
+// Render returns the agreement for the contract in the given language and
+// format. Cancelled contracts are rendered too, for reprints of the archive.
+func (uc *AgreementService) Render(contractID, language string, format domain.DocumentFormat) ([]byte, error) {
+	contract, err := uc.contracts.FindByID(contractID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.renderer.Render(language, format, domain.NewAgreementData(contract, uc.store))
+}
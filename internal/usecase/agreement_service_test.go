@@ -0,0 +1,62 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockAgreementRenderer struct {
+	mock.Mock
+}
+
+func (m *MockAgreementRenderer) Render(language string, format domain.DocumentFormat, data domain.AgreementData) ([]byte, error) {
+	args := m.Called(language, format, data)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func TestAgreementService_Render(t *testing.T) {
+	contract := newTestContract(domain.Computer, time.Hour)
+	store := domain.Store{Name: "Магазин", TaxID: "123"}
+
+	mockRepo := new(MockContractRepository)
+	mockRepo.On("FindByID", "C-1").Return(contract, nil)
+
+	mockRenderer := new(MockAgreementRenderer)
+	mockRenderer.On("Render", "tg", domain.FormatPDF, mock.MatchedBy(func(data domain.AgreementData) bool {
+		return data.ContractID == "C-1" &&
+			data.Store == store &&
+			data.PhoneNumber == "+992001002005" &&
+			data.Price == 1000 &&
+			data.TotalPayment == 1030 &&
+			data.Overpayment == 30 &&
+			data.OverpaymentPercent == 3 &&
+			len(data.Schedule) == 6
+	})).Return([]byte("%PDF-"), nil)
+
+	service := usecase.NewAgreementService(mockRepo, mockRenderer, store)
+
+	document, err := service.Render("C-1", "tg", domain.FormatPDF)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("%PDF-"), document)
+
+	mockRepo.AssertExpectations(t)
+	mockRenderer.AssertExpectations(t)
+}
+
+func TestAgreementService_RenderUnknownContract(t *testing.T) {
+	mockRepo := new(MockContractRepository)
+	mockRepo.On("FindByID", "missing").Return(domain.Contract{}, domain.ErrContractNotFound)
+
+	mockRenderer := new(MockAgreementRenderer)
+	service := usecase.NewAgreementService(mockRepo, mockRenderer, domain.Store{})
+
+	_, err := service.Render("missing", "ru", domain.FormatHTML)
+	assert.ErrorIs(t, err, domain.ErrContractNotFound)
+	mockRenderer.AssertNotCalled(t, "Render", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+)
+
+const (
+	DefaultConsentTTL         = 5 * time.Minute
+	DefaultConsentMaxAttempts = 3
+)
+
+// ConsentService confirms that the phone owner agrees to the installment: a
+// one-time code goes to the phone by SMS and the contract is only opened once
+// the cashier enters it.
+type ConsentService struct {
+	consents    domain.ConsentRepository
+	sender      domain.SMSSender
+	renderer    domain.MessageRenderer
+	customers   domain.CustomerRepository
+	contracts   *ContractService
+	ttl         time.Duration
+	maxAttempts int
+	now         func() time.Time
+}
+
+func NewConsentService(
+	consents domain.ConsentRepository,
+	sender domain.SMSSender,
+	renderer domain.MessageRenderer,
+	customers domain.CustomerRepository,
+	contracts *ContractService,
+	ttl time.Duration,
+	maxAttempts int,
+) *ConsentService {
+	return &ConsentService{
+		consents:    consents,
+		sender:      sender,
+		renderer:    renderer,
+		customers:   customers,
+		contracts:   contracts,
+		ttl:         ttl,
+		maxAttempts: maxAttempts,
+		now:         time.Now,
+	}
+}
+
+// Request sends the code for the quoted installment and returns the pending
+// request to confirm.
+func (uc *ConsentService) Request(
+	ctx context.Context,
+	product domain.Product,
+	totalPayment float64,
+) (domain.ConsentRequest, error) {
+	code, err := newConsentCode()
+	if err != nil {
+		return domain.ConsentRequest{}, err
+	}
+
+	request := domain.NewConsentRequest("consent-"+randomID(), code, product, totalPayment, uc.now(), uc.ttl, uc.maxAttempts)
+
+	customer, err := uc.customers.FindByPhone(product.PhoneNumber)
+	if err != nil && !errors.Is(err, domain.ErrCustomerNotFound) {
+		return domain.ConsentRequest{}, err
+	}
+
+	message, err := uc.renderer.Render(customer.Language, domain.EventConsent, domain.MessageData{
+		Product:      product.Type,
+		Price:        product.Price,
+		PeriodMonths: product.PeriodMonths,
+		TotalPayment: totalPayment,
+		Code:         code,
+		ValidMinutes: int(uc.ttl.Round(time.Minute) / time.Minute),
+	})
+	if err != nil {
+		return domain.ConsentRequest{}, err
+	}
+
+	if err := uc.consents.Save(request); err != nil {
+		return domain.ConsentRequest{}, err
+	}
+
+	ctx = domain.WithMessageEvent(ctx, domain.EventConsent)
+	if _, err := uc.sender.SendSMS(ctx, product.PhoneNumber, message); err != nil {
+		_ = uc.consents.Delete(request.ID)
+		return domain.ConsentRequest{}, fmt.Errorf("не удалось отправить код подтверждения: %w", err)
+	}
+
+	return request, nil
+}
+
+func (uc *ConsentService) Find(requestID string) (domain.ConsentRequest, error) {
+	return uc.consents.FindByID(requestID)
+}
+
+// Confirm checks the code and opens the contract with the consent evidence.
+// A wrong code may be retried while attempts remain; an expired or exhausted
+// request has to be started over. The request is checked and used up in one
+// step, so only one confirmation can open the contract.
+func (uc *ConsentService) Confirm(ctx context.Context, requestID, code string) (domain.Contract, error) {
+	var (
+		request domain.ConsentRequest
+		consent domain.Consent
+	)
+	err := uc.consents.Update(requestID, func(pending *domain.ConsentRequest) (bool, error) {
+		var err error
+		consent, err = pending.Verify(code, uc.now())
+		request = *pending
+		return errors.Is(err, domain.ErrConsentCodeMismatch), err
+	})
+	if errors.Is(err, domain.ErrConsentCodeMismatch) {
+		return domain.Contract{}, fmt.Errorf("%w: осталось попыток %d", err, request.RemainingAttempts())
+	}
+	if err != nil {
+		return domain.Contract{}, err
+	}
+
+	return uc.contracts.Open(ctx, request.Product, request.TotalPayment, &consent)
+}
+
+func newConsentCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+func randomID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,202 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/infra/sms"
+	"github.com/icoder-new/installment-cli/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryConsentRepository map[string]domain.ConsentRequest
+
+func (r memoryConsentRepository) Save(request domain.ConsentRequest) error {
+	r[request.ID] = request
+	return nil
+}
+
+func (r memoryConsentRepository) FindByID(id string) (domain.ConsentRequest, error) {
+	request, ok := r[id]
+	if !ok {
+		return domain.ConsentRequest{}, domain.ErrConsentNotFound
+	}
+	return request, nil
+}
+
+func (r memoryConsentRepository) Delete(id string) error {
+	delete(r, id)
+	return nil
+}
+
+func (r memoryConsentRepository) Update(id string, update func(*domain.ConsentRequest) (bool, error)) error {
+	request, ok := r[id]
+	if !ok {
+		return domain.ErrConsentNotFound
+	}
+
+	keep, err := update(&request)
+	if keep {
+		r[id] = request
+	} else {
+		delete(r, id)
+	}
+	return err
+}
+
+var consentProduct = domain.Product{
+	Type:         domain.Smartphone,
+	Price:        1000,
+	PhoneNumber:  "+992001002005",
+	PeriodMonths: 3,
+}
+
+// newTestConsentService returns the service and a function that yields the
+// code from the last SMS sent.
+func newTestConsentService(t *testing.T, consents memoryConsentRepository, ttl time.Duration) (*usecase.ConsentService, *MockContractRepository, func() string) {
+	t.Helper()
+
+	var sent string
+	codeSMS := new(MockSMSSender)
+	codeSMS.On("SendSMS", mock.Anything, consentProduct.PhoneNumber, mock.Anything).
+		Run(func(args mock.Arguments) { sent = args.String(2) }).
+		Return(testReceipt, nil)
+
+	contracts := new(MockContractRepository)
+	contracts.On("FindByID", mock.Anything).Return(domain.Contract{}, domain.ErrContractNotFound)
+	contracts.On("Save", mock.Anything).Return(nil)
+
+	notificationSMS := new(MockSMSSender)
+	notificationSMS.On("SendSMS", mock.Anything, mock.Anything, mock.Anything).Return(testReceipt, nil)
+	contractService, _ := newTestContractService(t, contracts, notificationSMS)
+
+	renderer, err := sms.NewTemplateRenderer("")
+	require.NoError(t, err)
+
+	service := usecase.NewConsentService(consents, codeSMS, renderer, memoryCustomerRepository{},
+		contractService, ttl, usecase.DefaultConsentMaxAttempts)
+
+	return service, contracts, func() string {
+		return regexp.MustCompile(`\d{6}`).FindString(sent)
+	}
+}
+
+func TestConsentService_ConfirmOpensContract(t *testing.T) {
+	consents := memoryConsentRepository{}
+	service, contracts, lastCode := newTestConsentService(t, consents, usecase.DefaultConsentTTL)
+
+	request, err := service.Request(context.Background(), consentProduct, 1000)
+	require.NoError(t, err)
+	code := lastCode()
+	require.Len(t, code, 6)
+	assert.NotContains(t, consents[request.ID].CodeHash, code, "the code must not be stored in clear")
+
+	contract, err := service.Confirm(context.Background(), request.ID, code)
+	require.NoError(t, err)
+
+	require.NotNil(t, contract.Consent)
+	assert.Equal(t, request.ID, contract.Consent.RequestID)
+	assert.Equal(t, "+992*****2005", contract.Consent.MaskedPhone)
+	assert.Equal(t, domain.HashConsentCode(request.ID, code), contract.Consent.CodeHash)
+	assert.Empty(t, consents, "a used request must be removed")
+	contracts.AssertCalled(t, "Save", mock.MatchedBy(func(c domain.Contract) bool {
+		return c.Consent != nil && c.TotalPayment == 1000
+	}))
+}
+
+func TestConsentService_ConfirmOpensOneContract(t *testing.T) {
+	service, contracts, lastCode := newTestConsentService(t, memoryConsentRepository{}, usecase.DefaultConsentTTL)
+
+	request, err := service.Request(context.Background(), consentProduct, 1000)
+	require.NoError(t, err)
+
+	_, err = service.Confirm(context.Background(), request.ID, lastCode())
+	require.NoError(t, err)
+
+	_, err = service.Confirm(context.Background(), request.ID, lastCode())
+	assert.ErrorIs(t, err, domain.ErrConsentNotFound)
+
+	opened := make(map[string]bool)
+	for _, call := range contracts.Calls {
+		if call.Method == "Save" {
+			opened[call.Arguments.Get(0).(domain.Contract).ID] = true
+		}
+	}
+	assert.Len(t, opened, 1)
+}
+
+func TestConsentService_ConfirmRejects(t *testing.T) {
+	tests := []struct {
+		name        string
+		ttl         time.Duration
+		codes       func(code string) []string
+		expectedErr []error
+	}{
+		{
+			name:        "Wrong code then right code",
+			ttl:         usecase.DefaultConsentTTL,
+			codes:       func(code string) []string { return []string{"000000", code} },
+			expectedErr: []error{domain.ErrConsentCodeMismatch, nil},
+		},
+		{
+			name:  "Attempts exhausted",
+			ttl:   usecase.DefaultConsentTTL,
+			codes: func(code string) []string { return []string{"000000", "000000", "000000", code} },
+			expectedErr: []error{
+				domain.ErrConsentCodeMismatch,
+				domain.ErrConsentCodeMismatch,
+				domain.ErrConsentAttemptsExceeded,
+				domain.ErrConsentNotFound,
+			},
+		},
+		{
+			name:        "Expired code",
+			ttl:         -time.Second,
+			codes:       func(code string) []string { return []string{code} },
+			expectedErr: []error{domain.ErrConsentExpired},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _, lastCode := newTestConsentService(t, memoryConsentRepository{}, tt.ttl)
+
+			request, err := service.Request(context.Background(), consentProduct, 1000)
+			require.NoError(t, err)
+			if lastCode() == "000000" {
+				t.Skip("the random code happens to match the wrong one")
+			}
+
+			for i, code := range tt.codes(lastCode()) {
+				_, err := service.Confirm(context.Background(), request.ID, code)
+				if tt.expectedErr[i] == nil {
+					assert.NoError(t, err, "attempt %d", i+1)
+				} else {
+					assert.ErrorIs(t, err, tt.expectedErr[i], "attempt %d", i+1)
+				}
+			}
+		})
+	}
+}
+
+func TestConsentService_RequestSendFailure(t *testing.T) {
+	consents := memoryConsentRepository{}
+	renderer, err := sms.NewTemplateRenderer("")
+	require.NoError(t, err)
+
+	failing := new(MockSMSSender)
+	failing.On("SendSMS", mock.Anything, mock.Anything, mock.Anything).Return(domain.Receipt{}, errors.New("gateway down"))
+
+	service := usecase.NewConsentService(consents, failing, renderer, memoryCustomerRepository{},
+		nil, usecase.DefaultConsentTTL, usecase.DefaultConsentMaxAttempts)
+
+	_, err = service.Request(context.Background(), consentProduct, 1000)
+	assert.ErrorContains(t, err, "не удалось отправить код подтверждения: gateway down")
+	assert.Empty(t, consents)
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+)
+
+type ContractService struct {
+	contracts domain.ContractRepository
+	events    domain.EventPublisher
+	now       func() time.Time
+}
+
+func NewContractService(
+	contracts domain.ContractRepository,
+	events domain.EventPublisher,
+) *ContractService {
+	return &ContractService{
+		contracts: contracts,
+		events:    events,
+		now:       time.Now,
+	}
+}
+
+func (uc *ContractService) Open(
+	ctx context.Context,
+	product domain.Product,
+	totalPayment float64,
+	consent *domain.Consent,
+) (domain.Contract, error) {
+	now := uc.now()
+	contract := domain.NewContract(newContractID(now), product, totalPayment, now)
+	contract.Consent = consent
+
+	if err := uc.contracts.Save(contract); err != nil {
+		return domain.Contract{}, fmt.Errorf("не удалось сохранить договор: %w", err)
+	}
+
+	return contract, uc.events.Publish(ctx, domain.InstallmentConfirmed{Contract: contract})
+}
+
+// newContractID derives the ID from the opening time and adds a random suffix,
+// so contracts opened within the same second by different processes never
+// share an ID and overwrite each other on Save.
+func newContractID(now time.Time) string {
+	b := make([]byte, 3)
+	_, _ = rand.Read(b)
+	return now.Format("20060102-150405") + "-" + hex.EncodeToString(b)
+}
+
+func (uc *ContractService) Cancel(ctx context.Context, contractID, reason string) (domain.Contract, error) {
+	var contract domain.Contract
+	err := uc.contracts.Update(contractID, func(stored *domain.Contract) error {
+		if err := stored.Cancel(reason, uc.now()); err != nil {
+			return err
+		}
+		contract = *stored
+		return nil
+	})
+	if err != nil {
+		return domain.Contract{}, err
+	}
+
+	return contract, uc.events.Publish(ctx, domain.InstallmentCancelled{Contract: contract})
+}
+
+// RecordPayment applies a payment to the contract.
+func (uc *ContractService) RecordPayment(ctx context.Context, contractID string, amount float64) (domain.Contract, error) {
+	var contract domain.Contract
+	var payment domain.Payment
+	err := uc.contracts.Update(contractID, func(stored *domain.Contract) error {
+		var err error
+		if payment, err = stored.AddPayment(amount, uc.now()); err != nil {
+			return err
+		}
+		contract = *stored
+		return nil
+	})
+	if err != nil {
+		return domain.Contract{}, err
+	}
+
+	return contract, uc.events.Publish(ctx, domain.PaymentReceived{Contract: contract, Payment: payment})
+}
+
+func (uc *ContractService) Find(contractID string) (domain.Contract, error) {
+	return uc.contracts.FindByID(contractID)
+}
+
+func (uc *ContractService) ActiveContracts() ([]domain.Contract, error) {
+	contracts, err := uc.contracts.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	return domain.ActiveContracts(contracts), nil
+}
+
+func (uc *ContractService) OutstandingBalance() (float64, error) {
+	contracts, err := uc.ActiveContracts()
+	if err != nil {
+		return 0, err
+	}
+
+	var balance float64
+	for _, contract := range contracts {
+		balance += contract.Balance()
+	}
+	return balance, nil
+}
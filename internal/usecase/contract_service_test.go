@@ -0,0 +1,303 @@
+package usecase_test
+
+import (
+	"context"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockContractRepository struct {
+	mock.Mock
+	saved map[string]domain.Contract
+}
+
+func (m *MockContractRepository) Save(contract domain.Contract) error {
+	args := m.Called(contract)
+	if args.Error(0) == nil {
+		if m.saved == nil {
+			m.saved = make(map[string]domain.Contract)
+		}
+		m.saved[contract.ID] = contract
+	}
+	return args.Error(0)
+}
+
+func (m *MockContractRepository) FindByID(id string) (domain.Contract, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.Contract), args.Error(1)
+}
+
+// Update starts from the contract saved last, like a real repository, or
+// from the mocked FindByID, and stores the result through the mocked Save.
+func (m *MockContractRepository) Update(id string, update func(*domain.Contract) error) error {
+	contract, ok := m.saved[id]
+	if !ok {
+		var err error
+		if contract, err = m.FindByID(id); err != nil {
+			return err
+		}
+	}
+	if err := update(&contract); err != nil {
+		return err
+	}
+	return m.Save(contract)
+}
+
+func (m *MockContractRepository) FindAll() ([]domain.Contract, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Contract), args.Error(1)
+}
+
+// newTestContractService wires the service to an event bus with the
+// notification subscriber, as main does, and records the published events.
+func newTestContractService(t *testing.T, contracts domain.ContractRepository, sender domain.SMSSender) (*usecase.ContractService, *eventRecorder) {
+	t.Helper()
+	bus := usecase.NewEventBus(log.New(io.Discard, "", 0))
+	events := new(eventRecorder)
+	bus.Subscribe(events.Handle)
+	bus.Subscribe(usecase.NewNotificationSubscriber(contracts, newTestNotifier(t, sender), bus).Handle)
+	return usecase.NewContractService(contracts, bus), events
+}
+
+func newTestContract(productType domain.ProductType, age time.Duration) domain.Contract {
+	product := domain.Product{
+		Type:         productType,
+		Price:        1000,
+		PhoneNumber:  "+992001002005",
+		PeriodMonths: 6,
+	}
+	contract := domain.NewContract("C-1", product, 1030, time.Now().Add(-age))
+	contract.Payments = []domain.Payment{
+		{Amount: 171.67, PaidAt: time.Now().Add(-age)},
+		{Amount: 100, PaidAt: time.Now()},
+	}
+	return contract
+}
+
+func TestContractService_Cancel(t *testing.T) {
+	tests := []struct {
+		name           string
+		contract       domain.Contract
+		reason         string
+		setupMocks     func(*MockContractRepository, *MockSMSSender)
+		expectedRefund float64
+		expectError    bool
+		errorMessage   string
+	}{
+		{
+			name:     "Smartphone returned within window",
+			contract: newTestContract(domain.Smartphone, 3*24*time.Hour),
+			reason:   "брак",
+			setupMocks: func(r *MockContractRepository, s *MockSMSSender) {
+				r.On("Save", mock.MatchedBy(func(c domain.Contract) bool {
+					return c.Status == domain.ContractCancelled
+				})).Return(nil)
+				s.On("SendSMS", mock.Anything, "+992001002005", mock.Anything).Return(testReceipt, nil)
+			},
+			expectedRefund: 271.67,
+		},
+		{
+			name:         "TV returned after window",
+			contract:     newTestContract(domain.TV, 10*24*time.Hour),
+			reason:       "не подошел",
+			setupMocks:   func(r *MockContractRepository, s *MockSMSSender) {},
+			expectError:  true,
+			errorMessage: "срок возврата товара истек: для Телевизор возврат возможен в течение 7 дней",
+		},
+		{
+			name:         "Missing reason",
+			contract:     newTestContract(domain.Computer, time.Hour),
+			reason:       "",
+			setupMocks:   func(r *MockContractRepository, s *MockSMSSender) {},
+			expectError:  true,
+			errorMessage: "необходимо указать причину отмены",
+		},
+		{
+			name: "Already cancelled",
+			contract: func() domain.Contract {
+				c := newTestContract(domain.Computer, time.Hour)
+				c.Status = domain.ContractCancelled
+				return c
+			}(),
+			reason:       "брак",
+			setupMocks:   func(r *MockContractRepository, s *MockSMSSender) {},
+			expectError:  true,
+			errorMessage: "договор не активен: C-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockContractRepository)
+			mockSMS := new(MockSMSSender)
+			mockRepo.On("FindByID", "C-1").Return(tt.contract, nil)
+			tt.setupMocks(mockRepo, mockSMS)
+
+			service, events := newTestContractService(t, mockRepo, mockSMS)
+
+			contract, err := service.Cancel(context.Background(), "C-1", tt.reason)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMessage)
+				assert.Empty(t, events.events)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, domain.ContractCancelled, contract.Status)
+				assert.InDelta(t, tt.expectedRefund, contract.Refund, 0.001)
+				assert.Zero(t, contract.Balance())
+				assert.Equal(t, []string{domain.EventNameInstallmentCancelled}, events.names())
+			}
+
+			mockRepo.AssertExpectations(t)
+			mockSMS.AssertExpectations(t)
+		})
+	}
+}
+
+func TestContractService_OutstandingBalanceSkipsCancelled(t *testing.T) {
+	active := newTestContract(domain.Smartphone, time.Hour)
+	cancelled := newTestContract(domain.TV, time.Hour)
+	cancelled.ID = "C-2"
+	cancelled.Status = domain.ContractCancelled
+
+	mockRepo := new(MockContractRepository)
+	mockRepo.On("FindAll").Return([]domain.Contract{active, cancelled}, nil)
+
+	service, _ := newTestContractService(t, mockRepo, new(MockSMSSender))
+
+	balance, err := service.OutstandingBalance()
+	require.NoError(t, err)
+	assert.InDelta(t, 1030-271.67, balance, 0.001)
+}
+
+func TestContractService_OpenAvoidsDuplicateIDs(t *testing.T) {
+	product := domain.Product{
+		Type:         domain.Smartphone,
+		Price:        1000,
+		PhoneNumber:  "+992001002005",
+		PeriodMonths: 3,
+	}
+
+	mockRepo := new(MockContractRepository)
+	mockRepo.On("Save", mock.Anything).Return(nil)
+	mockSMS := new(MockSMSSender)
+	mockSMS.On("SendSMS", mock.Anything, "+992001002005", mock.Anything).Return(testReceipt, nil)
+
+	service, events := newTestContractService(t, mockRepo, mockSMS)
+
+	first, err := service.Open(context.Background(), product, 1000, nil)
+	require.NoError(t, err)
+	second, err := service.Open(context.Background(), product, 1000, nil)
+	require.NoError(t, err)
+
+	assert.Regexp(t, `^\d{8}-\d{6}-[0-9a-f]{6}$`, first.ID)
+	assert.NotEqual(t, first.ID, second.ID)
+	mockRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+	assert.Equal(t, []string{
+		domain.EventNameInstallmentConfirmed,
+		domain.EventNameInstallmentConfirmed,
+	}, events.names())
+}
+
+func TestContractService_RecordPayment(t *testing.T) {
+	tests := []struct {
+		name         string
+		contract     domain.Contract
+		amount       float64
+		setupMocks   func(*MockContractRepository, *MockSMSSender)
+		expectedPaid float64
+		expectPayoff bool
+		expectError  bool
+		errorMessage string
+	}{
+		{
+			name:     "Partial payment",
+			contract: newTestContract(domain.Smartphone, time.Hour),
+			amount:   200,
+			setupMocks: func(r *MockContractRepository, s *MockSMSSender) {
+				r.On("Save", mock.Anything).Return(nil).Once()
+			},
+			expectedPaid: 471.67,
+		},
+		{
+			name:     "Final payment sends payoff message",
+			contract: newTestContract(domain.Smartphone, time.Hour),
+			amount:   758.33,
+			setupMocks: func(r *MockContractRepository, s *MockSMSSender) {
+				r.On("Save", mock.MatchedBy(func(c domain.Contract) bool {
+					return len(c.Notifications) == 0
+				})).Return(nil).Once()
+				r.On("Save", mock.MatchedBy(func(c domain.Contract) bool {
+					return len(c.Notifications) == 1 && c.Notifications[0].Event == domain.EventPayoff
+				})).Return(nil).Once()
+				s.On("SendSMS", mock.Anything, "+992001002005", mock.Anything).Return(testReceipt, nil)
+			},
+			expectedPaid: 1030,
+			expectPayoff: true,
+		},
+		{
+			name:         "Overpayment",
+			contract:     newTestContract(domain.Smartphone, time.Hour),
+			amount:       800,
+			setupMocks:   func(r *MockContractRepository, s *MockSMSSender) {},
+			expectError:  true,
+			errorMessage: "сумма платежа превышает остаток по договору",
+		},
+		{
+			name:         "Negative amount",
+			contract:     newTestContract(domain.Smartphone, time.Hour),
+			amount:       -10,
+			setupMocks:   func(r *MockContractRepository, s *MockSMSSender) {},
+			expectError:  true,
+			errorMessage: "сумма платежа должна быть больше 0",
+		},
+		{
+			name: "Cancelled contract",
+			contract: func() domain.Contract {
+				c := newTestContract(domain.Smartphone, time.Hour)
+				c.Status = domain.ContractCancelled
+				return c
+			}(),
+			amount:       100,
+			setupMocks:   func(r *MockContractRepository, s *MockSMSSender) {},
+			expectError:  true,
+			errorMessage: "договор не активен: C-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockContractRepository)
+			mockSMS := new(MockSMSSender)
+			mockRepo.On("FindByID", "C-1").Return(tt.contract, nil)
+			tt.setupMocks(mockRepo, mockSMS)
+
+			service, events := newTestContractService(t, mockRepo, mockSMS)
+
+			contract, err := service.RecordPayment(context.Background(), "C-1", tt.amount)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMessage)
+				assert.Empty(t, events.events)
+			} else {
+				require.NoError(t, err)
+				assert.InDelta(t, tt.expectedPaid, contract.TotalPaid(), 0.001)
+				assert.Equal(t, tt.expectPayoff, contract.IsPaidOff())
+				assert.Equal(t, []string{domain.EventNamePaymentReceived}, events.names())
+			}
+
+			mockRepo.AssertExpectations(t)
+			mockSMS.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+)
+
+type CustomerService struct {
+	customers domain.CustomerRepository
+}
+
+func NewCustomerService(customers domain.CustomerRepository) *CustomerService {
+	return &CustomerService{customers: customers}
+}
+
+func (uc *CustomerService) Find(phoneNumber string) (domain.Customer, error) {
+	return uc.customers.FindByPhone(phoneNumber)
+}
+
+func (uc *CustomerService) SetLanguage(phoneNumber, language string) error {
+	return uc.update(phoneNumber, func(customer *domain.Customer) error {
+		customer.Language = language
+		return nil
+	})
+}
+
+// SetOptOut records whether the customer refuses optional messages.
+func (uc *CustomerService) SetOptOut(phoneNumber string, optedOut bool) error {
+	return uc.update(phoneNumber, func(customer *domain.Customer) error {
+		customer.OptedOut = optedOut
+		return nil
+	})
+}
+
+func (uc *CustomerService) SetEmail(phoneNumber, email string) error {
+	return uc.update(phoneNumber, func(customer *domain.Customer) error {
+		if email != "" {
+			address, err := mail.ParseAddress(email)
+			if err != nil {
+				return fmt.Errorf("%w: %s", domain.ErrInvalidEmail, email)
+			}
+			email = address.Address
+		}
+		customer.Email = email
+		return nil
+	})
+}
+
+func (uc *CustomerService) SetMessengerID(phoneNumber, messengerID string) error {
+	return uc.update(phoneNumber, func(customer *domain.Customer) error {
+		customer.MessengerID = messengerID
+		return nil
+	})
+}
+
+// SetChannels sets the channels, in order of preference, the customer wants
+// to be notified on. The customer needs a contact for each of them.
+func (uc *CustomerService) SetChannels(phoneNumber string, channels []domain.Channel) error {
+	return uc.update(phoneNumber, func(customer *domain.Customer) error {
+		for _, channel := range channels {
+			if !customer.CanReceive(channel) {
+				return fmt.Errorf("%w: %s", domain.ErrNoChannel, channel)
+			}
+		}
+		customer.Channels = channels
+		return nil
+	})
+}
+
+func (uc *CustomerService) update(phoneNumber string, change func(*domain.Customer) error) error {
+	customer, err := uc.customers.FindByPhone(phoneNumber)
+	if err != nil && !errors.Is(err, domain.ErrCustomerNotFound) {
+		return err
+	}
+
+	customer.PhoneNumber = phoneNumber
+	if err := change(&customer); err != nil {
+		return err
+	}
+	return uc.customers.Save(customer)
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+)
+
+type DeliveryReport struct {
+	MessageID string
+	Status    domain.DeliveryStatus
+	At        time.Time
+	Error     string
+}
+
+type UndeliveredNotification struct {
+	Contract     domain.Contract
+	Notification domain.Notification
+}
+
+type DeliveryService struct {
+	contracts domain.ContractRepository
+	now       func() time.Time
+}
+
+func NewDeliveryService(contracts domain.ContractRepository) *DeliveryService {
+	return &DeliveryService{
+		contracts: contracts,
+		now:       time.Now,
+	}
+}
+
+// Apply stores a delivery report against the contract the message belongs to.
+func (uc *DeliveryService) Apply(report DeliveryReport) error {
+	contracts, err := uc.contracts.FindAll()
+	if err != nil {
+		return err
+	}
+
+	if report.At.IsZero() {
+		report.At = uc.now()
+	}
+
+	notFound := fmt.Errorf("%w: %s", domain.ErrNotificationNotFound, report.MessageID)
+	for _, contract := range contracts {
+		if !contract.HasMessage(report.MessageID) {
+			continue
+		}
+		return uc.contracts.Update(contract.ID, func(contract *domain.Contract) error {
+			if !contract.UpdateDelivery(report.MessageID, report.Status, report.At, report.Error) {
+				return notFound
+			}
+			return nil
+		})
+	}
+
+	return notFound
+}
+
+// Undelivered lists notifications of active contracts that failed, expired or
+// stayed without a delivery report for longer than maxPending.
+func (uc *DeliveryService) Undelivered(maxPending time.Duration) ([]UndeliveredNotification, error) {
+	contracts, err := uc.contracts.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	var undelivered []UndeliveredNotification
+	for _, contract := range domain.ActiveContracts(contracts) {
+		for _, notification := range contract.Notifications {
+			if notification.IsLost(now, maxPending) {
+				undelivered = append(undelivered, UndeliveredNotification{
+					Contract:     contract,
+					Notification: notification,
+				})
+			}
+		}
+	}
+
+	return undelivered, nil
+}
@@ -0,0 +1,82 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newNotifiedContract(id, messageID string, sentAt time.Time) domain.Contract {
+	contract := newTestContract(domain.Smartphone, time.Hour)
+	contract.ID = id
+	contract.RecordNotification(domain.EventPurchase, domain.Receipt{
+		MessageID:  messageID,
+		Segments:   1,
+		AcceptedAt: sentAt,
+	})
+	return contract
+}
+
+func TestDeliveryService_Apply(t *testing.T) {
+	now := time.Now()
+	first := newNotifiedContract("C-1", "msg-1", now)
+	second := newNotifiedContract("C-2", "msg-2", now)
+
+	mockRepo := new(MockContractRepository)
+	mockRepo.On("FindAll").Return([]domain.Contract{first, second}, nil)
+	mockRepo.On("FindByID", "C-2").Return(second, nil).Once()
+	mockRepo.On("Save", mock.MatchedBy(func(contract domain.Contract) bool {
+		notification := contract.Notifications[0]
+		return contract.ID == "C-2" &&
+			notification.Status == domain.DeliveryFailed &&
+			notification.Error == "err:011"
+	})).Return(nil).Once()
+
+	service := usecase.NewDeliveryService(mockRepo)
+
+	err := service.Apply(usecase.DeliveryReport{MessageID: "msg-2", Status: domain.DeliveryFailed, Error: "err:011"})
+	require.NoError(t, err)
+
+	err = service.Apply(usecase.DeliveryReport{MessageID: "unknown", Status: domain.DeliveryDelivered})
+	assert.ErrorIs(t, err, domain.ErrNotificationNotFound)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestContract_UpdateDeliveryIgnoresLateReports(t *testing.T) {
+	contract := newNotifiedContract("C-1", "msg-1", time.Now())
+
+	require.True(t, contract.UpdateDelivery("msg-1", domain.DeliveryDelivered, time.Now(), ""))
+	require.True(t, contract.UpdateDelivery("msg-1", domain.DeliveryPending, time.Now(), ""))
+
+	assert.Equal(t, domain.DeliveryDelivered, contract.Notifications[0].Status)
+}
+
+func TestDeliveryService_Undelivered(t *testing.T) {
+	now := time.Now()
+	delivered := newNotifiedContract("DELIVERED", "msg-1", now.Add(-48*time.Hour))
+	delivered.UpdateDelivery("msg-1", domain.DeliveryDelivered, now, "")
+	failed := newNotifiedContract("FAILED", "msg-2", now)
+	failed.UpdateDelivery("msg-2", domain.DeliveryFailed, now, "")
+	stale := newNotifiedContract("STALE", "msg-3", now.Add(-48*time.Hour))
+	fresh := newNotifiedContract("FRESH", "msg-4", now.Add(-time.Hour))
+	cancelled := newNotifiedContract("CANCELLED", "msg-5", now.Add(-48*time.Hour))
+	cancelled.Status = domain.ContractCancelled
+
+	mockRepo := new(MockContractRepository)
+	mockRepo.On("FindAll").Return([]domain.Contract{delivered, failed, stale, fresh, cancelled}, nil)
+
+	undelivered, err := usecase.NewDeliveryService(mockRepo).Undelivered(24 * time.Hour)
+	require.NoError(t, err)
+
+	var ids []string
+	for _, item := range undelivered {
+		ids = append(ids, item.Contract.ID)
+	}
+	assert.Equal(t, []string{"FAILED", "STALE"}, ids)
+}
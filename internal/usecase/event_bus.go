@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+)
+
+type EventHandler func(ctx context.Context, event domain.Event) error
+
+type subscriber struct {
+	handle EventHandler
+	events []string
+	async  bool
+}
+
+func (s subscriber) wants(event domain.Event) bool {
+	return len(s.events) == 0 || slices.Contains(s.events, event.EventName())
+}
+
+// EventBus delivers events to subscribers within the process. Synchronous
+// subscribers run in the order they subscribed and their errors are returned
+// to the publisher; asynchronous ones run in the background and their errors
+// are only logged. Asynchronous subscribers keep the publisher's context
+// values but not its cancellation, so they may outlive the request that
+// published the event. A failing or panicking subscriber never keeps the others
+// from getting the event.
+type EventBus struct {
+	mu          sync.RWMutex
+	subscribers []subscriber
+	pending     sync.WaitGroup
+	logger      *log.Logger
+}
+
+func NewEventBus(logger *log.Logger) *EventBus {
+	return &EventBus{logger: logger}
+}
+
+// Subscribe registers a synchronous handler for the named events, or for all
+// events when none are named.
+func (b *EventBus) Subscribe(handler EventHandler, events ...string) {
+	b.subscribe(subscriber{handle: handler, events: events})
+}
+
+// SubscribeAsync registers a handler that runs in the background. Call Wait
+// before the process exits to let it finish.
+func (b *EventBus) SubscribeAsync(handler EventHandler, events ...string) {
+	b.subscribe(subscriber{handle: handler, events: events, async: true})
+}
+
+func (b *EventBus) subscribe(s subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers = append(b.subscribers, s)
+}
+
+func (b *EventBus) Publish(ctx context.Context, event domain.Event) error {
+	b.mu.RLock()
+	subscribers := slices.Clone(b.subscribers)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, s := range subscribers {
+		if !s.wants(event) {
+			continue
+		}
+
+		if s.async {
+			b.pending.Add(1)
+			go func() {
+				defer b.pending.Done()
+				if err := handle(context.WithoutCancel(ctx), s.handle, event); err != nil {
+					b.logger.Printf("событие %s: %v", event.EventName(), err)
+				}
+			}()
+			continue
+		}
+
+		if err := handle(ctx, s.handle, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Wait blocks until the asynchronous handlers of published events finish.
+func (b *EventBus) Wait() {
+	b.pending.Wait()
+}
+
+func handle(ctx context.Context, handler EventHandler, event domain.Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("сбой обработчика события %s: %v", event.EventName(), r)
+		}
+	}()
+	return handler(ctx, event)
+}
+
+var _ domain.EventPublisher = (*EventBus)(nil)
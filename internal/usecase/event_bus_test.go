@@ -0,0 +1,100 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testQuote = domain.InstallmentQuoted{TotalPayment: 1000, QuotedAt: time.Now()}
+
+func TestEventBus_SyncSubscribersIsolated(t *testing.T) {
+	bus := usecase.NewEventBus(log.New(&bytes.Buffer{}, "", 0))
+
+	var calls []string
+	bus.Subscribe(func(ctx context.Context, event domain.Event) error {
+		calls = append(calls, "failing")
+		return errors.New("audit unavailable")
+	})
+	bus.Subscribe(func(ctx context.Context, event domain.Event) error {
+		calls = append(calls, "panicking")
+		panic("boom")
+	})
+	bus.Subscribe(func(ctx context.Context, event domain.Event) error {
+		calls = append(calls, "ok")
+		return nil
+	})
+
+	err := bus.Publish(context.Background(), testQuote)
+
+	assert.Equal(t, []string{"failing", "panicking", "ok"}, calls)
+	assert.ErrorContains(t, err, "audit unavailable")
+	assert.ErrorContains(t, err, "boom")
+}
+
+func TestEventBus_FiltersByEventName(t *testing.T) {
+	bus := usecase.NewEventBus(log.New(&bytes.Buffer{}, "", 0))
+
+	quotes, all := new(eventRecorder), new(eventRecorder)
+	bus.Subscribe(quotes.Handle, domain.EventNameInstallmentQuoted)
+	bus.Subscribe(all.Handle)
+
+	require.NoError(t, bus.Publish(context.Background(), testQuote))
+	require.NoError(t, bus.Publish(context.Background(), domain.InstallmentCancelled{}))
+
+	assert.Equal(t, []string{domain.EventNameInstallmentQuoted}, quotes.names())
+	assert.Equal(t, []string{domain.EventNameInstallmentQuoted, domain.EventNameInstallmentCancelled}, all.names())
+}
+
+func TestEventBus_AsyncErrorsAreLogged(t *testing.T) {
+	var logs bytes.Buffer
+	bus := usecase.NewEventBus(log.New(&logs, "", 0))
+
+	var handled atomic.Int32
+	release := make(chan struct{})
+	bus.SubscribeAsync(func(ctx context.Context, event domain.Event) error {
+		<-release
+		handled.Add(1)
+		return errors.New("webhook down")
+	})
+
+	err := bus.Publish(context.Background(), testQuote)
+	require.NoError(t, err, "async failures must not reach the publisher")
+	assert.Zero(t, handled.Load())
+
+	close(release)
+	bus.Wait()
+
+	assert.Equal(t, int32(1), handled.Load())
+	assert.Contains(t, logs.String(), "installment.quoted: webhook down")
+}
+
+func TestEventBus_AsyncSubscribersOutliveThePublisherContext(t *testing.T) {
+	bus := usecase.NewEventBus(log.New(&bytes.Buffer{}, "", 0))
+
+	release := make(chan struct{})
+	var handlerErr error
+	bus.SubscribeAsync(func(ctx context.Context, event domain.Event) error {
+		<-release
+		handlerErr = ctx.Err()
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, bus.Publish(ctx, testQuote))
+	cancel()
+
+	close(release)
+	bus.Wait()
+
+	assert.NoError(t, handlerErr, "the request that published the event may already be over")
+}
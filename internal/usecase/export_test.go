@@ -0,0 +1,8 @@
+package usecase
+
+import "time"
+
+// SetNow replaces the service clock so tests do not depend on the current date.
+func (uc *ReminderService) SetNow(now func() time.Time) {
+	uc.now = now
+}
@@ -1,47 +1,42 @@
 package usecase
 
 import (
-	"fmt"
+	"context"
+	"time"
 
 	"github.com/icoder-new/installment-cli/internal/domain"
 )
 
 type InstallmentCalculator struct {
-	smsSender domain.SMSSender
+	events domain.EventPublisher
+	now    func() time.Time
 }
 
-func NewInstallmentCalculator(smsSender domain.SMSSender) *InstallmentCalculator {
+func NewInstallmentCalculator(events domain.EventPublisher) *InstallmentCalculator {
 	return &InstallmentCalculator{
-		smsSender: smsSender,
+		events: events,
+		now:    time.Now,
 	}
 }
 
-func (uc *InstallmentCalculator) CalculateInstallment(product domain.Product) (float64, error) {
+// CalculateInstallment returns the total payment and raises InstallmentQuoted.
+func (uc *InstallmentCalculator) CalculateInstallment(
+	ctx context.Context,
+	product domain.Product,
+) (float64, error) {
 	totalPayment := product.CalculateTotalPayment()
 
 	if err := product.Validate(); err != nil {
 		return 0, err
 	}
 
-	overpayment := totalPayment - product.Price
-
-	message := fmt.Sprintf(
-		"Уважаемый клиент!\n"+
-			"Детали вашей покупки:\n"+
-			"Товар: %s\n"+
-			"Сумма: %.2f сомони\n"+
-			"Срок рассрочки: %d мес.\n"+
-			"Переплата: %.2f сомони\n"+
-			"Итого к оплате: %.2f сомони",
-		product.Type,
-		product.Price,
-		product.PeriodMonths,
-		overpayment,
-		totalPayment,
-	)
-
-	if err := uc.smsSender.SendSMS(product.PhoneNumber, message); err != nil {
-		return 0, fmt.Errorf("не удалось отправить SMS: %w", err)
+	err := uc.events.Publish(ctx, domain.InstallmentQuoted{
+		Product:      product,
+		TotalPayment: totalPayment,
+		QuotedAt:     uc.now(),
+	})
+	if err != nil {
+		return 0, err
 	}
 
 	return totalPayment, nil
@@ -1,10 +1,11 @@
 package usecase_test
 
 import (
-	"errors"
+	"context"
 	"testing"
 
 	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/infra/sms"
 	"github.com/icoder-new/installment-cli/internal/usecase"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -15,16 +16,61 @@ type MockSMSSender struct {
 	mock.Mock
 }
 
-func (m *MockSMSSender) SendSMS(phoneNumber string, message string) error {
-	args := m.Called(phoneNumber, message)
-	return args.Error(0)
+func (m *MockSMSSender) SendSMS(ctx context.Context, phoneNumber string, message string) (domain.Receipt, error) {
+	args := m.Called(ctx, phoneNumber, message)
+	return args.Get(0).(domain.Receipt), args.Error(1)
+}
+
+var testReceipt = domain.Receipt{MessageID: "msg-1", Segments: 1}
+
+type memoryCustomerRepository map[string]domain.Customer
+
+func (r memoryCustomerRepository) Save(customer domain.Customer) error {
+	r[customer.PhoneNumber] = customer
+	return nil
+}
+
+func (r memoryCustomerRepository) FindByPhone(phoneNumber string) (domain.Customer, error) {
+	customer, ok := r[phoneNumber]
+	if !ok {
+		return domain.Customer{}, domain.ErrCustomerNotFound
+	}
+	return customer, nil
+}
+
+func newTestNotifier(t *testing.T, sender domain.SMSSender) *usecase.Notifier {
+	t.Helper()
+	renderer, err := sms.NewTemplateRenderer("")
+	require.NoError(t, err)
+	return usecase.NewNotifier(renderer, memoryCustomerRepository{}, sms.NewChannel(sender))
+}
+
+// eventRecorder stands in for the event bus and can also subscribe to one.
+type eventRecorder struct {
+	events []domain.Event
+}
+
+func (r *eventRecorder) Publish(_ context.Context, event domain.Event) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *eventRecorder) Handle(ctx context.Context, event domain.Event) error {
+	return r.Publish(ctx, event)
+}
+
+func (r *eventRecorder) names() []string {
+	names := make([]string, len(r.events))
+	for i, event := range r.events {
+		names[i] = event.EventName()
+	}
+	return names
 }
 
 func TestInstallmentCalculator_CalculateInstallment(t *testing.T) {
 	tests := []struct {
 		name           string
 		product        domain.Product
-		setupMocks     func(*MockSMSSender)
 		expectedResult float64
 		expectError    bool
 		errorMessage   string
@@ -37,9 +83,6 @@ func TestInstallmentCalculator_CalculateInstallment(t *testing.T) {
 				PhoneNumber:  "+992001002005",
 				PeriodMonths: 3,
 			},
-			setupMocks: func(m *MockSMSSender) {
-				m.On("SendSMS", "+992001002005", mock.Anything).Return(nil)
-			},
 			expectedResult: 1000,
 			expectError:    false,
 		},
@@ -51,9 +94,6 @@ func TestInstallmentCalculator_CalculateInstallment(t *testing.T) {
 				PhoneNumber:  "+992001002005",
 				PeriodMonths: 6,
 			},
-			setupMocks: func(m *MockSMSSender) {
-				m.On("SendSMS", "+992001002005", mock.Anything).Return(nil)
-			},
 			expectedResult: 3120,
 			expectError:    false,
 		},
@@ -65,9 +105,6 @@ func TestInstallmentCalculator_CalculateInstallment(t *testing.T) {
 				PhoneNumber:  "+992001002005",
 				PeriodMonths: 12,
 			},
-			setupMocks: func(m *MockSMSSender) {
-				m.On("SendSMS", "+992001002005", mock.Anything).Return(nil)
-			},
 			expectedResult: 2300,
 			expectError:    false,
 		},
@@ -79,7 +116,6 @@ func TestInstallmentCalculator_CalculateInstallment(t *testing.T) {
 				PhoneNumber:  "+992001002005",
 				PeriodMonths: 2,
 			},
-			setupMocks:     func(m *MockSMSSender) {},
 			expectedResult: 0,
 			expectError:    true,
 			errorMessage:   "неверный срок рассрочки: для Смартфон допустимый срок от 3 до 9 месяцев",
@@ -92,46 +128,32 @@ func TestInstallmentCalculator_CalculateInstallment(t *testing.T) {
 				PhoneNumber:  "+992001002005",
 				PeriodMonths: 4,
 			},
-			setupMocks:     func(m *MockSMSSender) {},
 			expectedResult: 0,
 			expectError:    true,
 			errorMessage:   "неверный срок рассрочки: допустимые значения: [3 6 9 12 18 24]",
 		},
-		{
-			name: "SMS send failure",
-			product: domain.Product{
-				Type:         domain.Smartphone,
-				Price:        1000,
-				PhoneNumber:  "+992001002005",
-				PeriodMonths: 3,
-			},
-			setupMocks: func(m *MockSMSSender) {
-				m.On("SendSMS", "+992001002005", mock.Anything).Return(errors.New("sms service unavailable"))
-			},
-			expectedResult: 0,
-			expectError:    true,
-			errorMessage:   "не удалось отправить SMS: sms service unavailable",
-		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockSMS := new(MockSMSSender)
-			tt.setupMocks(mockSMS)
-
-			calculator := usecase.NewInstallmentCalculator(mockSMS)
+			events := new(eventRecorder)
+			calculator := usecase.NewInstallmentCalculator(events)
 
-			result, err := calculator.CalculateInstallment(tt.product)
+			result, err := calculator.CalculateInstallment(context.Background(), tt.product)
 
 			if tt.expectError {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.errorMessage)
+				assert.Empty(t, events.events)
 			} else {
 				assert.NoError(t, err)
 				assert.InDelta(t, tt.expectedResult, result, 0.0001, "Expected result to be within 0.0001 of %v, got %v", tt.expectedResult, result)
-			}
 
-			mockSMS.AssertExpectations(t)
+				require.Len(t, events.events, 1)
+				quoted := events.events[0].(domain.InstallmentQuoted)
+				assert.Equal(t, tt.product, quoted.Product)
+				assert.InDelta(t, tt.expectedResult, quoted.TotalPayment, 0.0001)
+			}
 		})
 	}
 }
@@ -186,16 +208,11 @@ func TestInterestCalculation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockSMS := new(MockSMSSender)
-			mockSMS.On("SendSMS", "+992001002005", mock.Anything).Return(nil)
+			calculator := usecase.NewInstallmentCalculator(new(eventRecorder))
 
-			calculator := usecase.NewInstallmentCalculator(mockSMS)
-
-			result, err := calculator.CalculateInstallment(tt.product)
+			result, err := calculator.CalculateInstallment(context.Background(), tt.product)
 			require.NoError(t, err)
 			assert.InDelta(t, tt.expectedAmount, result, 0.01, "Expected amount to be within 0.01 of %v, got %v", tt.expectedAmount, result)
-
-			mockSMS.AssertExpectations(t)
 		})
 	}
 }
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+)
+
+// NotificationSubscriber tells customers about their contracts: the purchase
+// terms, the cancellation and the payoff. It records the sent notifications on
+// the contract carried by the event, so it must be subscribed synchronously.
+type NotificationSubscriber struct {
+	contracts domain.ContractRepository
+	notifier  *Notifier
+	events    domain.EventPublisher
+	now       func() time.Time
+}
+
+func NewNotificationSubscriber(
+	contracts domain.ContractRepository,
+	notifier *Notifier,
+	events domain.EventPublisher,
+) *NotificationSubscriber {
+	return &NotificationSubscriber{
+		contracts: contracts,
+		notifier:  notifier,
+		events:    events,
+		now:       time.Now,
+	}
+}
+
+func (s *NotificationSubscriber) Handle(ctx context.Context, event domain.Event) error {
+	switch event := event.(type) {
+	case domain.InstallmentConfirmed:
+		return s.notify(ctx, event.Contract, domain.EventPurchase)
+	case domain.InstallmentCancelled:
+		return s.notify(ctx, event.Contract, domain.EventCancellation)
+	case domain.PaymentReceived:
+		if event.Contract.IsPaidOff() {
+			return s.notify(ctx, event.Contract, domain.EventPayoff)
+		}
+	}
+	return nil
+}
+
+func (s *NotificationSubscriber) notify(ctx context.Context, contract domain.Contract, event domain.MessageEvent) error {
+	receipts, err := s.notifier.Notify(ctx, contract.Product.PhoneNumber, event, domain.MessageData{
+		ContractID:   contract.ID,
+		Product:      contract.Product.Type,
+		Price:        contract.Product.Price,
+		PeriodMonths: contract.Product.PeriodMonths,
+		Overpayment:  contract.TotalPayment - contract.Product.Price,
+		TotalPayment: contract.TotalPayment,
+		Reason:       contract.CancelReason,
+		Refund:       contract.Refund,
+	})
+	if errors.Is(err, domain.ErrMessageSuppressed) {
+		return nil
+	}
+	if err != nil {
+		failure := domain.NotificationFailed{
+			ContractID:  contract.ID,
+			PhoneNumber: contract.Product.PhoneNumber,
+			Event:       event,
+			Error:       err.Error(),
+			FailedAt:    s.now(),
+		}
+		return errors.Join(
+			fmt.Errorf("не удалось отправить SMS: %w", err),
+			s.events.Publish(ctx, failure),
+		)
+	}
+
+	err = s.contracts.Update(contract.ID, func(contract *domain.Contract) error {
+		for _, receipt := range receipts {
+			contract.RecordNotification(event, receipt)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("не удалось сохранить договор: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/infra/sms"
+	"github.com/icoder-new/installment-cli/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationSubscriber_Handle(t *testing.T) {
+	paidOff := newTestContract(domain.Smartphone, time.Hour)
+	_, err := paidOff.AddPayment(paidOff.Balance(), time.Now())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		event         domain.Event
+		smsErr        error
+		expectedEvent domain.MessageEvent
+		expectedSent  bool
+		errorMessage  string
+	}{
+		{
+			name:          "Purchase terms on confirmation",
+			event:         domain.InstallmentConfirmed{Contract: newTestContract(domain.Smartphone, 0)},
+			expectedEvent: domain.EventPurchase,
+			expectedSent:  true,
+		},
+		{
+			name:          "Payoff after the last payment",
+			event:         domain.PaymentReceived{Contract: paidOff},
+			expectedEvent: domain.EventPayoff,
+			expectedSent:  true,
+		},
+		{
+			name:  "Nothing for a partial payment",
+			event: domain.PaymentReceived{Contract: newTestContract(domain.Smartphone, time.Hour)},
+		},
+		{
+			name:  "Nothing for a quote",
+			event: domain.InstallmentQuoted{Product: newTestContract(domain.Smartphone, 0).Product},
+		},
+		{
+			name:          "SMS send failure",
+			event:         domain.InstallmentConfirmed{Contract: newTestContract(domain.Smartphone, 0)},
+			smsErr:        errors.New("sms service unavailable"),
+			expectedEvent: domain.EventPurchase,
+			errorMessage:  "не удалось отправить SMS: sms service unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockContractRepository)
+			mockSMS := new(MockSMSSender)
+			if tt.expectedEvent != "" {
+				mockSMS.On("SendSMS", mock.Anything, "+992001002005", mock.Anything).Return(testReceipt, tt.smsErr)
+			}
+			if tt.expectedSent {
+				mockRepo.On("FindByID", "C-1").Return(newTestContract(domain.Smartphone, 0), nil)
+				mockRepo.On("Save", mock.MatchedBy(func(c domain.Contract) bool {
+					last := c.Notifications[len(c.Notifications)-1]
+					return last.Event == tt.expectedEvent && last.MessageID == testReceipt.MessageID
+				})).Return(nil)
+			}
+
+			events := new(eventRecorder)
+			subscriber := usecase.NewNotificationSubscriber(mockRepo, newTestNotifier(t, mockSMS), events)
+
+			err := subscriber.Handle(context.Background(), tt.event)
+
+			if tt.errorMessage != "" {
+				assert.ErrorContains(t, err, tt.errorMessage)
+				require.Len(t, events.events, 1)
+				failed := events.events[0].(domain.NotificationFailed)
+				assert.Equal(t, "C-1", failed.ContractID)
+				assert.Equal(t, tt.expectedEvent, failed.Event)
+			} else {
+				require.NoError(t, err)
+				assert.Empty(t, events.events)
+			}
+
+			mockRepo.AssertExpectations(t)
+			mockSMS.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNotificationSubscriber_UsesCustomerLanguage(t *testing.T) {
+	renderer, err := sms.NewTemplateRenderer("")
+	require.NoError(t, err)
+
+	customers := memoryCustomerRepository{}
+	require.NoError(t, usecase.NewCustomerService(customers).SetLanguage("+992001002005", "tg"))
+
+	mockSMS := new(MockSMSSender)
+	mockSMS.On("SendSMS", mock.Anything, "+992001002005", mock.MatchedBy(func(message string) bool {
+		return strings.HasPrefix(message, "Муҳтарам мизоҷ!")
+	})).Return(testReceipt, nil)
+
+	mockRepo := new(MockContractRepository)
+	mockRepo.On("FindByID", "C-1").Return(newTestContract(domain.Smartphone, 0), nil)
+	mockRepo.On("Save", mock.Anything).Return(nil)
+
+	notifier := usecase.NewNotifier(renderer, customers, sms.NewChannel(mockSMS))
+	subscriber := usecase.NewNotificationSubscriber(mockRepo, notifier, new(eventRecorder))
+
+	err = subscriber.Handle(context.Background(), domain.InstallmentConfirmed{
+		Contract: newTestContract(domain.Smartphone, 0),
+	})
+	require.NoError(t, err)
+
+	mockSMS.AssertExpectations(t)
+}
+
+func TestNotificationSubscriber_SkipsOptedOutPayoff(t *testing.T) {
+	contract := newTestContract(domain.Smartphone, time.Hour)
+	_, err := contract.AddPayment(contract.Balance(), time.Now())
+	require.NoError(t, err)
+
+	renderer, err := sms.NewTemplateRenderer("")
+	require.NoError(t, err)
+
+	customers := memoryCustomerRepository{}
+	require.NoError(t, usecase.NewCustomerService(customers).SetOptOut("+992001002005", true))
+
+	mockSMS := new(MockSMSSender)
+	events := new(eventRecorder)
+	notifier := usecase.NewNotifier(renderer, customers, sms.NewChannel(mockSMS))
+	subscriber := usecase.NewNotificationSubscriber(new(MockContractRepository), notifier, events)
+
+	err = subscriber.Handle(context.Background(), domain.PaymentReceived{Contract: contract})
+	require.NoError(t, err)
+	assert.Empty(t, events.events)
+	mockSMS.AssertNotCalled(t, "SendSMS", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+)
+
+// Notifier renders event messages in the language the customer prefers and
+// delivers them over the customer's preferred channels.
+type Notifier struct {
+	renderer  domain.MessageRenderer
+	customers domain.CustomerRepository
+	channels  map[domain.Channel]domain.Notifier
+}
+
+func NewNotifier(renderer domain.MessageRenderer, customers domain.CustomerRepository, channels ...domain.Notifier) *Notifier {
+	byChannel := make(map[domain.Channel]domain.Notifier, len(channels))
+	for _, channel := range channels {
+		byChannel[channel.Channel()] = channel
+	}
+
+	return &Notifier{
+		renderer:  renderer,
+		customers: customers,
+		channels:  byChannel,
+	}
+}
+
+// Notify returns a receipt for every channel that accepted the message. When
+// none of the preferred channels works, SMS is tried as a last resort; an
+// error is returned only if the message went out nowhere.
+func (n *Notifier) Notify(
+	ctx context.Context,
+	phoneNumber string,
+	event domain.MessageEvent,
+	data domain.MessageData,
+) ([]domain.Receipt, error) {
+	customer, err := n.customers.FindByPhone(phoneNumber)
+	if err != nil && !errors.Is(err, domain.ErrCustomerNotFound) {
+		return nil, err
+	}
+	customer.PhoneNumber = phoneNumber
+
+	if customer.OptedOut && !event.IsMandatory() {
+		return nil, domain.ErrMessageSuppressed
+	}
+
+	text, err := n.renderer.Render(customer.Language, event, data)
+	if err != nil {
+		return nil, err
+	}
+
+	message := domain.Message{Event: event, Language: customer.Language, Text: text}
+	ctx = domain.WithMessageEvent(ctx, event)
+
+	preferred := customer.PreferredChannels()
+	channels := preferred
+	if !slices.Contains(channels, domain.ChannelSMS) {
+		channels = append(slices.Clone(channels), domain.ChannelSMS)
+	}
+
+	var receipts []domain.Receipt
+	var errs []error
+	for i, channel := range channels {
+		if i >= len(preferred) && len(receipts) > 0 {
+			break
+		}
+
+		notifier, ok := n.channels[channel]
+		if !ok || !customer.CanReceive(channel) {
+			errs = append(errs, fmt.Errorf("%w: %s", domain.ErrNoChannel, channel))
+			continue
+		}
+
+		receipt, err := notifier.Notify(ctx, customer, message)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	if len(receipts) == 0 {
+		return nil, errors.Join(errs...)
+	}
+	return receipts, nil
+}
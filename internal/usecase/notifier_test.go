@@ -0,0 +1,150 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/infra/sms"
+	"github.com/icoder-new/installment-cli/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeChannel struct {
+	channel  domain.Channel
+	err      error
+	messages []domain.Message
+}
+
+func (c *fakeChannel) Channel() domain.Channel {
+	return c.channel
+}
+
+func (c *fakeChannel) Notify(ctx context.Context, customer domain.Customer, message domain.Message) (domain.Receipt, error) {
+	c.messages = append(c.messages, message)
+	if c.err != nil {
+		return domain.Receipt{}, c.err
+	}
+	return domain.Receipt{Channel: c.channel, MessageID: string(c.channel) + "-1"}, nil
+}
+
+func TestNotifier_Notify(t *testing.T) {
+	const phone = "+992001002005"
+
+	tests := []struct {
+		name             string
+		customer         *domain.Customer
+		event            domain.MessageEvent
+		emailErr         error
+		smsErr           error
+		expectedChannels []domain.Channel
+		expectError      error
+	}{
+		{
+			name:             "Unknown customer gets SMS",
+			event:            domain.EventPurchase,
+			expectedChannels: []domain.Channel{domain.ChannelSMS},
+		},
+		{
+			name:             "Every preferred channel",
+			customer:         &domain.Customer{Email: "client@example.tj", Channels: []domain.Channel{domain.ChannelEmail, domain.ChannelSMS}},
+			event:            domain.EventPurchase,
+			expectedChannels: []domain.Channel{domain.ChannelEmail, domain.ChannelSMS},
+		},
+		{
+			name:             "Email only",
+			customer:         &domain.Customer{Email: "client@example.tj", Channels: []domain.Channel{domain.ChannelEmail}},
+			event:            domain.EventPurchase,
+			expectedChannels: []domain.Channel{domain.ChannelEmail},
+		},
+		{
+			name:             "SMS as a last resort",
+			customer:         &domain.Customer{Email: "client@example.tj", Channels: []domain.Channel{domain.ChannelEmail}},
+			event:            domain.EventPurchase,
+			emailErr:         errors.New("smtp down"),
+			expectedChannels: []domain.Channel{domain.ChannelSMS},
+		},
+		{
+			name:             "Channel without a configured notifier",
+			customer:         &domain.Customer{MessengerID: "@client", Channels: []domain.Channel{domain.ChannelMessenger}},
+			event:            domain.EventPurchase,
+			expectedChannels: []domain.Channel{domain.ChannelSMS},
+		},
+		{
+			name:        "Opted-out customer gets no reminders",
+			customer:    &domain.Customer{OptedOut: true},
+			event:       domain.EventReminder,
+			expectError: domain.ErrMessageSuppressed,
+		},
+		{
+			name:             "Opted-out customer still gets debt notices",
+			customer:         &domain.Customer{OptedOut: true},
+			event:            domain.EventOverdue,
+			expectedChannels: []domain.Channel{domain.ChannelSMS},
+		},
+		{
+			name:        "Nothing delivered",
+			customer:    &domain.Customer{Email: "client@example.tj", Channels: []domain.Channel{domain.ChannelEmail}},
+			event:       domain.EventPurchase,
+			emailErr:    errors.New("smtp down"),
+			smsErr:      errors.New("gateway down"),
+			expectError: errors.New("smtp down\ngateway down"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			customers := memoryCustomerRepository{}
+			if tt.customer != nil {
+				customer := *tt.customer
+				customer.PhoneNumber = phone
+				require.NoError(t, customers.Save(customer))
+			}
+
+			renderer, err := sms.NewTemplateRenderer("")
+			require.NoError(t, err)
+
+			smsChannel := &fakeChannel{channel: domain.ChannelSMS, err: tt.smsErr}
+			emailChannel := &fakeChannel{channel: domain.ChannelEmail, err: tt.emailErr}
+			notifier := usecase.NewNotifier(renderer, customers, smsChannel, emailChannel)
+
+			receipts, err := notifier.Notify(context.Background(), phone, tt.event, domain.MessageData{})
+			if tt.expectError != nil {
+				if errors.Is(tt.expectError, domain.ErrMessageSuppressed) {
+					assert.ErrorIs(t, err, tt.expectError)
+				} else {
+					assert.EqualError(t, err, tt.expectError.Error())
+				}
+				assert.Empty(t, receipts)
+				return
+			}
+			require.NoError(t, err)
+
+			var channels []domain.Channel
+			for _, receipt := range receipts {
+				channels = append(channels, receipt.Channel)
+			}
+			assert.Equal(t, tt.expectedChannels, channels)
+		})
+	}
+}
+
+func TestCustomerService_SetChannels(t *testing.T) {
+	customers := memoryCustomerRepository{}
+	service := usecase.NewCustomerService(customers)
+
+	err := service.SetChannels("+992001002005", []domain.Channel{domain.ChannelEmail})
+	assert.ErrorIs(t, err, domain.ErrNoChannel, "an email address is needed first")
+
+	assert.ErrorIs(t, service.SetEmail("+992001002005", "not an address"), domain.ErrInvalidEmail)
+
+	require.NoError(t, service.SetEmail("+992001002005", "Client <client@example.tj>"))
+	require.NoError(t, service.SetChannels("+992001002005", []domain.Channel{domain.ChannelEmail}))
+
+	customer, err := service.Find("+992001002005")
+	require.NoError(t, err)
+	assert.Equal(t, "client@example.tj", customer.Email)
+	assert.Equal(t, []domain.Channel{domain.ChannelEmail}, customer.PreferredChannels())
+}
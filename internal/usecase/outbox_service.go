@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+)
+
+type OutboxService struct {
+	outbox    domain.SMSOutbox
+	smsSender domain.SMSSender
+	contracts domain.ContractRepository
+	now       func() time.Time
+}
+
+func NewOutboxService(outbox domain.SMSOutbox, smsSender domain.SMSSender, contracts domain.ContractRepository) *OutboxService {
+	return &OutboxService{
+		outbox:    outbox,
+		smsSender: smsSender,
+		contracts: contracts,
+		now:       time.Now,
+	}
+}
+
+// Flush sends the queued messages that are due and points the contract
+// notifications at the messages that actually went out. It returns how many
+// messages were handed to a provider; the rest were dropped or queued again.
+func (uc *OutboxService) Flush(ctx context.Context) (int, error) {
+	due, err := uc.outbox.Due(uc.now())
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var errs []error
+	for _, queued := range due {
+		if err := ctx.Err(); err != nil {
+			return sent, err
+		}
+
+		sendCtx := ctx
+		if queued.Event != "" {
+			sendCtx = domain.WithMessageEvent(ctx, queued.Event)
+		}
+
+		receipt, err := uc.smsSender.SendSMS(sendCtx, queued.PhoneNumber, queued.Message)
+		suppressed := errors.Is(err, domain.ErrMessageSuppressed)
+		if err != nil && !suppressed {
+			errs = append(errs, fmt.Errorf("не удалось отправить смс %s: %w", queued.ID, err))
+			continue
+		}
+
+		if err := uc.outbox.Remove(queued.ID); err != nil {
+			return sent, err
+		}
+
+		if suppressed {
+			err = uc.updateContract(queued.ID, func(contract *domain.Contract) bool {
+				return contract.UpdateDelivery(queued.ID, domain.DeliveryFailed, uc.now(), domain.ErrMessageSuppressed.Error())
+			})
+		} else {
+			if receipt.ScheduledAt.IsZero() {
+				sent++
+			}
+			err = uc.updateContract(queued.ID, func(contract *domain.Contract) bool {
+				return contract.ReplaceMessage(queued.ID, receipt)
+			})
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return sent, errors.Join(errs...)
+}
+
+func (uc *OutboxService) updateContract(messageID string, update func(*domain.Contract) bool) error {
+	contracts, err := uc.contracts.FindAll()
+	if err != nil {
+		return err
+	}
+
+	for _, contract := range contracts {
+		if !contract.HasMessage(messageID) {
+			continue
+		}
+		return uc.contracts.Update(contract.ID, func(contract *domain.Contract) error {
+			update(contract)
+			return nil
+		})
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryOutbox map[string]domain.QueuedSMS
+
+func (o memoryOutbox) Enqueue(message domain.QueuedSMS) error {
+	o[message.ID] = message
+	return nil
+}
+
+func (o memoryOutbox) Due(now time.Time) ([]domain.QueuedSMS, error) {
+	var due []domain.QueuedSMS
+	for _, message := range o {
+		if !message.NotBefore.After(now) {
+			due = append(due, message)
+		}
+	}
+	return due, nil
+}
+
+func (o memoryOutbox) Remove(id string) error {
+	delete(o, id)
+	return nil
+}
+
+func TestOutboxService_Flush(t *testing.T) {
+	now := time.Now()
+	contract := newTestContract(domain.Computer, time.Hour)
+	contract.RecordNotification(domain.EventReminder, domain.Receipt{
+		Channel:     domain.ChannelSMS,
+		MessageID:   "queued-1",
+		AcceptedAt:  now.Add(-time.Hour),
+		ScheduledAt: now.Add(-time.Minute),
+	})
+	contract.RecordNotification(domain.EventPayoff, domain.Receipt{
+		MessageID:   "queued-2",
+		AcceptedAt:  now.Add(-time.Hour),
+		ScheduledAt: now.Add(-time.Minute),
+	})
+	require.Equal(t, domain.DeliveryQueued, contract.Notifications[0].Status)
+
+	outbox := memoryOutbox{
+		"queued-1": {ID: "queued-1", PhoneNumber: "+992001002005", Message: "reminder", Event: domain.EventReminder, NotBefore: now.Add(-time.Minute)},
+		"queued-2": {ID: "queued-2", PhoneNumber: "+992001002005", Message: "payoff", Event: domain.EventPayoff, NotBefore: now.Add(-time.Minute)},
+		"queued-3": {ID: "queued-3", PhoneNumber: "+992001002005", Message: "later", Event: domain.EventReminder, NotBefore: now.Add(time.Hour)},
+	}
+
+	mockSMS := new(MockSMSSender)
+	mockSMS.On("SendSMS", mock.MatchedBy(func(ctx context.Context) bool {
+		event, ok := domain.MessageEventFromContext(ctx)
+		return ok && event == domain.EventReminder
+	}), "+992001002005", "reminder").Return(testReceipt, nil).Once()
+	mockSMS.On("SendSMS", mock.Anything, "+992001002005", "payoff").Return(domain.Receipt{}, domain.ErrMessageSuppressed).Once()
+
+	var saved domain.Contract
+	mockRepo := new(MockContractRepository)
+	mockRepo.On("FindAll").Return([]domain.Contract{contract}, nil)
+	mockRepo.On("FindByID", contract.ID).Return(contract, nil).Once()
+	mockRepo.On("Save", mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(0).(domain.Contract)
+	}).Return(nil).Twice()
+
+	sent, err := usecase.NewOutboxService(outbox, mockSMS, mockRepo).Flush(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, []string{"queued-3"}, keys(outbox))
+	assert.Equal(t, testReceipt.MessageID, saved.Notifications[0].MessageID)
+	assert.Equal(t, domain.DeliveryPending, saved.Notifications[0].Status)
+	assert.Equal(t, domain.ChannelSMS, saved.Notifications[0].Channel, "the raw SMS receipt has no channel")
+	assert.Equal(t, domain.DeliveryFailed, saved.Notifications[1].Status)
+
+	mockSMS.AssertExpectations(t)
+	mockRepo.AssertNumberOfCalls(t, "Save", 2)
+}
+
+func keys(outbox memoryOutbox) []string {
+	var ids []string
+	for id := range outbox {
+		ids = append(ids, id)
+	}
+	return ids
+}
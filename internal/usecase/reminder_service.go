@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+)
+
+type ReminderService struct {
+	contracts domain.ContractRepository
+	reminders domain.ReminderLog
+	notifier  *Notifier
+	now       func() time.Time
+}
+
+func NewReminderService(
+	contracts domain.ContractRepository,
+	reminders domain.ReminderLog,
+	notifier *Notifier,
+) *ReminderService {
+	return &ReminderService{
+		contracts: contracts,
+		reminders: reminders,
+		notifier:  notifier,
+		now:       time.Now,
+	}
+}
+
+// SendReminders texts customers whose unpaid installments fall due within
+// daysBefore days or have been overdue for at least daysOverdue days. It
+// returns the number of reminders sent; a failed send does not stop the run
+// and is tried again by the next run.
+func (uc *ReminderService) SendReminders(ctx context.Context, daysBefore, daysOverdue int) (int, error) {
+	contracts, err := uc.contracts.FindAll()
+	if err != nil {
+		return 0, err
+	}
+
+	today := truncateToDay(uc.now())
+	sent := 0
+	var errs []error
+
+	active := domain.ActiveContracts(contracts)
+	for i := range active {
+		contract := &active[i]
+
+		for _, installment := range contract.Schedule() {
+			if err := ctx.Err(); err != nil {
+				return sent, errors.Join(append(errs, err)...)
+			}
+
+			if installment.Paid {
+				continue
+			}
+
+			days := int(truncateToDay(installment.DueDate).Sub(today).Hours() / 24)
+
+			var kind domain.ReminderKind
+			switch {
+			case days >= 0 && days <= daysBefore:
+				kind = domain.ReminderDue
+			case days < 0 && -days >= daysOverdue:
+				kind = domain.ReminderOverdue
+			default:
+				continue
+			}
+
+			ok, err := uc.remind(ctx, contract, installment, kind, days)
+			if ok {
+				sent++
+			}
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return sent, errors.Join(errs...)
+}
+
+func (uc *ReminderService) remind(
+	ctx context.Context,
+	contract *domain.Contract,
+	installment domain.Installment,
+	kind domain.ReminderKind,
+	days int,
+) (bool, error) {
+	key := domain.ReminderKey(contract.ID, installment.Number, kind)
+
+	// Claiming first means a reminder that cannot be logged is not sent at
+	// all, rather than sent again by the next run.
+	claimed, err := uc.reminders.Claim(key, uc.now())
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	event := domain.EventReminder
+	if kind == domain.ReminderOverdue {
+		event = domain.EventOverdue
+		days = -days
+	}
+
+	receipts, err := uc.notifier.Notify(ctx, contract.Product.PhoneNumber, event, domain.MessageData{
+		ContractID:        contract.ID,
+		Product:           contract.Product.Type,
+		Price:             contract.Product.Price,
+		PeriodMonths:      contract.Product.PeriodMonths,
+		TotalPayment:      contract.TotalPayment,
+		InstallmentNumber: installment.Number,
+		DueDate:           installment.DueDate,
+		Amount:            installment.Amount,
+		Days:              days,
+	})
+	if errors.Is(err, domain.ErrMessageSuppressed) {
+		// The customer opted out: keep the claim so the reminder is not tried
+		// again.
+		return false, nil
+	}
+	if err != nil {
+		err = fmt.Errorf("не удалось отправить напоминание %s: %w", key, err)
+		return false, errors.Join(err, uc.reminders.Release(key))
+	}
+
+	return true, uc.contracts.Update(contract.ID, func(contract *domain.Contract) error {
+		for _, receipt := range receipts {
+			contract.RecordNotification(event, receipt)
+		}
+		return nil
+	})
+}
+
+func truncateToDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
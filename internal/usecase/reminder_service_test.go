@@ -0,0 +1,166 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/icoder-new/installment-cli/internal/domain"
+	"github.com/icoder-new/installment-cli/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryReminderLog map[string]time.Time
+
+func (l memoryReminderLog) Claim(key string, at time.Time) (bool, error) {
+	if _, ok := l[key]; ok {
+		return false, nil
+	}
+	l[key] = at
+	return true, nil
+}
+
+func (l memoryReminderLog) Release(key string) error {
+	delete(l, key)
+	return nil
+}
+
+type failingReminderLog struct{}
+
+func (failingReminderLog) Claim(string, time.Time) (bool, error) {
+	return false, errors.New("диск заполнен")
+}
+
+func (failingReminderLog) Release(string) error {
+	return errors.New("диск заполнен")
+}
+
+// reminderNow is the end of a month, where shifting dates by a month is
+// easiest to get wrong.
+var reminderNow = time.Date(2025, time.March, 30, 12, 0, 0, 0, time.UTC)
+
+func newReminderService(t *testing.T, repo *MockContractRepository, log domain.ReminderLog, sms *MockSMSSender) *usecase.ReminderService {
+	t.Helper()
+
+	service := usecase.NewReminderService(repo, log, newTestNotifier(t, sms))
+	service.SetNow(func() time.Time { return reminderNow })
+	return service
+}
+
+func newReminderContract(id string, createdAt time.Time, payments ...domain.Payment) domain.Contract {
+	product := domain.Product{
+		Type:         domain.Computer,
+		Price:        3000,
+		PhoneNumber:  "+992001002005",
+		PeriodMonths: 6,
+	}
+	contract := domain.NewContract(id, product, 3120, createdAt)
+	contract.Payments = payments
+	return contract
+}
+
+func TestReminderService_SendReminders(t *testing.T) {
+	createdDueSoon := time.Date(2025, time.March, 1, 10, 0, 0, 0, time.UTC)
+	dueSoon := newReminderContract("DUE", createdDueSoon)
+	overdue := newReminderContract("LATE", time.Date(2025, time.February, 25, 10, 0, 0, 0, time.UTC))
+	paid := newReminderContract("PAID", createdDueSoon, domain.Payment{Amount: 520, PaidAt: reminderNow})
+	cancelled := newReminderContract("CANCELLED", createdDueSoon)
+	cancelled.Status = domain.ContractCancelled
+
+	mockRepo := new(MockContractRepository)
+	mockRepo.On("FindAll").Return([]domain.Contract{dueSoon, overdue, paid, cancelled}, nil)
+	mockRepo.On("FindByID", "DUE").Return(dueSoon, nil).Once()
+	mockRepo.On("FindByID", "LATE").Return(overdue, nil).Once()
+	mockRepo.On("Save", mock.MatchedBy(func(contract domain.Contract) bool {
+		return len(contract.Notifications) == 1 && contract.Notifications[0].MessageID == testReceipt.MessageID
+	})).Return(nil).Times(2)
+
+	mockSMS := new(MockSMSSender)
+	mockSMS.On("SendSMS", mock.Anything, "+992001002005", mock.Anything).Return(testReceipt, nil).Times(2)
+
+	reminderLog := memoryReminderLog{}
+	service := newReminderService(t, mockRepo, reminderLog, mockSMS)
+
+	sent, err := service.SendReminders(context.Background(), 3, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	assert.Contains(t, reminderLog, domain.ReminderKey("DUE", 1, domain.ReminderDue))
+	assert.Contains(t, reminderLog, domain.ReminderKey("LATE", 1, domain.ReminderOverdue))
+
+	sent, err = service.SendReminders(context.Background(), 3, 1)
+	require.NoError(t, err)
+	assert.Zero(t, sent, "reminders must not be sent twice")
+
+	mockSMS.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestReminderService_SkipsOptedOutCustomers(t *testing.T) {
+	dueSoon := newReminderContract("DUE", time.Date(2025, time.March, 1, 10, 0, 0, 0, time.UTC))
+
+	mockRepo := new(MockContractRepository)
+	mockRepo.On("FindAll").Return([]domain.Contract{dueSoon}, nil)
+
+	mockSMS := new(MockSMSSender)
+	mockSMS.On("SendSMS", mock.Anything, "+992001002005", mock.Anything).Return(domain.Receipt{}, domain.ErrMessageSuppressed).Once()
+
+	reminderLog := memoryReminderLog{}
+	service := newReminderService(t, mockRepo, reminderLog, mockSMS)
+
+	sent, err := service.SendReminders(context.Background(), 3, 1)
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+	assert.Contains(t, reminderLog, domain.ReminderKey("DUE", 1, domain.ReminderDue), "a suppressed reminder is not retried")
+
+	mockSMS.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestReminderService_DoesNotSendWhenLogFails(t *testing.T) {
+	dueSoon := newReminderContract("DUE", time.Date(2025, time.March, 1, 10, 0, 0, 0, time.UTC))
+
+	mockRepo := new(MockContractRepository)
+	mockRepo.On("FindAll").Return([]domain.Contract{dueSoon}, nil)
+
+	mockSMS := new(MockSMSSender)
+
+	service := newReminderService(t, mockRepo, failingReminderLog{}, mockSMS)
+
+	sent, err := service.SendReminders(context.Background(), 3, 1)
+	require.ErrorContains(t, err, "диск заполнен")
+	assert.Zero(t, sent)
+
+	mockSMS.AssertNotCalled(t, "SendSMS", mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestReminderService_RetriesFailedSends(t *testing.T) {
+	dueSoon := newReminderContract("DUE", time.Date(2025, time.March, 1, 10, 0, 0, 0, time.UTC))
+
+	mockRepo := new(MockContractRepository)
+	mockRepo.On("FindAll").Return([]domain.Contract{dueSoon}, nil)
+	mockRepo.On("FindByID", "DUE").Return(dueSoon, nil).Once()
+	mockRepo.On("Save", mock.Anything).Return(nil).Once()
+
+	mockSMS := new(MockSMSSender)
+	mockSMS.On("SendSMS", mock.Anything, "+992001002005", mock.Anything).Return(domain.Receipt{}, errors.New("gateway down")).Once()
+	mockSMS.On("SendSMS", mock.Anything, "+992001002005", mock.Anything).Return(testReceipt, nil).Once()
+
+	reminderLog := memoryReminderLog{}
+	service := newReminderService(t, mockRepo, reminderLog, mockSMS)
+
+	sent, err := service.SendReminders(context.Background(), 3, 1)
+	require.ErrorContains(t, err, "gateway down")
+	assert.Zero(t, sent)
+	assert.Empty(t, reminderLog, "a failed send releases its claim")
+
+	sent, err = service.SendReminders(context.Background(), 3, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+
+	mockSMS.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}